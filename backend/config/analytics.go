@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+const defaultAnalyticsCacheTTL = 5 * time.Minute
+
+// LoadAnalyticsCacheTTL คืนค่าระยะเวลาที่เก็บผลสถิติไว้ใน cache
+// กำหนดผ่าน ANALYTICS_CACHE_TTL (ค่าเริ่มต้น 5m) ตั้งเป็น "0" เพื่อปิด cache
+func LoadAnalyticsCacheTTL() time.Duration {
+	value := os.Getenv("ANALYTICS_CACHE_TTL")
+	if value == "" {
+		return defaultAnalyticsCacheTTL
+	}
+	if value == "0" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		log.Printf("Warning: invalid ANALYTICS_CACHE_TTL, using %s", defaultAnalyticsCacheTTL)
+		return defaultAnalyticsCacheTTL
+	}
+	return ttl
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// ฟิลด์ที่เปิดเผยในประกาศผลสาธารณะได้ (student_number ต้องเปิดเองผ่าน env)
+const (
+	PublicFieldPrefix        = "prefix"
+	PublicFieldName          = "name"
+	PublicFieldFaculty       = "faculty"
+	PublicFieldAwardType     = "award_type"
+	PublicFieldStudentNumber = "student_number"
+)
+
+var publicAnnouncementFields = map[string]bool{
+	PublicFieldPrefix:        true,
+	PublicFieldName:          true,
+	PublicFieldFaculty:       true,
+	PublicFieldAwardType:     true,
+	PublicFieldStudentNumber: true,
+}
+
+// PublicAnnouncementConfig การตั้งค่าหน้าประกาศผลสาธารณะ
+type PublicAnnouncementConfig struct {
+	// Fields ฟิลด์ที่ได้รับความยินยอมให้เปิดเผย
+	Fields []string
+	// CacheMaxAge ค่า max-age ของ Cache-Control
+	CacheMaxAge time.Duration
+}
+
+// LoadPublicAnnouncementConfig อ่านค่าจาก PUBLIC_ANNOUNCEMENT_FIELDS (ค่าเริ่มต้น "prefix,name,faculty,award_type")
+// และ PUBLIC_ANNOUNCEMENT_CACHE_MAX_AGE (5m, "0" = no-cache)
+func LoadPublicAnnouncementConfig() PublicAnnouncementConfig {
+	cfg := PublicAnnouncementConfig{
+		Fields:      []string{PublicFieldPrefix, PublicFieldName, PublicFieldFaculty, PublicFieldAwardType},
+		CacheMaxAge: 5 * time.Minute,
+	}
+
+	if value := os.Getenv("PUBLIC_ANNOUNCEMENT_FIELDS"); value != "" {
+		fields := make([]string, 0)
+		seen := make(map[string]bool)
+		for _, field := range strings.Split(value, ",") {
+			field = strings.ToLower(strings.TrimSpace(field))
+			if field == "" || seen[field] {
+				continue
+			}
+			if !publicAnnouncementFields[field] {
+				log.Printf("Warning: unknown PUBLIC_ANNOUNCEMENT_FIELDS entry %q ignored", field)
+				continue
+			}
+			seen[field] = true
+			fields = append(fields, field)
+		}
+		cfg.Fields = fields
+	}
+	if value := os.Getenv("PUBLIC_ANNOUNCEMENT_CACHE_MAX_AGE"); value != "" {
+		d, err := time.ParseDuration(value)
+		if value == "0" {
+			d, err = 0, nil
+		}
+		if err != nil || d < 0 {
+			log.Println("Warning: invalid PUBLIC_ANNOUNCEMENT_CACHE_MAX_AGE, using 5m")
+		} else {
+			cfg.CacheMaxAge = d
+		}
+	}
+	return cfg
+}
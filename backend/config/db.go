@@ -1,8 +1,11 @@
 package config
 
 import (
+	"backend/internal/fieldcrypto"
+	"context"
 	"fmt"
 	"os"
+	"reflect"
 	// "strings"
 	"log"
 
@@ -11,8 +14,9 @@ import (
 	"gorm.io/gorm/logger" // เพิ่มอันนี้
 )
 
-func ConnectDB() *gorm.DB {
-	dsn := fmt.Sprintf(
+// LoadDSN สร้าง connection string จาก env (ใช้ร่วมกับ connection ที่ไม่ผ่าน gorm เช่น LISTEN ของ real-time)
+func LoadDSN() string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_USER"),
@@ -20,10 +24,14 @@ func ConnectDB() *gorm.DB {
 		os.Getenv("DB_NAME"),
 		os.Getenv("DB_PORT"),
 	)
+}
+
+func ConnectDB() *gorm.DB {
+	dsn := LoadDSN()
 	
 	// เพิ่ม Logger: Info เพื่อดู SQL ทุกคำสั่งที่ส่งไป DB
     db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-        Logger: logger.Default.LogMode(logger.Info),
+        Logger: redactingLogger{logger.Default.LogMode(logger.Info)},
         DisableForeignKeyConstraintWhenMigrating: true, 
     })
 
@@ -32,3 +40,47 @@ func ConnectDB() *gorm.DB {
 	}
 	return db
 }
+
+// redactingLogger ซ่อนค่าที่เข้ารหัสแล้วใน SQL log เพื่อไม่ให้ข้อมูลส่วนบุคคล (แม้เป็น ciphertext) ไปอยู่ใน log
+type redactingLogger struct {
+	logger.Interface
+}
+
+func (l redactingLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return redactingLogger{l.Interface.LogMode(level)}
+}
+
+// ParamsFilter ถูกเรียกโดย gorm ก่อนประกอบ SQL สำหรับ log
+// ซ่อนตามชนิดของพารามิเตอร์ ไม่เรียก Value() เพื่อไม่ให้ต้องเข้ารหัสค่าซ้ำทุกครั้งที่เขียน log
+func (l redactingLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	redacted := make([]interface{}, len(params))
+	for i, param := range params {
+		redacted[i] = param
+		if isEncryptedFieldParam(param) {
+			redacted[i] = "[REDACTED]"
+		} else if s, ok := param.(string); ok && fieldcrypto.IsEncrypted(s) {
+			redacted[i] = "[REDACTED]"
+		}
+	}
+	return sql, redacted
+}
+
+// isEncryptedFieldParam พารามิเตอร์ของฟิลด์ serializer:encrypted (gorm ส่งมาเป็น schema.serializer ที่ยังไม่เข้ารหัส)
+func isEncryptedFieldParam(param interface{}) bool {
+	value := reflect.ValueOf(param)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return false
+	}
+	valuer := value.FieldByName("SerializeValuer")
+	if !valuer.IsValid() || valuer.Kind() != reflect.Interface || valuer.IsNil() || !valuer.CanInterface() {
+		return false
+	}
+	switch valuer.Interface().(type) {
+	case fieldcrypto.EncryptedSerializer, *fieldcrypto.EncryptedSerializer:
+		return true
+	}
+	return false
+}
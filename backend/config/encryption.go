@@ -0,0 +1,14 @@
+package config
+
+import "os"
+
+const defaultFieldEncryptionKeyFile = "keys/field-encryption.json"
+
+// LoadFieldEncryptionKeyFile คืนค่า path ของไฟล์ key สำหรับเข้ารหัสข้อมูลส่วนบุคคลในฟอร์ม
+// กำหนดผ่าน FIELD_ENCRYPTION_KEYFILE (ค่าเริ่มต้น keys/field-encryption.json) ต้องสร้างไฟล์ก่อนด้วย "./main init-field-key"
+func LoadFieldEncryptionKeyFile() string {
+	if path := os.Getenv("FIELD_ENCRYPTION_KEYFILE"); path != "" {
+		return path
+	}
+	return defaultFieldEncryptionKeyFile
+}
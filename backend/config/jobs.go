@@ -0,0 +1,62 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// JobWorkerConfig การตั้งค่า worker ของคิวงานเบื้องหลัง
+type JobWorkerConfig struct {
+	Concurrency  int
+	PollInterval time.Duration
+	LockTimeout  time.Duration
+	// Embedded ให้ API server รัน worker ในตัวด้วย (ปิดได้เมื่อแยกรัน "./main worker" ต่างหาก)
+	Embedded bool
+}
+
+// LoadJobWorkerConfig อ่านค่าจาก JOB_WORKER_CONCURRENCY (ค่าเริ่มต้น 2), JOB_POLL_INTERVAL (2s),
+// JOB_LOCK_TIMEOUT (15m) และ JOB_WORKER_EMBEDDED (true)
+func LoadJobWorkerConfig() JobWorkerConfig {
+	cfg := JobWorkerConfig{
+		Concurrency:  2,
+		PollInterval: 2 * time.Second,
+		LockTimeout:  15 * time.Minute,
+		Embedded:     true,
+	}
+
+	if value := os.Getenv("JOB_WORKER_CONCURRENCY"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 64 {
+			log.Println("Warning: JOB_WORKER_CONCURRENCY must be between 1 and 64, using 2")
+		} else {
+			cfg.Concurrency = n
+		}
+	}
+	if value := os.Getenv("JOB_POLL_INTERVAL"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 100*time.Millisecond {
+			log.Println("Warning: JOB_POLL_INTERVAL must be a duration of at least 100ms, using 2s")
+		} else {
+			cfg.PollInterval = d
+		}
+	}
+	if value := os.Getenv("JOB_LOCK_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < time.Minute {
+			log.Println("Warning: JOB_LOCK_TIMEOUT must be a duration of at least 1m, using 15m")
+		} else {
+			cfg.LockTimeout = d
+		}
+	}
+	if value := os.Getenv("JOB_WORKER_EMBEDDED"); value != "" {
+		embedded, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Println("Warning: JOB_WORKER_EMBEDDED must be true or false, using true")
+		} else {
+			cfg.Embedded = embedded
+		}
+	}
+	return cfg
+}
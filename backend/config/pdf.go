@@ -0,0 +1,8 @@
+package config
+
+import "os"
+
+// LoadPDFFontPath คืนค่า path ของฟอนต์ TTF ที่ใช้สร้าง PDF (ต้องรองรับภาษาไทย เช่น THSarabunNew.ttf)
+func LoadPDFFontPath() string {
+	return os.Getenv("PDF_FONT_PATH")
+}
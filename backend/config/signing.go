@@ -0,0 +1,29 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log"
+	"os"
+)
+
+// LoadSigningMasterKey คืนค่า master key (32 bytes) สำหรับเข้ารหัส private key ของผู้ลงนาม
+// กำหนดผ่าน SIGNING_MASTER_KEY (base64 ของ 32 bytes) ถ้าไม่กำหนดหรือไม่ถูกต้องจะคืน error ให้ระบบหยุดตอนเริ่ม
+// ใช้ key dev ได้เฉพาะเมื่อตั้ง SIGNING_ALLOW_DEV_KEY=true (ห้ามใช้ใน production)
+func LoadSigningMasterKey() ([]byte, error) {
+	encoded := os.Getenv("SIGNING_MASTER_KEY")
+	if encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, errors.New("SIGNING_MASTER_KEY must be base64 of 32 bytes")
+		}
+		return key, nil
+	}
+	if os.Getenv("SIGNING_ALLOW_DEV_KEY") != "true" {
+		return nil, errors.New("SIGNING_MASTER_KEY is not set (set SIGNING_ALLOW_DEV_KEY=true to use the dev key in development)")
+	}
+	log.Println("Warning: SIGNING_MASTER_KEY not set, using dev signing key (SIGNING_ALLOW_DEV_KEY=true)")
+	sum := sha256.Sum256([]byte("dev-signing-master-key"))
+	return sum[:], nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import "os"
+
+// SMTPConfig ค่าที่ใช้ส่งอีเมลแจ้งเตือน ถ้าไม่กำหนด SMTP_HOST จะไม่เปิดช่องทางอีเมล
+// สำหรับทดสอบในเครื่องใช้ SMTP sink เช่น mailpit (SMTP_HOST=localhost, SMTP_PORT=1025)
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func LoadSMTPConfig() SMTPConfig {
+	cfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	if cfg.From == "" {
+		cfg.From = "no-reply@localhost"
+	}
+	return cfg
+}
+
+// LoadFrontendBaseURL URL ของหน้าเว็บ ใช้สร้างลิงก์ในการแจ้งเตือน
+func LoadFrontendBaseURL() string {
+	return os.Getenv("FRONTEND_BASE_URL")
+}
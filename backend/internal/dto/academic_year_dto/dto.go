@@ -1,23 +1,108 @@
 package academicyear
 
-import "time"
+import (
+	"backend/internal/models"
+	"time"
+)
 
+// CreateAcademicYear ไม่ส่ง campus_id (หรือ 0) = เพิ่มภาคเรียนในปฏิทินกลาง
+// semester ไม่ส่ง = ภาคการศึกษาถัดไปที่ข้ามไม่ได้ ส่งเพื่อเลือกภาคที่ข้ามได้ (เช่น ภาคฤดูร้อน)
 type CreateAcademicYear struct {
+	CampusID  int       `json:"campus_id"`
+	Semester  int       `json:"semester"`
 	StartDate time.Time `json:"start_date" binding:"required"`
 	EndDate   time.Time `json:"end_date" binding:"required"`
 }
 
 type UpdateAcademicYear struct {
 	Year      int       `json:"year" binding:"required"`
-	Semester  int       `json:"semester" binding:"required,min=1,max=2"`
+	Semester  int       `json:"semester" binding:"required,min=1"`
 	StartDate time.Time `json:"start_date" binding:"required"`
 	EndDate   time.Time `json:"end_date" binding:"required"`
 }
 
 type AcademicYearResponse struct {
 	AcademicYearID uint      `json:"academic_year_id"`
+	CampusID       int       `json:"campus_id"`
 	Year           int       `json:"year"`
 	Semester       int       `json:"semester"`
+	Status         string    `json:"status"`
 	StartDate      time.Time `json:"start_date"`
 	EndDate        time.Time `json:"end_date"`
 }
+
+// ChangeStatusRequest status: open, review, closed, archived
+// expire_pending = true ปิดภาคเรียนได้แม้ยังมีฟอร์มค้าง (ฟอร์มที่ค้างถูกปิดเป็นสถานะหมดเวลา)
+type ChangeStatusRequest struct {
+	Status        string `json:"status"`
+	ExpirePending bool   `json:"expire_pending"`
+	Note          string `json:"note"`
+}
+
+// RolloverRequest วันเริ่ม/สิ้นสุดของภาคเรียนใหม่ ช่วงเวลาที่คัดลอกมาถูกเลื่อนตามวันเริ่ม
+// semester เลือกภาคการศึกษาถัดไปเหมือน CreateAcademicYear
+type RolloverRequest struct {
+	Semester  int       `json:"semester"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Note      string    `json:"note"`
+}
+
+type TermSummaryResponse struct {
+	AcademicYear AcademicYearResponse           `json:"academic_year"`
+	Summary      models.TermSummary             `json:"summary"`
+	Committee    []models.AcademicYearCommittee `json:"committee"`
+}
+
+// SubmissionWindowRequest stage: 0 = ส่งฟอร์ม, 2-7 = ขั้นพิจารณาของ role นั้น
+// award_type_id ไม่ส่ง = ใช้กับทุกประเภทรางวัล
+type SubmissionWindowRequest struct {
+	AwardTypeID *uint     `json:"award_type_id"`
+	Stage       int       `json:"stage"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+}
+
+type SubmissionWindowResponse struct {
+	WindowID       uint      `json:"window_id"`
+	AcademicYearID uint      `json:"academic_year_id"`
+	AwardTypeID    *uint     `json:"award_type_id,omitempty"`
+	Stage          int       `json:"stage"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+}
+
+// CampusTerm ภาคเรียนพร้อมชื่อประเภทภาคการศึกษาและช่วงเวลาเปิดรับ/พิจารณา
+type CampusTerm struct {
+	AcademicYearResponse
+	TermName string                     `json:"term_name"`
+	Windows  []SubmissionWindowResponse `json:"windows"`
+}
+
+// CampusCalendarResponse ปฏิทินของวิทยาเขต uses_default = true เมื่อวิทยาเขตยังใช้ปฏิทินกลาง
+type CampusCalendarResponse struct {
+	CampusID    int               `json:"campus_id"`
+	UsesDefault bool              `json:"uses_default"`
+	TermTypes   []models.TermType `json:"term_types"`
+	Terms       []CampusTerm      `json:"terms"`
+}
+
+// TermTypeRequest ประเภทภาคการศึกษา 1 รายการ ลำดับในปีเป็นไปตามลำดับใน term_types
+type TermTypeRequest struct {
+	Semester   int    `json:"semester"`
+	Name       string `json:"name"`
+	IsOptional bool   `json:"is_optional"`
+}
+
+// UpdateTermTypesRequest แทนที่ประเภทภาคการศึกษาทั้งหมดของวิทยาเขต
+// term_types ว่างสำหรับวิทยาเขต (campus_id > 0) = กลับไปใช้ของปฏิทินกลาง
+type UpdateTermTypesRequest struct {
+	TermTypes []TermTypeRequest `json:"term_types"`
+}
+
+// TermTypeListResponse uses_default = true เมื่อวิทยาเขตยังใช้ประเภทภาคการศึกษาของปฏิทินกลาง
+type TermTypeListResponse struct {
+	CampusID    int               `json:"campus_id"`
+	UsesDefault bool              `json:"uses_default"`
+	TermTypes   []models.TermType `json:"term_types"`
+}
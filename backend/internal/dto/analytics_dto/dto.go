@@ -0,0 +1,69 @@
+package analyticsdto
+
+import "time"
+
+// AnalyticsQuery เงื่อนไขกรองที่ใช้ร่วมกันทุก endpoint ค่า 0 หรือค่าว่าง = ไม่กรอง
+type AnalyticsQuery struct {
+	CampusID     int    `query:"campus_id"`
+	FacultyID    int    `query:"faculty_id"`
+	DepartmentID int    `query:"department_id"`
+	AwardType    string `query:"award_type"`
+	AcademicYear int    `query:"academic_year"`
+	Semester     int    `query:"semester"`
+	GroupBy      string `query:"group_by"` // status, award_type, campus, faculty, department, academic_year, semester, month, student_year
+	Limit        int    `query:"limit"`    // ใช้กับ rejection-reasons (default: 10, max: 50)
+	Refresh      bool   `query:"refresh"`  // true = ไม่ใช้ค่าใน cache
+}
+
+// ChartSeries ข้อมูลหนึ่งชุดของกราฟ ลำดับของ Data ตรงกับ Labels
+type ChartSeries struct {
+	Name string    `json:"name"`
+	Data []float64 `json:"data"`
+}
+
+// ChartResponse รูปแบบที่ส่งเข้า chart library ได้ตรง ๆ (labels + series)
+type ChartResponse struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	GroupBy     string        `json:"group_by,omitempty"`
+	Keys        []string      `json:"keys"`
+	Labels      []string      `json:"labels"`
+	Series      []ChartSeries `json:"series"`
+}
+
+// SummaryResponse ภาพรวม อัตราคิดจากฟอร์มที่พิจารณาเสร็จแล้ว (อนุมัติ + ตีกลับ/ไม่อนุมัติ) เป็นร้อยละ
+type SummaryResponse struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	Total         int64     `json:"total"`
+	Approved      int64     `json:"approved"`
+	Rejected      int64     `json:"rejected"`
+	Pending       int64     `json:"pending"`
+	ApprovalRate  float64   `json:"approval_rate"`
+	RejectionRate float64   `json:"rejection_rate"`
+}
+
+type RejectionReason struct {
+	Reason         string  `json:"reason"`
+	FormStatusID   int     `json:"form_status_id"`
+	FormStatusName string  `json:"form_status_name"`
+	Count          int64   `json:"count"`
+	Percent        float64 `json:"percent"`
+}
+
+type RejectionReasonsResponse struct {
+	ChartResponse
+	Items []RejectionReason `json:"items"`
+}
+
+type StepDuration struct {
+	FormStatusID int     `json:"form_status_id"`
+	StepName     string  `json:"step_name"`
+	Transitions  int64   `json:"transitions"`
+	AvgHours     float64 `json:"avg_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	MaxHours     float64 `json:"max_hours"`
+}
+
+type StepDurationsResponse struct {
+	ChartResponse
+	Items []StepDuration `json:"items"`
+}
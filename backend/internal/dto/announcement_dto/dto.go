@@ -0,0 +1,141 @@
+package announcementdto
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	"time"
+)
+
+// CreateAnnouncementRequest สร้างประกาศของวิทยาเขตผู้สร้าง form_ids ว่าง = ทุกฟอร์มที่อนุมัติเสร็จสิ้นของภาคเรียนนั้น
+// ไม่ส่ง academic_year และ semester = ภาคเรียนปัจจุบันตามปฏิทินของวิทยาเขต
+type CreateAnnouncementRequest struct {
+	AcademicYear int    `json:"academic_year"`
+	Semester     int    `json:"semester"`
+	Title        string `json:"title"`
+	FormIDs      []uint `json:"form_ids"`
+}
+
+// UpdateDraftRequest แก้ไขฉบับร่าง ฟิลด์ที่ไม่ได้ส่งมาจะคงค่าเดิม
+// form_ids แทนที่รายการทั้งหมด, include_all = true ดึงทุกฟอร์มที่อนุมัติเสร็จสิ้นใหม่อีกครั้ง
+type UpdateDraftRequest struct {
+	Title      *string `json:"title"`
+	FormIDs    *[]uint `json:"form_ids"`
+	IncludeAll bool    `json:"include_all"`
+}
+
+// PublishAnnouncementRequest publish_at ว่างหรือเป็นอดีต = เผยแพร่ทันที
+type PublishAnnouncementRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+type AmendAnnouncementRequest struct {
+	Note string `json:"note"`
+}
+
+type RetractAnnouncementRequest struct {
+	Reason string `json:"reason"`
+}
+
+type AnnouncementVersionResponse struct {
+	VersionID   uint       `json:"version_id"`
+	Version     int        `json:"version"`
+	Status      string     `json:"status"`
+	Note        string     `json:"note,omitempty"`
+	ItemCount   int        `json:"item_count"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	PublishedBy *uint      `json:"published_by,omitempty"`
+	CreatedBy   uint       `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AnnouncementResponse struct {
+	AnnouncementID   uint                          `json:"announcement_id"`
+	CampusID         int                           `json:"campus_id"`
+	AcademicYear     int                           `json:"academic_year"`
+	Semester         int                           `json:"semester"`
+	Title            string                        `json:"title"`
+	Status           string                        `json:"status"`
+	PublishedVersion *int                          `json:"published_version,omitempty"`
+	DraftVersion     *int                          `json:"draft_version,omitempty"`
+	PublishedAt      *time.Time                    `json:"published_at,omitempty"`
+	RetractedAt      *time.Time                    `json:"retracted_at,omitempty"`
+	RetractReason    string                        `json:"retract_reason,omitempty"`
+	CreatedBy        uint                          `json:"created_by"`
+	CreatedAt        time.Time                     `json:"created_at"`
+	UpdatedAt        time.Time                     `json:"updated_at"`
+	Versions         []AnnouncementVersionResponse `json:"versions,omitempty"`
+}
+
+type PaginatedAnnouncementResponse struct {
+	Data       []AnnouncementResponse      `json:"data"`
+	Pagination awardformdto.PaginationMeta `json:"pagination"`
+}
+
+// AnnouncementPreviewResponse เนื้อหาของฉบับหนึ่งจัดหมวดเหมือนหน้าประกาศผล
+type AnnouncementPreviewResponse struct {
+	AnnouncementID uint                                    `json:"announcement_id"`
+	Title          string                                  `json:"title"`
+	CampusID       int                                     `json:"campus_id"`
+	AcademicYear   int                                     `json:"academic_year"`
+	Semester       int                                     `json:"semester"`
+	Version        int                                     `json:"version"`
+	VersionStatus  string                                  `json:"version_status"`
+	TotalItems     int                                     `json:"total_items"`
+	Sections       []awardformdto.AnnouncementAwardSection `json:"sections"`
+}
+
+// PublicAnnouncementQuery เงื่อนไขของ GET /api/public/announcements (ค่า 0 = ไม่กรอง)
+type PublicAnnouncementQuery struct {
+	CampusID     int `query:"campus_id"`
+	AcademicYear int `query:"academic_year"`
+	Semester     int `query:"semester"`
+	Page         int `query:"page"`
+	Limit        int `query:"limit"`
+}
+
+type PublicAnnouncementSummary struct {
+	AnnouncementID uint      `json:"announcement_id"`
+	CampusID       int       `json:"campus_id"`
+	AcademicYear   int       `json:"academic_year"`
+	Semester       int       `json:"semester"`
+	Title          string    `json:"title"`
+	Version        int       `json:"version"`
+	PublishedAt    time.Time `json:"published_at"`
+}
+
+type PaginatedPublicAnnouncementResponse struct {
+	Data       []PublicAnnouncementSummary `json:"data"`
+	Pagination awardformdto.PaginationMeta `json:"pagination"`
+}
+
+// PublicAnnouncementItem มีเฉพาะฟิลด์ที่ได้รับความยินยอมให้เปิดเผย (ฟิลด์อื่นจะไม่ถูกส่ง)
+type PublicAnnouncementItem struct {
+	Prefix        string `json:"prefix,omitempty"`
+	Name          string `json:"name,omitempty"`
+	FacultyName   string `json:"faculty_name,omitempty"`
+	AwardType     string `json:"award_type,omitempty"`
+	StudentNumber string `json:"student_number,omitempty"`
+}
+
+type PublicAnnouncementSection struct {
+	Key   string                   `json:"key"`
+	Label string                   `json:"label"`
+	Items []PublicAnnouncementItem `json:"items"`
+}
+
+type PublicAnnouncementResponse struct {
+	PublicAnnouncementSummary
+	Fields     []string                    `json:"fields"`
+	TotalItems int                         `json:"total_items"`
+	Sections   []PublicAnnouncementSection `json:"sections"`
+}
+
+// PublicationPreferenceResponse opt_out = true ไม่แสดงชื่อในประกาศผลสาธารณะ
+type PublicationPreferenceResponse struct {
+	OptOut    bool       `json:"opt_out"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type UpdatePublicationPreferenceRequest struct {
+	OptOut *bool `json:"opt_out"`
+}
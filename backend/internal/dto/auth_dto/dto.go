@@ -137,14 +137,15 @@ type ChancellorMeData struct {
 }
 
 // UpdateUserRequest ใช้สำหรับอัพเดทข้อมูล current user
+// รับได้ทั้ง JSON และ multipart/form-data (แนบรูปใหม่ในฟิลด์ profile_image)
 type UpdateUserRequest struct {
-	Prefix       *string `json:"prefix"`
-	Firstname    *string `json:"firstname"`
-	Lastname     *string `json:"lastname"`
-	ImagePath    *string `json:"image_path"`
-	CampusID     *int    `json:"campus_id"`
-	RoleID       *int    `json:"role_id"`
-	IsFirstLogin *bool   `json:"is_first_login"`
+	Prefix       *string `json:"prefix" form:"prefix"`
+	Firstname    *string `json:"firstname" form:"firstname"`
+	Lastname     *string `json:"lastname" form:"lastname"`
+	ImagePath    *string `json:"image_path" form:"image_path"`
+	CampusID     *int    `json:"campus_id" form:"campus_id"`
+	RoleID       *int    `json:"role_id" form:"role_id"`
+	IsFirstLogin *bool   `json:"is_first_login" form:"is_first_login"`
 }
 
 // FirstLoginRequest ใช้สำหรับตั้งค่าข้อมูลครั้งแรกของนักศึกษา/องค์กร
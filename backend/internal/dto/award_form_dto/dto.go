@@ -1,6 +1,7 @@
 package awardformdto
 
 import (
+	"backend/internal/models"
 	"time"
 )
 
@@ -29,6 +30,10 @@ type SubmitAwardRequest struct {
 	StudentNumber    string `json:"student_number"`
 	FacultyID        int    `json:"faculty_id"`
 	DepartmentID     int    `json:"department_id"`
+
+	// === ทุก Role ===
+	// FormAnswers คำตอบของฟิลด์เฉพาะประเภทรางวัล (ส่งเป็น JSON object ใน form value "form_answers")
+	FormAnswers map[string]interface{} `json:"form_answers"`
 }
 
 // --- Response DTOs ---
@@ -61,6 +66,20 @@ type AwardFormResponse struct {
 	FormDetail         string    `json:"form_detail"`
 	RejectReason       string    `json:"reject_reason"`
 
+	// ผู้ส่ง (UserID) กับผู้ถูกเสนอชื่อ: SubmitterRoleID 8 = องค์กรเสนอชื่อ, Nominee* = นิสิตในระบบที่ผูกตามรหัสนิสิต
+	SubmitterRoleID    int        `json:"submitter_role_id"`
+	NomineeStudentID   *uint      `json:"nominee_student_id"`
+	NomineeUserID      *uint      `json:"nominee_user_id"`
+	NomineeRespondedAt *time.Time `json:"nominee_responded_at,omitempty"`
+
+	// คำตอบของฟิลด์เฉพาะประเภทรางวัล และนิยามฟิลด์ (มีเฉพาะหน้ารายละเอียด) สำหรับแสดง label
+	AwardTypeID *uint                   `json:"award_type_id"`
+	FormAnswers map[string]interface{}  `json:"form_answers"`
+	FormFields  []models.AwardFormField `json:"form_fields,omitempty"`
+
+	// ชื่อฟิลด์ข้อมูลส่วนบุคคลที่ถูกซ่อนตามบทบาทของผู้ดู (มีเฉพาะหน้ารายละเอียด)
+	MaskedFields []string `json:"masked_fields,omitempty"`
+
 	// ข้อมูลไฟล์แนบ
 	Files []FileResponse `json:"files,omitempty"`
 }
@@ -74,15 +93,20 @@ type FileResponse struct {
 
 // --- Search & Pagination DTOs ---
 type SearchAwardRequest struct {
-	Keyword     string `query:"keyword"`      // ค้นหาใน firstname, lastname, studentNumber, semester, year, award_type
-	Date        string `query:"date"`         // กรองตามวันที่ (format: YYYY-MM-DD)
-	StudentYear int    `query:"student_year"` // กรองตามชั้นปี
-	AwardType   string `query:"award_type"`   // กรองตามประเภทรางวัล
-	SortBy      string `query:"sort_by"`      // name, studentNumber, awardType, date (default: date)
-	SortOrder   string `query:"sort_order"`   // asc, des/desc (default: des)
-	Page        int    `query:"page"`         // หน้าปัจจุบัน (default: 1)
-	Limit       int    `query:"limit"`        // จำนวนต่อหน้า (default: 5, max: 5)
-	Arrangement string `query:"arrangement"`  // backward-compatible: asc หรือ desc
+	Keyword      string `query:"keyword"`       // ค้นหาใน firstname, lastname, studentNumber, semester, year, award_type
+	Date         string `query:"date"`          // กรองตามวันที่ (format: YYYY-MM-DD)
+	StudentYear  int    `query:"student_year"`  // กรองตามชั้นปี
+	AcademicYear int    `query:"academic_year"` // กรองตามปีการศึกษา
+	Semester     int    `query:"semester"`      // กรองตามภาคการศึกษา (เลขตามประเภทภาคการศึกษาของวิทยาเขต)
+	AwardType    string `query:"award_type"`    // กรองตามประเภทรางวัล
+	SortBy       string `query:"sort_by"`       // name, studentNumber, awardType, date (default: date)
+	SortOrder    string `query:"sort_order"`    // asc, des/desc (default: des)
+	Page         int    `query:"page"`          // หน้าปัจจุบัน (default: 1)
+	Limit        int    `query:"limit"`         // จำนวนต่อหน้า (default: 5, max: 5)
+	Arrangement  string `query:"arrangement"`   // backward-compatible: asc หรือ desc
+
+	// Answers กรองตามคำตอบของฟอร์ม มาจาก query "answer.<key>=<value>" (handler เป็นผู้เติม)
+	Answers map[string]string `query:"-"`
 }
 
 type PaginatedAwardResponse struct {
@@ -226,11 +250,19 @@ type AwardTypeOption struct {
 	IsOther bool   `json:"is_other"`
 }
 
+// TermOption ภาคการศึกษาพร้อมชื่อตามปฏิทินของวิทยาเขต (เช่น ภาคฤดูร้อน, ไตรภาคที่ 3)
+type TermOption struct {
+	Label string `json:"label"`
+	Value int    `json:"value"`
+}
+
+// AnnouncementAwardFilterMeta semester_options เรียงตามลำดับภาคการศึกษาในปี (ล่าสุดก่อน) term_options เรียงเหมือนกันพร้อมชื่อ
 type AnnouncementAwardFilterMeta struct {
 	AcademicYear     int               `json:"academic_year"`
 	AcademicYears    []int             `json:"academic_year_options,omitempty"`
 	Semester         int               `json:"semester"`
 	Semesters        []int             `json:"semester_options,omitempty"`
+	TermOptions      []TermOption      `json:"term_options,omitempty"`
 	AwardType        string            `json:"award_type"`
 	FacultyID        int               `json:"faculty_id,omitempty"`
 	Keyword          string            `json:"keyword,omitempty"`
@@ -250,4 +282,49 @@ type AnnouncementAwardSection struct {
 	Keyword    string                  `json:"keyword,omitempty"`
 	Data       []AnnouncementAwardItem `json:"data"`
 	Pagination PaginationMeta          `json:"pagination"`
-}
\ No newline at end of file
+}
+// ExportColumnResponse คอลัมน์ที่เลือกส่งออกได้ (ส่ง key คั่นด้วย , ผ่าน query columns)
+type ExportColumnResponse struct {
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Default bool   `json:"default"`
+}
+
+// NominationListRequest รายการเสนอชื่อขององค์กร
+type NominationListRequest struct {
+	AcademicYear int    `query:"academic_year"`
+	Semester     int    `query:"semester"`
+	FormStatus   int    `query:"form_status"`
+	AwardType    string `query:"award_type"` // code หรือชื่อประเภทรางวัล
+	Keyword      string `query:"keyword"`    // รหัสนิสิต หรือชื่อ-นามสกุล
+	Page         int    `query:"page"`       // default: 1
+	Limit        int    `query:"limit"`      // default: 20, max: 100
+}
+
+// BulkNominationRowResult ผลของแต่ละแถวในไฟล์ (row นับรวมแถวหัวตาราง เพื่อให้ตรงกับที่เห็นใน spreadsheet)
+type BulkNominationRowResult struct {
+	Row           int    `json:"row"`
+	StudentNumber string `json:"student_number"`
+	Status        string `json:"status"` // created | failed
+	Linked        bool   `json:"linked"` // ผูกกับข้อมูลนิสิตในระบบได้
+	Error         string `json:"error,omitempty"`
+}
+
+type BulkNominationResponse struct {
+	Total   int                       `json:"total"`
+	Created int                       `json:"created"`
+	Failed  int                       `json:"failed"`
+	Rows    []BulkNominationRowResult `json:"rows"`
+}
+
+// NomineeConsentRequest ข้อมูลส่วนตัวที่นิสิตกรอกเองเมื่อยินยอมการเสนอชื่อขององค์กร
+type NomineeConsentRequest struct {
+	GPA                *float64 `json:"gpa"`
+	StudentAddress     string   `json:"student_address"`
+	StudentDateOfBirth string   `json:"student_date_of_birth"` // YYYY-MM-DD
+	StudentPhoneNumber string   `json:"student_phone_number"`  // ไม่บังคับ ว่าง = ใช้เบอร์ที่องค์กรกรอก
+}
+
+type NomineeDeclineRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,61 @@
+package awardtypedto
+
+import (
+	"backend/internal/models"
+	"time"
+)
+
+// AwardTypeRequest ใช้ทั้งสร้างและแก้ไข (PUT แทนค่าทั้งหมด code แก้ไขไม่ได้)
+type AwardTypeRequest struct {
+	Code                string   `json:"code"`
+	NameTH              string   `json:"name_th"`
+	NameEN              string   `json:"name_en"`
+	Description         string   `json:"description"`
+	Category            string   `json:"category"` // extracurricular | creativity | behavior | other
+	IsActive            *bool    `json:"is_active"`
+	MinGPA              *float64 `json:"min_gpa"`
+	AllowedStudentYears []int    `json:"allowed_student_years"`
+	AllowedRoles        []int    `json:"allowed_roles"`
+	LegacyNames         []string `json:"legacy_names"`
+}
+
+// FormSchemaRequest แทนที่นิยามฟิลด์ของฟอร์มทั้งชุด (fields ว่าง = ไม่มีฟิลด์เพิ่มเติม)
+type FormSchemaRequest struct {
+	Fields []models.AwardFormField `json:"fields"`
+}
+
+type SetYearActiveRequest struct {
+	IsActive *bool `json:"is_active"`
+}
+
+// AwardTypeQuery academic_year ว่าง = ปีการศึกษาที่เปิดรับสมัครล่าสุด
+type AwardTypeQuery struct {
+	AcademicYear    int  `query:"academic_year"`
+	IncludeInactive bool `query:"include_inactive"`
+}
+
+type AwardTypeYearResponse struct {
+	AcademicYear int       `json:"academic_year"`
+	IsActive     bool      `json:"is_active"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type AwardTypeResponse struct {
+	AwardTypeID         uint                    `json:"award_type_id"`
+	Code                string                  `json:"code"`
+	NameTH              string                  `json:"name_th"`
+	NameEN              string                  `json:"name_en"`
+	Description         string                  `json:"description"`
+	Category            string                  `json:"category"`
+	CategoryLabel       string                  `json:"category_label"`
+	IsActive            bool                    `json:"is_active"`
+	AcademicYear        int                     `json:"academic_year,omitempty"`
+	ActiveInYear        *bool                   `json:"active_in_year,omitempty"`
+	MinGPA              *float64                `json:"min_gpa"`
+	AllowedStudentYears []int                   `json:"allowed_student_years"`
+	AllowedRoles        []int                   `json:"allowed_roles"`
+	LegacyNames         []string                `json:"legacy_names"`
+	FormFields          []models.AwardFormField `json:"form_fields"`
+	YearSettings        []AwardTypeYearResponse `json:"year_settings,omitempty"`
+	UpdatedAt           time.Time               `json:"updated_at"`
+}
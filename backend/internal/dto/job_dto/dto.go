@@ -0,0 +1,56 @@
+package jobdto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EnqueueJobRequest สร้างงานด้วยตนเอง run_at ว่าง = ทำทันที
+type EnqueueJobRequest struct {
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	RunAt       *time.Time      `json:"run_at"`
+	MaxAttempts int             `json:"max_attempts"`
+}
+
+// UpdateScheduleRequest ฟิลด์ที่ไม่ได้ส่งมาจะคงค่าเดิม
+type UpdateScheduleRequest struct {
+	CronExpr *string          `json:"cron_expr"`
+	Enabled  *bool            `json:"enabled"`
+	Payload  *json.RawMessage `json:"payload"`
+}
+
+type JobResponse struct {
+	JobID       uint            `json:"job_id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	RunAt       time.Time       `json:"run_at"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty"`
+	UniqueKey   *string         `json:"unique_key,omitempty"`
+	CreatedBy   *uint           `json:"created_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+type ScheduleResponse struct {
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CronExpr  string          `json:"cron_expr"`
+	Enabled   bool            `json:"enabled"`
+	NextRunAt *time.Time      `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time      `json:"last_run_at,omitempty"`
+	UpdatedBy *uint           `json:"updated_by,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// JobStatsResponse จำนวนงานแยกตามสถานะ (dead คือ dead letter ที่รอการตรวจสอบ)
+type JobStatsResponse struct {
+	Counts map[string]int64 `json:"counts"`
+}
@@ -0,0 +1,33 @@
+package notificationdto
+
+import "time"
+
+// ChannelPreference สถานะการเปิดรับแจ้งเตือนของแต่ละช่องทาง
+type ChannelPreference struct {
+	Channel string `json:"channel"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UpdatePreferencesRequest เช่น {"channels": {"email": false}} ช่องทางที่ไม่ได้ส่งมาจะคงค่าเดิม
+type UpdatePreferencesRequest struct {
+	Channels map[string]bool `json:"channels"`
+}
+
+// NotificationResponse รายการแจ้งเตือนใน inbox Link ชี้ไปที่รายละเอียดฟอร์มที่เกี่ยวข้อง
+type NotificationResponse struct {
+	NotificationID uint       `json:"notification_id"`
+	FormID         *uint      `json:"form_id,omitempty"`
+	EventType      string     `json:"event_type"`
+	Title          string     `json:"title"`
+	Message        string     `json:"message"`
+	Link           string     `json:"link,omitempty"`
+	IsRead         bool       `json:"is_read"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// BellResponse ข้อมูลสำหรับไอคอนกระดิ่ง: จำนวนที่ยังไม่อ่าน และรายการล่าสุด
+type BellResponse struct {
+	UnreadCount int64                  `json:"unread_count"`
+	Latest      []NotificationResponse `json:"latest"`
+}
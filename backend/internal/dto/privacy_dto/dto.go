@@ -0,0 +1,63 @@
+package privacydto
+
+import (
+	"backend/internal/models"
+	"time"
+)
+
+type CreateErasureRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReviewErasureRequest struct {
+	Note string `json:"note"`
+}
+
+// ErasureRequestListRequest query ของรายการคำขอลบข้อมูล (กองพัฒนานิสิต)
+type ErasureRequestListRequest struct {
+	Status string `query:"status"`
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+}
+
+// PersonalDataExport เนื้อหา data.json ในไฟล์ archive ที่เจ้าของข้อมูลดาวน์โหลด
+type PersonalDataExport struct {
+	ExportedAt time.Time          `json:"exported_at"`
+	User       UserExport         `json:"user"`
+	Student    *StudentExport     `json:"student,omitempty"`
+	Forms      []models.AwardForm `json:"award_forms"`
+
+	// ประวัติของฟอร์มข้างต้น (คะแนนโหวตรายบุคคลของกรรมการไม่รวมอยู่ด้วย)
+	StatusLogs   []models.AwardStatusLog   `json:"status_logs"`
+	ApprovalLogs []models.AwardApprovalLog `json:"approval_logs"`
+	TypeLogs     []models.AwardTypeLog     `json:"award_type_logs"`
+
+	Notifications           []models.Notification           `json:"notifications"`
+	NotificationPreferences []models.NotificationPreference `json:"notification_preferences"`
+	PublicationPreference   *models.PublicationPreference   `json:"publication_preference,omitempty"`
+	ErasureRequests         []models.ErasureRequest         `json:"erasure_requests"`
+
+	// ไฟล์แนบที่อยู่ในโฟลเดอร์ files/ ของ archive (path ภายใน archive -> path เดิม)
+	Files map[string]string `json:"files"`
+}
+
+type UserExport struct {
+	UserID       uint      `json:"user_id"`
+	Prefix       string    `json:"prefix"`
+	Firstname    string    `json:"firstname"`
+	Lastname     string    `json:"lastname"`
+	Email        string    `json:"email"`
+	ImagePath    string    `json:"image_path"`
+	Provider     string    `json:"provider"`
+	RoleID       int       `json:"role_id"`
+	CampusID     int       `json:"campus_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	LatestUpdate time.Time `json:"latest_update"`
+}
+
+type StudentExport struct {
+	StudentID     uint   `json:"student_id"`
+	StudentNumber string `json:"student_number"`
+	FacultyID     uint   `json:"faculty_id"`
+	DepartmentID  uint   `json:"department_id"`
+}
@@ -0,0 +1,21 @@
+package realtimedto
+
+// VoteTally ผลนับคะแนนโหวตล่าสุดของกรรมการ ส่งทุกครั้งที่มีการโหวต
+type VoteTally struct {
+	FormID         uint  `json:"form_id"`
+	ApproveCount   int64 `json:"approve_count"`
+	RejectCount    int64 `json:"reject_count"`
+	TotalVoters    int64 `json:"total_voters"`
+	VotedCount     int64 `json:"voted_count"`
+	HasMajority    bool  `json:"has_majority"`
+	MajorityTarget int64 `json:"majority_target"`
+	FormStatusID   int   `json:"form_status_id"`
+}
+
+// FormStatusChanged แจ้งว่าฟอร์มเปลี่ยนสถานะ (client ดึงรายละเอียดใหม่เองจาก /api/awards/details/:formId)
+type FormStatusChanged struct {
+	FormID       uint   `json:"form_id"`
+	FormStatusID int    `json:"form_status_id"`
+	EventType    string `json:"event_type"`
+	ChangedBy    uint   `json:"changed_by"`
+}
@@ -0,0 +1,9 @@
+package retentiondto
+
+type UpdateRetentionPolicyRequest struct {
+	RetainYears *int `json:"retain_years"`
+}
+
+type RunPurgeRequest struct {
+	DryRun bool `json:"dry_run"`
+}
@@ -0,0 +1,35 @@
+package signaturedto
+
+import "time"
+
+type SignerKeyResponse struct {
+	KeyID       uint       `json:"key_id"`
+	Algorithm   string     `json:"algorithm"`
+	PublicKey   string     `json:"public_key"`
+	Fingerprint string     `json:"fingerprint"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+}
+
+// SignatureCheck ผลการตรวจลายมือชื่อหนึ่งรายการใน Award_Signed_Log
+type SignatureCheck struct {
+	SignedLogID    uint      `json:"signed_log_id"`
+	UserID         uint      `json:"user_id"`
+	FormStatusID   int       `json:"form_status_id"`
+	SignedAt       time.Time `json:"signed_at"`
+	KeyID          uint      `json:"key_id"`
+	Fingerprint    string    `json:"fingerprint,omitempty"`
+	Algorithm      string    `json:"algorithm,omitempty"`
+	SignedHash     string    `json:"signed_hash"`
+	HashMatches    bool      `json:"hash_matches"`
+	SignatureValid bool      `json:"signature_valid"`
+	Message        string    `json:"message,omitempty"`
+}
+
+type FormSignatureVerificationResponse struct {
+	FormID      uint             `json:"form_id"`
+	CurrentHash string           `json:"current_hash"`
+	AllValid    bool             `json:"all_valid"`
+	Signatures  []SignatureCheck `json:"signatures"`
+}
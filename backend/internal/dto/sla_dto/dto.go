@@ -0,0 +1,59 @@
+package sladto
+
+import "time"
+
+// UpdateSLAPolicyRequest ฟิลด์ที่ไม่ได้ส่งมาจะคงค่าเดิม
+type UpdateSLAPolicyRequest struct {
+	DurationHours       *int    `json:"duration_hours"`
+	ReminderBeforeHours *int    `json:"reminder_before_hours"`
+	EscalateTo          *string `json:"escalate_to"`
+}
+
+// SLACheckResult ผลการตรวจ SLA หนึ่งรอบ
+type SLACheckResult struct {
+	CheckedAt   time.Time `json:"checked_at"`
+	Reminders   int       `json:"reminders"`
+	Escalations int       `json:"escalations"`
+}
+
+// OverdueDashboardResponse ฟอร์มที่เกินกำหนดแยกตามขั้น และสรุปตามผู้พิจารณา
+type OverdueDashboardResponse struct {
+	GeneratedAt  time.Time         `json:"generated_at"`
+	TotalOverdue int               `json:"total_overdue"`
+	Steps        []OverdueStep     `json:"steps"`
+	Approvers    []OverdueApprover `json:"approvers"`
+}
+
+type OverdueStep struct {
+	FormStatusID  int           `json:"form_status_id"`
+	StepName      string        `json:"step_name"`
+	DurationHours int           `json:"duration_hours"`
+	OverdueCount  int           `json:"overdue_count"`
+	Forms         []OverdueForm `json:"forms"`
+}
+
+type OverdueForm struct {
+	FormID        uint      `json:"form_id"`
+	StudentName   string    `json:"student_name"`
+	StudentNumber string    `json:"student_number"`
+	AwardType     string    `json:"award_type"`
+	CampusID      int       `json:"campus_id"`
+	FacultyID     int       `json:"faculty_id"`
+	DepartmentID  int       `json:"department_id"`
+	LatestUpdate  time.Time `json:"latest_update"`
+	StepStartedAt time.Time `json:"step_started_at"`
+	DueAt         time.Time `json:"due_at"`
+	OverdueHours  int       `json:"overdue_hours"`
+	Escalated     bool      `json:"escalated"`
+	ApproverIDs   []uint    `json:"approver_ids"`
+}
+
+// OverdueApprover ผู้พิจารณาที่มีฟอร์มค้างเกินกำหนด
+type OverdueApprover struct {
+	UserID       uint   `json:"user_id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	RoleID       int    `json:"role_id"`
+	OverdueCount int    `json:"overdue_count"`
+	FormIDs      []uint `json:"form_ids"`
+}
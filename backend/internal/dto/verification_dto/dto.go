@@ -0,0 +1,30 @@
+package verificationdto
+
+import "time"
+
+// PublicVerificationResponse ข้อมูลที่ส่งกลับจาก endpoint สาธารณะ (เฉพาะข้อมูลขั้นต่ำ ไม่มีข้อมูลส่วนบุคคล)
+type PublicVerificationResponse struct {
+	Code         string     `json:"code"`
+	Status       string     `json:"status"` // "valid" | "revoked"
+	Name         string     `json:"name"`
+	AwardType    string     `json:"award_type"`
+	AcademicYear int        `json:"academic_year"`
+	Semester     int        `json:"semester"`
+	CampusName   string     `json:"campus_name"`
+	SignedAt     *time.Time `json:"signed_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
+
+// VerificationCodeResponse รหัสตรวจสอบของฟอร์ม สำหรับเจ้าของฟอร์มหรือกองพัฒนานิสิต
+type VerificationCodeResponse struct {
+	FormID       uint       `json:"form_id"`
+	Code         string     `json:"code"`
+	IssuedAt     time.Time  `json:"issued_at"`
+	IsRevoked    bool       `json:"is_revoked"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason string     `json:"revoke_reason,omitempty"`
+}
+
+type RevokeVerificationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
@@ -0,0 +1,89 @@
+package webhookdto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookRequest struct {
+	Name     string   `json:"name"`
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"is_active"`
+}
+
+// UpdateWebhookRequest ฟิลด์ที่ไม่ได้ส่งมาจะคงค่าเดิม
+type UpdateWebhookRequest struct {
+	Name     *string   `json:"name"`
+	URL      *string   `json:"url"`
+	Events   *[]string `json:"events"`
+	IsActive *bool     `json:"is_active"`
+}
+
+// WebhookEndpointResponse Secret แสดงเฉพาะตอนสร้างและตอน rotate เท่านั้น
+type WebhookEndpointResponse struct {
+	EndpointID uint      `json:"endpoint_id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	Events     []string  `json:"events"`
+	IsActive   bool      `json:"is_active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedBy  uint      `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryID     uint            `json:"delivery_id"`
+	EndpointID     uint            `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	DurationMs     int64           `json:"duration_ms"`
+	IsTest         bool            `json:"is_test"`
+	CreatedAt      time.Time       `json:"created_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// WebhookEvent รูปแบบ body ที่ส่งไปยังผู้รับทุกเหตุการณ์
+type WebhookEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// AwardEventData ข้อมูลฟอร์มที่ส่งออก (ไม่รวมข้อมูลส่วนบุคคล เช่น ที่อยู่ เบอร์โทร วันเกิด เกรดเฉลี่ย)
+type AwardEventData struct {
+	FormID               uint   `json:"form_id"`
+	StudentNumber        string `json:"student_number"`
+	StudentFirstname     string `json:"student_firstname"`
+	StudentLastname      string `json:"student_lastname"`
+	AwardType            string `json:"award_type"`
+	AcademicYear         int    `json:"academic_year"`
+	Semester             int    `json:"semester"`
+	CampusID             int    `json:"campus_id"`
+	FacultyID            int    `json:"faculty_id"`
+	DepartmentID         int    `json:"department_id"`
+	FormStatusID         int    `json:"form_status_id"`
+	PreviousFormStatusID int    `json:"previous_form_status_id,omitempty"`
+	ChangedBy            uint   `json:"changed_by,omitempty"`
+}
+
+// VoteCastData ผลนับคะแนนหลังกรรมการโหวต (ไม่ระบุตัวผู้โหวต)
+type VoteCastData struct {
+	FormID         uint  `json:"form_id"`
+	ApproveCount   int64 `json:"approve_count"`
+	RejectCount    int64 `json:"reject_count"`
+	TotalVoters    int64 `json:"total_voters"`
+	VotedCount     int64 `json:"voted_count"`
+	HasMajority    bool  `json:"has_majority"`
+	MajorityTarget int64 `json:"majority_target"`
+	FormStatusID   int   `json:"form_status_id"`
+}
@@ -0,0 +1,167 @@
+package fieldcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ค่าที่เข้ารหัสแล้วอยู่ในรูป "enc:v1:<key id>:<data key ที่ห่อด้วย KEK>:<ciphertext>"
+// แต่ละค่ามี data key ของตัวเอง การหมุน KEK จึงต้องห่อใหม่เฉพาะ data key
+const envelopePrefix = "enc:v1:"
+
+var (
+	mu       sync.RWMutex
+	provider KeyProvider
+)
+
+// Configure กำหนด provider ที่ serializer และฟังก์ชันเข้ารหัสใช้ ต้องเรียกก่อนใช้งานฐานข้อมูล
+func Configure(p KeyProvider) {
+	mu.Lock()
+	defer mu.Unlock()
+	provider = p
+}
+
+func currentProvider() (KeyProvider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if provider == nil {
+		return nil, errors.New("field encryption is not configured")
+	}
+	return provider, nil
+}
+
+// IsEncrypted ค่าอยู่ในรูป envelope หรือไม่ (ค่าเดิมก่อนเปิดการเข้ารหัสเป็น plaintext)
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// KeyIDOf คืน key id ที่ใช้ห่อ data key ของค่านี้ ("" ถ้าไม่ได้เข้ารหัส)
+func KeyIDOf(value string) string {
+	if !IsEncrypted(value) {
+		return ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, envelopePrefix), ":", 3)
+	return parts[0]
+}
+
+// Encrypt เข้ารหัสด้วย data key ใหม่ที่ห่อด้วย active key ของ provider
+func Encrypt(plaintext string) (string, error) {
+	p, err := currentProvider()
+	if err != nil {
+		return "", err
+	}
+	keyID, kek, err := p.ActiveKey()
+	if err != nil {
+		return "", err
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := seal(kek, dataKey, []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataKey, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return envelopePrefix + keyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt ถอดค่า envelope ค่าที่ไม่ได้เข้ารหัส (ข้อมูลเดิม) คืนค่าเดิม
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, envelopePrefix), ":", 3)
+	if len(parts) != 3 {
+		return "", errors.New("malformed encrypted value")
+	}
+	p, err := currentProvider()
+	if err != nil {
+		return "", err
+	}
+	kek, err := p.Key(parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.New("malformed encrypted value")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.New("malformed encrypted value")
+	}
+	dataKey, err := open(kek, wrapped, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencrypt ค่าเป็น plaintext หรือเข้ารหัสด้วย key ที่ไม่ใช่ active key
+func NeedsReencrypt(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	p, err := currentProvider()
+	if err != nil {
+		return false, err
+	}
+	activeID, _, err := p.ActiveKey()
+	if err != nil {
+		return false, err
+	}
+	return KeyIDOf(value) != activeID, nil
+}
+
+func seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key []byte, sealed []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("malformed encrypted value")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], additionalData)
+	if err != nil {
+		return nil, errors.New("failed to decrypt value")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
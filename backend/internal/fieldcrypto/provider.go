@@ -0,0 +1,176 @@
+package fieldcrypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyProvider แหล่งของ key-encryption key (KEK) ที่ใช้ห่อ data key ของแต่ละค่า
+// ActiveKey ใช้เข้ารหัสค่าใหม่ ส่วน Key ใช้ถอดค่าที่เข้ารหัสด้วย key เก่าหลังหมุน key
+type KeyProvider interface {
+	ActiveKey() (keyID string, key []byte, err error)
+	Key(keyID string) ([]byte, error)
+}
+
+// KeyRotator provider ที่สร้าง key ใหม่และตั้งเป็น active ได้ (key เก่ายังเก็บไว้สำหรับถอดรหัส)
+type KeyRotator interface {
+	Rotate() (keyID string, err error)
+}
+
+type keyFileEntry struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"` // base64 ของ 32 bytes
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type keyFile struct {
+	ActiveKeyID string         `json:"active_key_id"`
+	Keys        []keyFileEntry `json:"keys"`
+}
+
+// LocalKeyFile เก็บ KEK ไว้ในไฟล์ JSON บนเครื่อง (สิทธิ์ 0600)
+type LocalKeyFile struct {
+	mu       sync.RWMutex
+	path     string
+	activeID string
+	keys     map[string][]byte
+	entries  []keyFileEntry
+}
+
+// OpenLocalKeyFile อ่านไฟล์ key ที่มีอยู่แล้ว ถ้าไม่มีไฟล์จะคืน error (สร้างไฟล์ด้วย InitLocalKeyFile)
+func OpenLocalKeyFile(path string) (*LocalKeyFile, error) {
+	p := &LocalKeyFile{path: path}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// InitLocalKeyFile สร้างไฟล์ key ใหม่พร้อม key แรก ถ้ามีไฟล์อยู่แล้วจะคืน error เพื่อไม่ให้ทับ key เดิม
+func InitLocalKeyFile(path string) (*LocalKeyFile, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("key file %s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	p := &LocalKeyFile{path: path, keys: make(map[string][]byte)}
+	if _, err := p.Rotate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload อ่านไฟล์ key ใหม่ (เช่น หลังหมุน key จากเครื่องอื่น) ถ้าไฟล์ไม่ถูกต้องจะคง key ชุดเดิมไว้
+func (p *LocalKeyFile) Reload() error {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("read key file %s: %w", p.path, err)
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("invalid key file %s: %w", p.path, err)
+	}
+	keys := make(map[string][]byte, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(entry.Key)
+		if err != nil || len(key) != 32 {
+			return fmt.Errorf("invalid key %q in %s: must be base64 of 32 bytes", entry.ID, p.path)
+		}
+		if entry.ID == "" || strings.Contains(entry.ID, ":") {
+			return fmt.Errorf("invalid key id %q in %s", entry.ID, p.path)
+		}
+		keys[entry.ID] = key
+	}
+	if _, ok := keys[file.ActiveKeyID]; !ok {
+		return fmt.Errorf("active key %q not found in %s", file.ActiveKeyID, p.path)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.activeID = file.ActiveKeyID
+	p.keys = keys
+	p.entries = file.Keys
+	return nil
+}
+
+func (p *LocalKeyFile) ActiveKey() (string, []byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.activeID, p.keys[p.activeID], nil
+}
+
+// Key ถ้าไม่พบ key id จะอ่านไฟล์ใหม่หนึ่งครั้ง (ค่าอาจถูกเข้ารหัสด้วย key ที่หมุนหลังโปรเซสนี้เริ่ม)
+func (p *LocalKeyFile) Key(keyID string) ([]byte, error) {
+	if key, ok := p.lookup(keyID); ok {
+		return key, nil
+	}
+	if err := p.Reload(); err != nil {
+		return nil, fmt.Errorf("encryption key %q not found: %w", keyID, err)
+	}
+	if key, ok := p.lookup(keyID); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key %q not found", keyID)
+}
+
+func (p *LocalKeyFile) lookup(keyID string) ([]byte, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	key, ok := p.keys[keyID]
+	return key, ok
+}
+
+// Rotate สร้าง key ใหม่ ตั้งเป็น active แล้วเขียนไฟล์ใหม่ทั้งไฟล์
+// ค่าเดิมยังถอดได้ด้วย key เก่า ใช้คำสั่ง reencrypt-fields เพื่อเข้ารหัสใหม่ด้วย key ปัจจุบัน
+func (p *LocalKeyFile) Rotate() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	keyID := "k" + now.UTC().Format("20060102150405")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.keys[keyID]; exists {
+		return "", fmt.Errorf("encryption key %q already exists", keyID)
+	}
+
+	entries := append(append([]keyFileEntry{}, p.entries...), keyFileEntry{
+		ID:        keyID,
+		Key:       base64.StdEncoding.EncodeToString(key),
+		CreatedAt: now,
+	})
+	content, err := json.MarshalIndent(keyFile{ActiveKeyID: keyID, Keys: entries}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(p.path, content); err != nil {
+		return "", err
+	}
+
+	p.keys[keyID] = key
+	p.entries = entries
+	p.activeID = keyID
+	return keyID, nil
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,94 @@
+package fieldcrypto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// dateLayout รูปแบบที่เก็บวันที่ก่อนเข้ารหัส (ตรงกับค่า date เดิมที่ถูก cast เป็น text)
+const dateLayout = "2006-01-02"
+
+func init() {
+	schema.RegisterSerializer("encrypted", EncryptedSerializer{})
+}
+
+// EncryptedSerializer ใช้กับ tag `serializer:encrypted` รองรับฟิลด์ string, float64 และ time.Time
+// คอลัมน์ต้องเป็น text: string ว่างเก็บเป็นค่าว่าง, ตัวเลข 0 และเวลา zero เก็บเป็น NULL (ไม่มีข้อมูล), ค่าเดิมที่ยังไม่เข้ารหัสอ่านได้ตามปกติ
+type EncryptedSerializer struct{}
+
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var raw string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		raw = fmt.Sprint(v)
+	}
+
+	plaintext, err := Decrypt(raw)
+	if err != nil {
+		return fmt.Errorf("decrypt %s: %w", field.DBName, err)
+	}
+
+	fieldValue := field.ReflectValueOf(ctx, dst)
+	switch fieldValue.Interface().(type) {
+	case string:
+		fieldValue.SetString(plaintext)
+	case float64:
+		var number float64
+		if plaintext != "" {
+			if number, err = strconv.ParseFloat(plaintext, 64); err != nil {
+				return fmt.Errorf("decrypt %s: %w", field.DBName, err)
+			}
+		}
+		fieldValue.SetFloat(number)
+	case time.Time:
+		var t time.Time
+		if plaintext != "" {
+			// ค่าเดิมที่ cast เป็น text อาจมีส่วนเวลาต่อท้าย ใช้เฉพาะส่วนวันที่
+			if len(plaintext) > len(dateLayout) {
+				plaintext = plaintext[:len(dateLayout)]
+			}
+			if t, err = time.Parse(dateLayout, plaintext); err != nil {
+				return fmt.Errorf("decrypt %s: %w", field.DBName, err)
+			}
+		}
+		fieldValue.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("serializer encrypted: unsupported field type %s", field.FieldType)
+	}
+	return nil
+}
+
+func (EncryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var plaintext string
+	switch v := fieldValue.(type) {
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		plaintext = v
+	case float64:
+		// GPA 0 คือยังไม่กรอก (เช่นรอนิสิตยินยอม) เก็บเป็น NULL ให้เงื่อนไข IS NOT NULL ของ retention ใช้ได้
+		if v == 0 {
+			return nil, nil
+		}
+		plaintext = strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return nil, nil
+		}
+		plaintext = v.Format(dateLayout)
+	default:
+		return nil, fmt.Errorf("serializer encrypted: unsupported field type %s", field.FieldType)
+	}
+	return Encrypt(plaintext)
+}
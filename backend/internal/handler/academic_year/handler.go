@@ -2,7 +2,12 @@ package academicyear
 
 import (
 	academicYearDTO "backend/internal/dto/academic_year_dto"
+	"backend/internal/middleware"
+	"backend/internal/models"
 	"backend/internal/usecase"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,7 +20,7 @@ func NewAcademicYearHandler(service usecase.AcademicYearService) *AcademicYearHa
 	return &AcademicYearHandler{service: service}
 }
 
-// CreateAcademicYear สร้าง academic year ใหม่
+// CreateAcademicYear สร้าง academic year ใหม่ (campus_id ไม่ส่ง = ปฏิทินกลาง)
 func (h *AcademicYearHandler) CreateAcademicYear(c *fiber.Ctx) error {
 	req := new(academicYearDTO.CreateAcademicYear)
 
@@ -32,13 +37,7 @@ func (h *AcademicYearHandler) CreateAcademicYear(c *fiber.Ctx) error {
 		})
 	}
 
-	response := &academicYearDTO.AcademicYearResponse{
-		AcademicYearID: academicYear.AcademicYearID,
-		Year:           academicYear.Year,
-		Semester:       academicYear.Semester,
-		StartDate:      academicYear.StartDate,
-		EndDate:        academicYear.EndDate,
-	}
+	response := toAcademicYearResponse(academicYear)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Academic year created successfully",
@@ -71,25 +70,322 @@ func (h *AcademicYearHandler) GetAllAcademicYears(c *fiber.Ctx) error {
 	})
 }
 
-// GetCurrentSemester ดึงข้อมูล academic year ล่าสุด
+// GetCurrentSemester ดึงข้อมูล academic year ล่าสุดของวิทยาเขต (query: campus_id ไม่ส่ง = ปฏิทินกลาง)
 func (h *AcademicYearHandler) GetCurrentSemester(c *fiber.Ctx) error {
-	academicYear, err := h.service.GetCurrentSemester(c.Context())
+	campusID := c.QueryInt("campus_id", 0)
+	if campusID < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid campus_id",
+		})
+	}
+
+	academicYear, err := h.service.GetCurrentSemester(c.Context(), campusID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "No academic year found",
 		})
 	}
 
-	response := &academicYearDTO.AcademicYearResponse{
+	response := toAcademicYearResponse(academicYear)
+
+	return c.JSON(fiber.Map{
+		"message": "Latest academic year retrieved successfully",
+		"data":    response,
+	})
+}
+
+// GetCampusCalendar ภาคเรียนและช่วงเวลาเปิดรับ/พิจารณาของวิทยาเขต (query: campus_id)
+func (h *AcademicYearHandler) GetCampusCalendar(c *fiber.Ctx) error {
+	campusID := c.QueryInt("campus_id", 0)
+	calendar, err := h.service.GetCampusCalendar(c.Context(), campusID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Campus calendar retrieved successfully",
+		"data":    calendar,
+	})
+}
+
+// CreateWindow เพิ่มช่วงเวลาเปิดรับ/พิจารณาให้ภาคเรียน :id (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) CreateWindow(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	academicYearID, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	var req academicYearDTO.SubmissionWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	window, err := h.service.CreateWindow(c.Context(), academicYearID, req, user.UserID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Submission window created successfully",
+		"data":    window,
+	})
+}
+
+// UpdateWindow แก้ไขช่วงเวลา :windowId (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) UpdateWindow(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	windowID, err := strconv.ParseUint(c.Params("windowId"), 10, 32)
+	if err != nil || windowID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid window id",
+		})
+	}
+
+	var req academicYearDTO.SubmissionWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	window, err := h.service.UpdateWindow(c.Context(), uint(windowID), req, user.UserID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Submission window updated successfully",
+		"data":    window,
+	})
+}
+
+// DeleteWindow ลบช่วงเวลา :windowId (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) DeleteWindow(c *fiber.Ctx) error {
+	windowID, err := strconv.ParseUint(c.Params("windowId"), 10, 32)
+	if err != nil || windowID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid window id",
+		})
+	}
+
+	if err := h.service.DeleteWindow(c.Context(), uint(windowID)); err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Submission window deleted successfully",
+	})
+}
+
+// GetTermTypes ประเภทภาคการศึกษาและลำดับในปีของวิทยาเขต (query: campus_id ไม่ส่ง = ปฏิทินกลาง)
+func (h *AcademicYearHandler) GetTermTypes(c *fiber.Ctx) error {
+	campusID := c.QueryInt("campus_id", 0)
+	termTypes, err := h.service.GetTermTypes(c.Context(), campusID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Term types retrieved successfully",
+		"data":    termTypes,
+	})
+}
+
+// UpdateTermTypes แทนที่ประเภทภาคการศึกษาของวิทยาเขต (query: campus_id) กองพัฒนานิสิต
+func (h *AcademicYearHandler) UpdateTermTypes(c *fiber.Ctx) error {
+	var req academicYearDTO.UpdateTermTypesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	termTypes, err := h.service.UpdateTermTypes(c.Context(), c.QueryInt("campus_id", 0), req)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Term types updated successfully",
+		"data":    termTypes,
+	})
+}
+
+// UpdateAcademicYear แก้ไขภาคเรียน :id (ปี/เทอมแก้ได้เฉพาะภาคเรียนที่ยังไม่เริ่ม) กองพัฒนานิสิต
+func (h *AcademicYearHandler) UpdateAcademicYear(c *fiber.Ctx) error {
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	req := new(academicYearDTO.UpdateAcademicYear)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	academicYear, err := h.service.UpdateAcademicYear(c.Context(), id, req)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Academic year updated successfully",
+		"data":    toAcademicYearResponse(academicYear),
+	})
+}
+
+// DeleteAcademicYear ลบภาคเรียน :id ที่ยังไม่เริ่ม (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) DeleteAcademicYear(c *fiber.Ctx) error {
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	if err := h.service.DeleteAcademicYear(c.Context(), id); err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Academic year deleted successfully",
+	})
+}
+
+// ChangeStatus เปลี่ยนสถานะภาคเรียน :id พร้อมรายงานสรุป (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) ChangeStatus(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	var req academicYearDTO.ChangeStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+
+	transition, err := h.service.ChangeStatus(c.Context(), id, req, user.UserID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Academic year status changed to " + transition.ToStatus,
+		"data":    transition,
+	})
+}
+
+// Rollover สร้างภาคเรียนถัดจาก :id พร้อมคัดลอกคณะกรรมการ ช่วงเวลา และการเปิดรับประเภทรางวัล (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) Rollover(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	var req academicYearDTO.RolloverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	academicYear, transition, err := h.service.Rollover(c.Context(), id, req, user.UserID)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Academic year rolled over successfully",
+		"data": fiber.Map{
+			"academic_year": toAcademicYearResponse(academicYear),
+			"transition":    transition,
+		},
+	})
+}
+
+// GetTermSummary สรุปภาพรวมปัจจุบันของภาคเรียน :id (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) GetTermSummary(c *fiber.Ctx) error {
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	summary, err := h.service.GetTermSummary(c.Context(), id)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Academic year summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+// GetTransitions รายงานการเปลี่ยนสถานะทั้งหมดของภาคเรียน :id (กองพัฒนานิสิต)
+func (h *AcademicYearHandler) GetTransitions(c *fiber.Ctx) error {
+	id, ok := parseAcademicYearID(c)
+	if !ok {
+		return nil
+	}
+
+	transitions, err := h.service.GetTransitions(c.Context(), id)
+	if err != nil {
+		return academicYearError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Academic year transitions retrieved successfully",
+		"data":    transitions,
+	})
+}
+
+func parseAcademicYearID(c *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid academic year id",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func toAcademicYearResponse(academicYear *models.AcademicYear) *academicYearDTO.AcademicYearResponse {
+	return &academicYearDTO.AcademicYearResponse{
 		AcademicYearID: academicYear.AcademicYearID,
+		CampusID:       academicYear.CampusID,
 		Year:           academicYear.Year,
 		Semester:       academicYear.Semester,
+		Status:         academicYear.Status,
 		StartDate:      academicYear.StartDate,
 		EndDate:        academicYear.EndDate,
 	}
+}
 
-	return c.JSON(fiber.Map{
-		"message": "Latest academic year retrieved successfully",
-		"data":    response,
+func academicYearError(c *fiber.Ctx, err error) error {
+	msg := err.Error()
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrAcademicYearClosed):
+		status = fiber.StatusForbidden
+	case strings.Contains(msg, "not found"):
+		status = fiber.StatusNotFound
+	case strings.Contains(msg, "already"), strings.Contains(msg, "unfinished forms"), strings.Contains(msg, "cannot"), strings.Contains(msg, "only"), strings.Contains(msg, "has changed"):
+		status = fiber.StatusConflict
+	case strings.Contains(msg, "must"), strings.Contains(msg, "required"), strings.Contains(msg, "invalid"):
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error": msg,
 	})
 }
@@ -0,0 +1,105 @@
+package analytics
+
+import (
+	analyticsdto "backend/internal/dto/analytics_dto"
+	"backend/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHandler struct {
+	service usecase.AnalyticsService
+}
+
+func NewAnalyticsHandler(service usecase.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GetSummary handles GET /api/analytics/summary
+func (h *AnalyticsHandler) GetSummary(c *fiber.Ctx) error {
+	query, ok := parseAnalyticsQuery(c)
+	if !ok {
+		return nil
+	}
+	summary, err := h.service.GetSummary(c.UserContext(), query)
+	return respond(c, summary, err)
+}
+
+// GetSubmissions handles GET /api/analytics/submissions?group_by=month
+func (h *AnalyticsHandler) GetSubmissions(c *fiber.Ctx) error {
+	query, ok := parseAnalyticsQuery(c)
+	if !ok {
+		return nil
+	}
+	chart, err := h.service.GetSubmissions(c.UserContext(), query)
+	return respond(c, chart, err)
+}
+
+// GetApprovalRates handles GET /api/analytics/approval-rates?group_by=faculty
+func (h *AnalyticsHandler) GetApprovalRates(c *fiber.Ctx) error {
+	query, ok := parseAnalyticsQuery(c)
+	if !ok {
+		return nil
+	}
+	chart, err := h.service.GetApprovalRates(c.UserContext(), query)
+	return respond(c, chart, err)
+}
+
+// GetRejectionReasons handles GET /api/analytics/rejection-reasons?limit=10
+func (h *AnalyticsHandler) GetRejectionReasons(c *fiber.Ctx) error {
+	query, ok := parseAnalyticsQuery(c)
+	if !ok {
+		return nil
+	}
+	reasons, err := h.service.GetRejectionReasons(c.UserContext(), query)
+	return respond(c, reasons, err)
+}
+
+// GetStepDurations handles GET /api/analytics/step-durations
+func (h *AnalyticsHandler) GetStepDurations(c *fiber.Ctx) error {
+	query, ok := parseAnalyticsQuery(c)
+	if !ok {
+		return nil
+	}
+	durations, err := h.service.GetStepDurations(c.UserContext(), query)
+	return respond(c, durations, err)
+}
+
+// parseAnalyticsQuery ตรวจสิทธิ์และอ่านเงื่อนไขกรอง ถ้าไม่ผ่านจะเขียน response ให้แล้ว
+func parseAnalyticsQuery(c *fiber.Ctx) (analyticsdto.AnalyticsQuery, bool) {
+	var query analyticsdto.AnalyticsQuery
+	if err := c.QueryParser(&query); err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid query parameters",
+		})
+		return query, false
+	}
+	if query.CampusID < 0 || query.FacultyID < 0 || query.DepartmentID < 0 || query.AcademicYear < 0 || query.Semester < 0 {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Filter values must not be negative",
+		})
+		return query, false
+	}
+	return query, true
+}
+
+func respond(c *fiber.Ctx, data interface{}, err error) error {
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "group_by") {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   data,
+	})
+}
@@ -0,0 +1,217 @@
+package announcement
+
+import (
+	announcementdto "backend/internal/dto/announcement_dto"
+	"backend/internal/middleware"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnnouncementHandler struct {
+	service usecase.AnnouncementService
+}
+
+func NewAnnouncementHandler(service usecase.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{service: service}
+}
+
+// GetAnnouncements handles GET /api/announcements?status=&page=&limit=
+func (h *AnnouncementHandler) GetAnnouncements(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	result, err := h.service.GetList(c.UserContext(), user.CampusID, c.Query("status"), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       result.Data,
+		"pagination": result.Pagination,
+	})
+}
+
+// CreateAnnouncement handles POST /api/announcements
+func (h *AnnouncementHandler) CreateAnnouncement(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+	var req announcementdto.CreateAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	announcement, err := h.service.Create(c.UserContext(), user, req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   announcement,
+	})
+}
+
+// GetAnnouncement handles GET /api/announcements/:id
+func (h *AnnouncementHandler) GetAnnouncement(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	announcement, err := h.service.GetByID(c.UserContext(), user, id)
+	return respond(c, announcement, err)
+}
+
+// UpdateDraft handles PUT /api/announcements/:id
+func (h *AnnouncementHandler) UpdateDraft(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	var req announcementdto.UpdateDraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+	announcement, err := h.service.UpdateDraft(c.UserContext(), user, id, req)
+	return respond(c, announcement, err)
+}
+
+// DeleteAnnouncement handles DELETE /api/announcements/:id
+func (h *AnnouncementHandler) DeleteAnnouncement(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	if err := h.service.Delete(c.UserContext(), user, id); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Announcement deleted",
+	})
+}
+
+// Preview handles GET /api/announcements/:id/preview?version=
+func (h *AnnouncementHandler) Preview(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	version := c.QueryInt("version", 0)
+	if version < 0 {
+		version = 0
+	}
+	preview, err := h.service.Preview(c.UserContext(), user, id, version)
+	return respond(c, preview, err)
+}
+
+// Publish handles POST /api/announcements/:id/publish
+func (h *AnnouncementHandler) Publish(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	var req announcementdto.PublishAnnouncementRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return invalidBody(c)
+		}
+	}
+	announcement, err := h.service.Publish(c.UserContext(), user, id, req)
+	return respond(c, announcement, err)
+}
+
+// Unschedule handles POST /api/announcements/:id/unschedule
+func (h *AnnouncementHandler) Unschedule(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	announcement, err := h.service.Unschedule(c.UserContext(), user, id)
+	return respond(c, announcement, err)
+}
+
+// Amend handles POST /api/announcements/:id/amend
+func (h *AnnouncementHandler) Amend(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	var req announcementdto.AmendAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+	announcement, err := h.service.Amend(c.UserContext(), user, id, req)
+	return respond(c, announcement, err)
+}
+
+// Retract handles POST /api/announcements/:id/retract
+func (h *AnnouncementHandler) Retract(c *fiber.Ctx) error {
+	user, id, ok := adminAndID(c)
+	if !ok {
+		return nil
+	}
+	var req announcementdto.RetractAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+	announcement, err := h.service.Retract(c.UserContext(), user, id, req)
+	return respond(c, announcement, err)
+}
+
+func adminAndID(c *fiber.Ctx) (*models.User, uint, bool) {
+	user := middleware.CurrentUser(c)
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid announcement ID",
+		})
+		return nil, 0, false
+	}
+	return user, uint(id), true
+}
+
+func invalidBody(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"status":  "error",
+		"message": "Invalid request body",
+	})
+}
+
+func respond(c *fiber.Ctx, data interface{}, err error) error {
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+func errorResponse(c *fiber.Ctx, err error) error {
+	var status int
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "not found"):
+		status = fiber.StatusNotFound
+	case strings.Contains(msg, "already exists"), strings.Contains(msg, "scheduled"), strings.Contains(msg, "published"), strings.Contains(msg, "has no draft"):
+		status = fiber.StatusConflict
+	case strings.Contains(msg, "required"), strings.Contains(msg, "must not"), strings.Contains(msg, "not completed"), strings.Contains(msg, "invalid"), strings.Contains(msg, "has no forms"):
+		status = fiber.StatusBadRequest
+	default:
+		status = fiber.StatusInternalServerError
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": err.Error(),
+	})
+}
@@ -14,6 +14,7 @@ type AuthHandler struct {
 	AuthService         usecase.AuthService
 	StudentService      usecase.StudentService
 	OrganizationService usecase.OrganizationService
+	ProfileImageService usecase.ProfileImageService
 }
 
 func NewAuthHandler(u usecase.AuthService) *AuthHandler {
@@ -24,8 +25,8 @@ func NewAuthHandlerWithStudent(u usecase.AuthService, s usecase.StudentService)
 	return &AuthHandler{AuthService: u, StudentService: s}
 }
 
-func NewAuthHandlerWithServices(u usecase.AuthService, s usecase.StudentService, o usecase.OrganizationService) *AuthHandler {
-	return &AuthHandler{AuthService: u, StudentService: s, OrganizationService: o}
+func NewAuthHandlerWithServices(u usecase.AuthService, s usecase.StudentService, o usecase.OrganizationService, img usecase.ProfileImageService) *AuthHandler {
+	return &AuthHandler{AuthService: u, StudentService: s, OrganizationService: o, ProfileImageService: img}
 }
 
 func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
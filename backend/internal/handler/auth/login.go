@@ -1,9 +1,9 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"mime/multipart"
 	"strconv"
 	"strings"
 	"time"
@@ -227,12 +227,33 @@ func (h *AuthHandler) UpdateMe(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
 	}
 
+	// รูปโปรไฟล์ในโฟลเดอร์ uploads ต้องอัปโหลดผ่าน profile_image เท่านั้น
+	// กันการชี้ image_path ไปที่ไฟล์ของคนอื่น (ซึ่งจะถูกลบเมื่อเปลี่ยนรูป)
+	if req.ImagePath != nil && *req.ImagePath != user.ImagePath && h.ProfileImageService != nil && h.ProfileImageService.IsStored(*req.ImagePath) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "upload a new image via profile_image instead of image_path"})
+	}
+
+	var newImagePath string
+	if file, err := c.FormFile("profile_image"); err == nil {
+		newImagePath, err = h.saveProfileImage(file, user.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		req.ImagePath = &newImagePath
+	}
+
 	// เรียกใช้ service เพื่ออัพเดทข้อมูล
 	updatedUser, err := h.AuthService.UpdateUser(c.Context(), user.UserID, &req)
 	if err != nil {
+		h.removeProfileImage(newImagePath)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	// ลบรูปเดิมเมื่อถูกแทนที่แล้ว
+	if updatedUser.ImagePath != user.ImagePath {
+		h.removeProfileImage(user.ImagePath)
+	}
+
 	return c.JSON(fiber.Map{
 		"message": "user updated successfully",
 		"user": fiber.Map{
@@ -321,24 +342,11 @@ func (h *AuthHandler) FirstLogin(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "profile_image is required for student"})
 		}
 
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-		if ext == "" {
-			ext = ".jpg"
-		}
-
-		uploadDir := filepath.Join("uploads", "user-profile")
-		if err := os.MkdirAll(uploadDir, 0o755); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to prepare upload directory"})
-		}
-
-		fileName := fmt.Sprintf("%d%s", user.UserID, ext)
-		savePath := filepath.Join(uploadDir, fileName)
-		if err := c.SaveFile(file, savePath); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to save profile image"})
+		imagePath, err = h.saveProfileImage(file, user.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 		}
 
-		imagePath = "/" + filepath.ToSlash(savePath)
-
 	case 8: // Organization
 		orgName := strings.TrimSpace(c.FormValue("organization_name"))
 		if orgName == "" {
@@ -353,23 +361,10 @@ func (h *AuthHandler) FirstLogin(c *fiber.Ctx) error {
 		// Optional: profile image for Organization
 		file, err := c.FormFile("profile_image")
 		if err == nil {
-			ext := strings.ToLower(filepath.Ext(file.Filename))
-			if ext == "" {
-				ext = ".jpg"
-			}
-
-			uploadDir := filepath.Join("uploads", "user-profile")
-			if err := os.MkdirAll(uploadDir, 0o755); err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to prepare upload directory"})
+			imagePath, err = h.saveProfileImage(file, user.UserID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 			}
-
-			fileName := fmt.Sprintf("%d%s", user.UserID, ext)
-			savePath := filepath.Join(uploadDir, fileName)
-			if err := c.SaveFile(file, savePath); err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to save profile image"})
-			}
-
-			imagePath = "/" + filepath.ToSlash(savePath)
 		}
 
 	default:
@@ -381,8 +376,12 @@ func (h *AuthHandler) FirstLogin(c *fiber.Ctx) error {
 	updatedUser, _, err := h.AuthService.CompleteFirstLogin(c.Context(), user.UserID, &req, imagePath)
 	if err != nil {
 		fmt.Printf("[FirstLogin] error: %v\n", err)
+		h.removeProfileImage(imagePath)
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
+	if imagePath != "" && user.ImagePath != updatedUser.ImagePath {
+		h.removeProfileImage(user.ImagePath)
+	}
 
 	// สร้าง response
 	response := authDto.MeResponse{
@@ -432,3 +431,28 @@ func (h *AuthHandler) FirstLogin(c *fiber.Ctx) error {
 		"user":    response,
 	})
 }
+
+// saveProfileImage ส่งไฟล์ที่อัปโหลดเข้า pipeline (ตรวจชนิด, ลบ EXIF, หมุน, ย่อขนาด) แล้วคืน image_path
+func (h *AuthHandler) saveProfileImage(file *multipart.FileHeader, userID uint) (string, error) {
+	if h.ProfileImageService == nil {
+		return "", errors.New("profile image service not configured")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return "", errors.New("failed to read profile image")
+	}
+	defer f.Close()
+
+	return h.ProfileImageService.Save(userID, f)
+}
+
+// removeProfileImage ลบไฟล์รูปที่ไม่ใช้แล้ว ความผิดพลาดจะถูก log ไว้แต่ไม่ทำให้ request ล้มเหลว
+func (h *AuthHandler) removeProfileImage(imagePath string) {
+	if imagePath == "" || h.ProfileImageService == nil {
+		return
+	}
+	if err := h.ProfileImageService.Remove(imagePath); err != nil {
+		fmt.Printf("[ProfileImage] failed to remove %s: %v\n", imagePath, err)
+	}
+}
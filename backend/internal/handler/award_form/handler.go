@@ -4,6 +4,8 @@ import (
 	awardformdto "backend/internal/dto/award_form_dto"
 	"backend/internal/models"
 	"backend/internal/usecase"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -34,25 +36,6 @@ func (h *AwardHandler) Submit(c *fiber.Ctx) error {
 		})
 	}
 
-	// เช็คว่าอยู่ในช่วงเวลาที่อนุญาตของ academic year หรือไม่
-	currentSemester, err := h.academicYearService.GetCurrentSemester(c.UserContext())
-	if err != nil || currentSemester == nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ไม่พบข้อมูลปีการศึกษาปัจจุบัน",
-		})
-	}
-	now := time.Now()
-	startOfDay := time.Date(currentSemester.StartDate.Year(), currentSemester.StartDate.Month(), currentSemester.StartDate.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := time.Date(currentSemester.EndDate.Year(), currentSemester.EndDate.Month(), currentSemester.EndDate.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), now.Location())
-
-	if now.Before(startOfDay) || now.After(endOfDay) {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ขณะนี้ไม่อยู่ในช่วงเวลาที่อนุญาตให้ส่งฟอร์ม (" + currentSemester.StartDate.Format("2006-01-02") + " ถึง " + currentSemester.EndDate.Format("2006-01-02") + ")",
-		})
-	}
-
 	// ดึงข้อมูลผู้ใช้ที่ login อยู่จาก middleware
 	currentUser := c.Locals("current_user")
 	if currentUser == nil {
@@ -259,39 +242,7 @@ func (h *AwardHandler) Submit(c *fiber.Ctx) error {
 		}
 		req.StudentPhoneNumber = studentPhoneNumber
 
-		studentAddress := c.FormValue("student_address")
-		if studentAddress == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "student_address is required",
-			})
-		}
-		req.StudentAddress = studentAddress
-
-		gpa, err := strconv.ParseFloat(c.FormValue("gpa"), 64)
-		if err != nil || gpa < 0 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "gpa is required and must be a valid number",
-			})
-		}
-		req.GPA = gpa
-
-		dobStr := c.FormValue("student_date_of_birth")
-		if dobStr == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "student_date_of_birth is required",
-			})
-		}
-		dob, err := time.Parse("2006-01-02", dobStr)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "student_date_of_birth format should be YYYY-MM-DD",
-			})
-		}
-		req.StudentDateOfBirth = dob
+		// GPA, ที่อยู่ และวันเกิด นิสิตผู้ถูกเสนอชื่อกรอกเองตอนยินยอม (ดู AcceptNomination)
 
 		formDetail := c.FormValue("form_detail")
 		if formDetail == "" {
@@ -309,6 +260,16 @@ func (h *AwardHandler) Submit(c *fiber.Ctx) error {
 		})
 	}
 
+	// คำตอบของฟิลด์เฉพาะประเภทรางวัล (ตรวจกับ schema ของประเภทรางวัลใน UseCase)
+	if formAnswers := strings.TrimSpace(c.FormValue("form_answers")); formAnswers != "" {
+		if err := json.Unmarshal([]byte(formAnswers), &req.FormAnswers); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": "form_answers must be a JSON object",
+			})
+		}
+	}
+
 	// จัดการกับไฟล์แนบ (ถ้ามี)
 	var awardFiles []models.AwardFileDirectory
 
@@ -395,6 +356,37 @@ func (h *AwardHandler) Submit(c *fiber.Ctx) error {
 			}
 		}
 
+		// ช่วงเวลาเปิดรับตามปฏิทินของวิทยาเขตผู้ส่ง
+		var windowClosed *usecase.WindowClosedError
+		if errors.As(err, &windowClosed) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": windowClosed.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "no open registration period") {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": "ขณะนี้ไม่มีภาคเรียนที่เปิดรับฟอร์ม",
+			})
+		}
+
+		// ประเภทรางวัลไม่อยู่ในแคตตาล็อก/ปิดรับ, ไม่ผ่านเงื่อนไขคุณสมบัติ หรือคำตอบไม่ตรง schema
+		if msg := err.Error(); strings.Contains(msg, "award type") || strings.Contains(msg, "award_type") || strings.Contains(msg, "not eligible") || strings.Contains(msg, "invalid form answers") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": msg,
+			})
+		}
+
+		// ผู้ถูกเสนอชื่อมีฟอร์มของประเภทรางวัลนี้ในภาคเรียนนี้แล้ว
+		if strings.Contains(err.Error(), "already nominated") {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  "error",
 			"message": "บันทึกข้อมูลไม่สำเร็จ (อาจมีการส่งข้อมูลในปีการศึกษานี้ไปแล้ว): " + err.Error(),
@@ -408,7 +400,8 @@ func (h *AwardHandler) Submit(c *fiber.Ctx) error {
 }
 
 // GetByKeyword ค้นหาและกรองตามเงื่อนไข พร้อม pagination
-// Query params: keyword, date (YYYY-MM-DD), student_year, page (default: 1), limit (default: 10)
+// Query params: keyword, date (YYYY-MM-DD), student_year, page (default: 1), limit (default: 10),
+// answer.<key> (กรองตามคำตอบของฟิลด์เฉพาะประเภทรางวัล)
 func (h *AwardHandler) GetByKeyword(c *fiber.Ctx) error {
 	// ดึงข้อมูล user จาก middleware
 	currentUser := c.Locals("current_user")
@@ -435,6 +428,8 @@ func (h *AwardHandler) GetByKeyword(c *fiber.Ctx) error {
 		})
 	}
 
+	req.Answers = answerFilters(c)
+
 	// ค้นหาและกรองตามวิทยาเขตของ user
 	sortOrder := req.SortOrder
 	if strings.TrimSpace(sortOrder) == "" {
@@ -449,13 +444,22 @@ func (h *AwardHandler) GetByKeyword(c *fiber.Ctx) error {
 		req.Keyword,
 		req.Date,
 		req.StudentYear,
+		req.AcademicYear,
+		req.Semester,
 		req.AwardType,
+		req.Answers,
 		req.SortBy,
 		sortOrder,
 		req.Page,
 		req.Limit,
 	)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid answer filter") || strings.Contains(err.Error(), "invalid semester filter") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  "error",
 			"message": err.Error(),
@@ -496,6 +500,12 @@ func (h *AwardHandler) GetAnnouncementAwards(c *fiber.Ctx) error {
 
 	results, err := h.useCase.GetAnnouncementAwards(c.UserContext(), user.CampusID, req)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid semester filter") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  "error",
 			"message": err.Error(),
@@ -550,8 +560,8 @@ func (h *AwardHandler) GetMySubmissions(c *fiber.Ctx) error {
 		}
 		year = yearValue
 	} else {
-		// ถ้าไม่มี query param ให้ดึง current active year
-		currentSemester, err := h.academicYearService.GetCurrentSemester(c.UserContext())
+		// ถ้าไม่มี query param ให้ดึง current active year ตามปฏิทินของวิทยาเขตผู้ใช้
+		currentSemester, err := h.academicYearService.GetCurrentSemester(c.UserContext(), user.CampusID)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"status":  "error",
@@ -612,8 +622,8 @@ func (h *AwardHandler) GetMyCurrentSemesterSubmissions(c *fiber.Ctx) error {
 		})
 	}
 
-	// ดึง Academic Year ปัจจุบันที่เปิดใช้งาน (isActive = true)
-	currentSemester, err := h.academicYearService.GetCurrentSemester(c.UserContext())
+	// ดึง Academic Year ปัจจุบันตามปฏิทินของวิทยาเขตผู้ใช้
+	currentSemester, err := h.academicYearService.GetCurrentSemester(c.UserContext(), user.CampusID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  "error",
@@ -651,7 +661,16 @@ func (h *AwardHandler) GetMyCurrentSemesterSubmissions(c *fiber.Ctx) error {
 	})
 }
 
+// GetByFormID ดูรายละเอียดฟอร์ม เฉพาะผู้ที่เกี่ยวข้องกับฟอร์ม ข้อมูลส่วนบุคคลถูกซ่อนตามบทบาท
 func (h *AwardHandler) GetByFormID(c *fiber.Ctx) error {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+	}
+
 	formID, err := strconv.Atoi(c.Params("formId"))
 	if err != nil || formID <= 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -660,11 +679,13 @@ func (h *AwardHandler) GetByFormID(c *fiber.Ctx) error {
 		})
 	}
 
-	form, err := h.useCase.GetByFormID(c.UserContext(), formID)
+	form, err := h.useCase.GetByFormID(c.UserContext(), formID, user)
 	if err != nil {
 		status := fiber.StatusInternalServerError
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
 			status = fiber.StatusNotFound
+		} else if strings.Contains(err.Error(), "forbidden") {
+			status = fiber.StatusForbidden
 		}
 		return c.Status(status).JSON(fiber.Map{
 			"status":  "error",
@@ -717,7 +738,13 @@ func (h *AwardHandler) UpdateAwardType(c *fiber.Ctx) error {
 	}
 
 	if err := h.useCase.UpdateAwardType(c.UserContext(), uint(formID), req.AwardType, user.UserID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "award type") {
+			status = fiber.StatusBadRequest
+		} else if strings.Contains(err.Error(), "form not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
 			"status":  "error",
 			"message": err.Error(),
 		})
@@ -768,7 +795,7 @@ func (h *AwardHandler) UpdateFormStatus(c *fiber.Ctx) error {
 	}
 
 	if user.RoleID == 6 {
-		isChairman, err := h.useCase.IsCommitteeChairman(c.UserContext(), user.UserID)
+		isChairman, err := h.useCase.IsFormCommitteeChairman(c.UserContext(), uint(formID), user.UserID)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"status":  "error",
@@ -787,21 +814,21 @@ func (h *AwardHandler) UpdateFormStatus(c *fiber.Ctx) error {
 	switch user.RoleID {
 	case 2, 3, 4:
 		if err := h.useCase.UpdateFormStatus(c.UserContext(), uint(formID), req.FormStatusID, req.RejectReason, user.UserID); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			return c.Status(statusUpdateErrorCode(err)).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
 			})
 		}
 	case 5:
 		if err := h.useCase.UpdateFormStatusWithLog(c.UserContext(), uint(formID), req.FormStatusID, req.RejectReason, user.UserID); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			return c.Status(statusUpdateErrorCode(err)).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
 			})
 		}
 	case 6, 7:
 		if err := h.useCase.UpdateFormStatusWithSignedLog(c.UserContext(), uint(formID), req.FormStatusID, req.RejectReason, user.UserID); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			return c.Status(statusUpdateErrorCode(err)).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
 			})
@@ -819,6 +846,18 @@ func (h *AwardHandler) UpdateFormStatus(c *fiber.Ctx) error {
 	})
 }
 
+// statusUpdateErrorCode พิจารณานอกช่วงเวลาของขั้นหรือภาคเรียนปิดแล้ว = 403, ฟอร์มยังรอความยินยอมของนิสิต = 409
+func statusUpdateErrorCode(err error) int {
+	var windowClosed *usecase.WindowClosedError
+	if errors.As(err, &windowClosed) || errors.Is(err, usecase.ErrAcademicYearClosed) {
+		return fiber.StatusForbidden
+	}
+	if errors.Is(err, usecase.ErrNominationPendingConsent) {
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
+
 func (h *AwardHandler) CommitteeVote(c *fiber.Ctx) error {
 	currentUser := c.Locals("current_user")
 	if currentUser == nil {
@@ -861,7 +900,11 @@ func (h *AwardHandler) CommitteeVote(c *fiber.Ctx) error {
 
 	voteResult, err := h.useCase.CommitteeVote(c.UserContext(), uint(formID), req.Operation, user.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		status := fiber.StatusBadRequest
+		if statusUpdateErrorCode(err) == fiber.StatusForbidden {
+			status = fiber.StatusForbidden
+		}
+		return c.Status(status).JSON(fiber.Map{
 			"status":  "error",
 			"message": err.Error(),
 		})
@@ -1056,7 +1099,11 @@ func (h *AwardHandler) GetMyVoteLogs(c *fiber.Ctx) error {
 
 // GetAllAwardTypes - ดึง award_type ทั้งหมดที่มีในระบบ
 func (h *AwardHandler) GetAllAwardTypes(c *fiber.Ctx) error {
-	awardTypes, err := h.useCase.GetAllAwardTypes(c.UserContext())
+	campusID := 0
+	if user, ok := c.Locals("current_user").(*models.User); ok && user != nil {
+		campusID = user.CampusID
+	}
+	awardTypes, err := h.useCase.GetAllAwardTypes(c.UserContext(), campusID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  "error",
@@ -1122,4 +1169,14 @@ func (h *AwardHandler) GetApprovalLogDetail(c *fiber.Ctx) error {
 		"status": "success",
 		"data":   detail,
 	})
-}
\ No newline at end of file
+}
+// answerFilters เก็บ query "answer.<key>=<value>" เป็นตัวกรองคำตอบของฟอร์ม
+func answerFilters(c *fiber.Ctx) map[string]string {
+	answers := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if name, ok := strings.CutPrefix(string(key), "answer."); ok && name != "" {
+			answers[name] = string(value)
+		}
+	})
+	return answers
+}
@@ -0,0 +1,147 @@
+package awardtype
+
+import (
+	awardtypedto "backend/internal/dto/award_type_dto"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AwardTypeHandler struct {
+	service usecase.AwardTypeService
+}
+
+func NewAwardTypeHandler(service usecase.AwardTypeService) *AwardTypeHandler {
+	return &AwardTypeHandler{service: service}
+}
+
+// GetAwardTypes handles GET /api/award-types?academic_year=&include_inactive=
+// ทุก role ดูได้ (include_inactive ใช้ได้เฉพาะกองพัฒนานิสิต)
+func (h *AwardTypeHandler) GetAwardTypes(c *fiber.Ctx) error {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		return errorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	var query awardtypedto.AwardTypeQuery
+	if err := c.QueryParser(&query); err != nil || query.AcademicYear < 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if user.RoleID != 5 {
+		query.IncludeInactive = false
+	}
+
+	awardTypes, err := h.service.GetAll(c.UserContext(), query.AcademicYear, query.IncludeInactive)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   awardTypes,
+	})
+}
+
+// GetAwardType handles GET /api/award-types/:id
+func (h *AwardTypeHandler) GetAwardType(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return nil
+	}
+	awardType, err := h.service.GetByID(c.UserContext(), id)
+	return respond(c, fiber.StatusOK, awardType, err)
+}
+
+// CreateAwardType handles POST /api/award-types
+func (h *AwardTypeHandler) CreateAwardType(c *fiber.Ctx) error {
+	var req awardtypedto.AwardTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	awardType, err := h.service.Create(c.UserContext(), req)
+	return respond(c, fiber.StatusCreated, awardType, err)
+}
+
+// UpdateAwardType handles PUT /api/award-types/:id
+func (h *AwardTypeHandler) UpdateAwardType(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return nil
+	}
+	var req awardtypedto.AwardTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	awardType, err := h.service.Update(c.UserContext(), id, req)
+	return respond(c, fiber.StatusOK, awardType, err)
+}
+
+// SetYearActive handles PUT /api/award-types/:id/years/:year (body: {"is_active": false})
+func (h *AwardTypeHandler) SetYearActive(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return nil
+	}
+	year, err := strconv.Atoi(c.Params("year"))
+	if err != nil || year <= 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid academic year")
+	}
+	var req awardtypedto.SetYearActiveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	awardType, err := h.service.SetYearActive(c.UserContext(), id, year, req)
+	return respond(c, fiber.StatusOK, awardType, err)
+}
+
+// UpdateFormSchema handles PUT /api/award-types/:id/form-schema (แทนที่นิยามฟิลด์ของฟอร์มทั้งชุด)
+func (h *AwardTypeHandler) UpdateFormSchema(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return nil
+	}
+	var req awardtypedto.FormSchemaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	awardType, err := h.service.UpdateFormSchema(c.UserContext(), id, req)
+	return respond(c, fiber.StatusOK, awardType, err)
+}
+
+func parseID(c *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		_ = errorResponse(c, fiber.StatusBadRequest, "Invalid award type ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respond(c *fiber.Ctx, status int, data interface{}, err error) error {
+	if err != nil {
+		msg := err.Error()
+		switch {
+		case strings.Contains(msg, "not found"):
+			return errorResponse(c, fiber.StatusNotFound, msg)
+		case strings.Contains(msg, "already"):
+			return errorResponse(c, fiber.StatusConflict, msg)
+		case strings.Contains(msg, "required"), strings.Contains(msg, "invalid"), strings.Contains(msg, "must"), strings.Contains(msg, "cannot"), strings.Contains(msg, "duplicate"):
+			return errorResponse(c, fiber.StatusBadRequest, msg)
+		default:
+			return errorResponse(c, fiber.StatusInternalServerError, msg)
+		}
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+func errorResponse(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": message,
+	})
+}
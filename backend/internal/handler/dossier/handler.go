@@ -0,0 +1,96 @@
+package dossier
+
+import (
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DossierHandler struct {
+	service usecase.DossierService
+}
+
+func NewDossierHandler(service usecase.DossierService) *DossierHandler {
+	return &DossierHandler{service: service}
+}
+
+// GetFormDossier handles GET /api/awards/details/:formId/dossier (PDF รวมข้อมูลฟอร์ม ประวัติ และไฟล์แนบ)
+func (h *DossierHandler) GetFormDossier(c *fiber.Ctx) error {
+	currentUser := c.Locals("current_user")
+	if currentUser == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+	}
+	user, ok := currentUser.(*models.User)
+	if !ok {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid user data",
+		})
+	}
+
+	formID, err := strconv.Atoi(c.Params("formId"))
+	if err != nil || formID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid formId",
+		})
+	}
+
+	content, fileName, err := h.service.BuildFormDossier(c.UserContext(), uint(formID), user)
+	if err != nil {
+		return dossierError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="`+fileName+`"`)
+	return c.Send(content)
+}
+
+// GetCommitteeDossiers handles GET /api/awards/dossiers/committee (zip ของทุกฟอร์มที่อยู่ในขั้นคณะกรรมการ)
+func (h *DossierHandler) GetCommitteeDossiers(c *fiber.Ctx) error {
+	currentUser := c.Locals("current_user")
+	if currentUser == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+	}
+	user, ok := currentUser.(*models.User)
+	if !ok {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid user data",
+		})
+	}
+
+	content, fileName, err := h.service.BuildCommitteeDossierZip(c.UserContext(), user)
+	if err != nil {
+		return dossierError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	return c.Send(content)
+}
+
+func dossierError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "not found") {
+		status = fiber.StatusNotFound
+	} else if strings.Contains(msg, "forbidden") {
+		status = fiber.StatusForbidden
+	} else if strings.Contains(msg, "font unavailable") {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": err.Error(),
+	})
+}
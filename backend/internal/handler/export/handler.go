@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportHandler struct {
+	service usecase.ExportService
+}
+
+func NewExportHandler(service usecase.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// GetColumns handles GET /api/awards/export/columns?dataset=search|announcement|approval-logs
+func (h *ExportHandler) GetColumns(c *fiber.Ctx) error {
+	if _, ok := userFromContext(c); !ok {
+		return nil
+	}
+
+	columns, err := h.service.GetColumns(c.Query("dataset", usecase.ExportDatasetSearch))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   columns,
+	})
+}
+
+// ExportSearch handles GET /api/awards/search/export (query เดียวกับ /search + format, columns)
+func (h *ExportHandler) ExportSearch(c *fiber.Ctx) error {
+	var req awardformdto.SearchAwardRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidQuery(c)
+	}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if name, ok := strings.CutPrefix(string(key), "answer."); ok && name != "" {
+			if req.Answers == nil {
+				req.Answers = make(map[string]string)
+			}
+			req.Answers[name] = string(value)
+		}
+	})
+	return h.stream(c, usecase.ExportDatasetSearch, func(ctx context.Context, plan *usecase.ExportPlan, user *models.User) (usecase.ExportStream, error) {
+		return h.service.ExportSearch(ctx, plan, user, req)
+	})
+}
+
+// ExportAnnouncement handles GET /api/awards/announcement/export (query เดียวกับ /announcement + format, columns)
+func (h *ExportHandler) ExportAnnouncement(c *fiber.Ctx) error {
+	var req awardformdto.AnnouncementAwardRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidQuery(c)
+	}
+	return h.stream(c, usecase.ExportDatasetAnnouncement, func(ctx context.Context, plan *usecase.ExportPlan, user *models.User) (usecase.ExportStream, error) {
+		return h.service.ExportAnnouncement(ctx, plan, user, req)
+	})
+}
+
+// ExportApprovalLogs handles GET /api/awards/my/approval-logs/export (query เดียวกับ /my/approval-logs + format, columns)
+func (h *ExportHandler) ExportApprovalLogs(c *fiber.Ctx) error {
+	var req awardformdto.SearchApprovalLogRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidQuery(c)
+	}
+	if strings.TrimSpace(req.Keyword) == "" {
+		req.Keyword = strings.TrimSpace(c.Query("q"))
+	}
+	return h.stream(c, usecase.ExportDatasetApprovalLogs, func(ctx context.Context, plan *usecase.ExportPlan, user *models.User) (usecase.ExportStream, error) {
+		return h.service.ExportApprovalLogs(ctx, plan, user, req)
+	})
+}
+
+// stream ตรวจแผนการส่งออกและตัวกรอง (open) ก่อน แล้วจึงส่งไฟล์กลับแบบ stream
+// ข้อผิดพลาดที่เกิดหลังเริ่ม stream เปลี่ยน HTTP status ไม่ได้แล้ว จะถูก log และไฟล์ถูกตัดจบ
+func (h *ExportHandler) stream(c *fiber.Ctx, dataset string, open func(ctx context.Context, plan *usecase.ExportPlan, user *models.User) (usecase.ExportStream, error)) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	plan, err := h.service.PrepareExport(user, dataset, c.Query("format"), c.Query("columns"))
+	if err != nil {
+		return exportError(c, err, fiber.StatusBadRequest)
+	}
+
+	write, err := open(c.UserContext(), plan, user)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = fiber.StatusBadRequest
+		}
+		return exportError(c, err, status)
+	}
+
+	c.Set(fiber.HeaderContentType, plan.ContentType())
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+plan.FileName(time.Now())+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	// stream writer ทำงานหลัง handler คืนค่า จึงใช้ context ใหม่แทน c.UserContext()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(context.Background(), w); err != nil {
+			log.Printf("export: %s for user %d failed: %v", dataset, user.UserID, err)
+		}
+		if err := w.Flush(); err != nil {
+			log.Printf("export: %s for user %d flush failed: %v", dataset, user.UserID, err)
+		}
+	})
+	return nil
+}
+
+// exportError ตอบข้อผิดพลาดก่อนเริ่ม stream (forbidden = 403 เสมอ)
+func exportError(c *fiber.Ctx, err error, status int) error {
+	if strings.Contains(err.Error(), "forbidden") {
+		status = fiber.StatusForbidden
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": err.Error(),
+	})
+}
+
+func invalidQuery(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"status":  "error",
+		"message": "Invalid query parameters",
+	})
+}
+
+func userFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+		return nil, false
+	}
+	return user, true
+}
@@ -0,0 +1,218 @@
+package job
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	jobdto "backend/internal/dto/job_dto"
+	"backend/internal/middleware"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct {
+	service usecase.JobService
+}
+
+func NewJobHandler(service usecase.JobService) *JobHandler {
+	return &JobHandler{service: service}
+}
+
+// GetJobs handles GET /api/jobs?status=&type=&page=&limit= (status=dead คือ dead letter)
+func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	jobs, total, err := h.service.GetJobs(c.UserContext(), strings.TrimSpace(c.Query("status")), strings.TrimSpace(c.Query("type")), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       jobs,
+		"pagination": awardformdto.PaginationMeta{CurrentPage: page, TotalPages: totalPages, TotalItems: total, Limit: limit},
+	})
+}
+
+// GetStats handles GET /api/jobs/stats
+func (h *JobHandler) GetStats(c *fiber.Ctx) error {
+	stats, err := h.service.GetStats(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   stats,
+	})
+}
+
+// GetJob handles GET /api/jobs/:jobId
+func (h *JobHandler) GetJob(c *fiber.Ctx) error {
+	jobID, ok := jobIDFromParams(c)
+	if !ok {
+		return nil
+	}
+
+	job, err := h.service.GetJob(c.UserContext(), jobID)
+	if err != nil {
+		return respondJobError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   job,
+	})
+}
+
+// EnqueueJob handles POST /api/jobs (body: {"type": "sla.check", "payload": {}, "run_at": "..."})
+func (h *JobHandler) EnqueueJob(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	var req jobdto.EnqueueJobRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Type) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "type is required",
+		})
+	}
+
+	job, err := h.service.EnqueueManual(c.UserContext(), req, user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   job,
+	})
+}
+
+// RetryJob handles POST /api/jobs/:jobId/retry
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	jobID, ok := jobIDFromParams(c)
+	if !ok {
+		return nil
+	}
+
+	job, err := h.service.RetryJob(c.UserContext(), jobID)
+	if err != nil {
+		return respondJobError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   job,
+	})
+}
+
+// CancelJob handles POST /api/jobs/:jobId/cancel
+func (h *JobHandler) CancelJob(c *fiber.Ctx) error {
+	jobID, ok := jobIDFromParams(c)
+	if !ok {
+		return nil
+	}
+
+	job, err := h.service.CancelJob(c.UserContext(), jobID)
+	if err != nil {
+		return respondJobError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   job,
+	})
+}
+
+// GetSchedules handles GET /api/jobs/schedules
+func (h *JobHandler) GetSchedules(c *fiber.Ctx) error {
+	schedules, err := h.service.GetSchedules(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   schedules,
+	})
+}
+
+// UpdateSchedule handles PUT /api/jobs/schedules/:name (body: {"cron_expr": "0 2 * * *", "enabled": true})
+func (h *JobHandler) UpdateSchedule(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	var req jobdto.UpdateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	schedule, err := h.service.UpdateSchedule(c.UserContext(), c.Params("name"), req, user.UserID)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if strings.Contains(err.Error(), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   schedule,
+	})
+}
+
+func jobIDFromParams(c *fiber.Ctx) (uint, bool) {
+	jobID, err := strconv.Atoi(c.Params("jobId"))
+	if err != nil || jobID <= 0 {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid jobId",
+		})
+		return 0, false
+	}
+	return uint(jobID), true
+}
+
+func respondJobError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		status = fiber.StatusNotFound
+	} else if strings.Contains(err.Error(), "only ") {
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": err.Error(),
+	})
+}
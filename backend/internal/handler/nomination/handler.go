@@ -0,0 +1,253 @@
+package nomination
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBulkFileSize ขนาดไฟล์ CSV สูงสุดของการเสนอชื่อแบบกลุ่ม
+const maxBulkFileSize = 2 * 1024 * 1024
+
+type NominationHandler struct {
+	useCase             usecase.AwardUseCase
+	academicYearService usecase.AcademicYearService
+}
+
+func NewNominationHandler(u usecase.AwardUseCase, ays usecase.AcademicYearService) *NominationHandler {
+	return &NominationHandler{useCase: u, academicYearService: ays}
+}
+
+// GetNominations handles GET /api/awards/nominations
+// query: academic_year, semester, form_status, award_type, keyword, page, limit
+func (h *NominationHandler) GetNominations(c *fiber.Ctx) error {
+	user, ok := organizationFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	var req awardformdto.NominationListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	result, err := h.useCase.GetNominations(c.UserContext(), user.UserID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "award type") {
+			return errorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       result.Data,
+		"pagination": result.Pagination,
+	})
+}
+
+// GetTemplate handles GET /api/awards/nominations/template (ไฟล์ CSV หัวตารางสำหรับเสนอชื่อแบบกลุ่ม)
+func (h *NominationHandler) GetTemplate(c *fiber.Ctx) error {
+	if _, ok := organizationFromContext(c); !ok {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff") // ให้ Excel เปิดภาษาไทยได้ถูกต้อง
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(usecase.NominationCSVColumns); err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	writer.Flush()
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="nomination-template.csv"`)
+	return c.Send(buf.Bytes())
+}
+
+// BulkNominate handles POST /api/awards/nominations/bulk (multipart field "file" เป็น CSV)
+// แต่ละแถวถูกตรวจและบันทึกแยกกัน ผลของทุกแถวส่งกลับใน response
+func (h *NominationHandler) BulkNominate(c *fiber.Ctx) error {
+	user, ok := organizationFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	// ตรวจช่วงเวลารับสมัครของวิทยาเขตก่อนอ่านไฟล์ ช่วงเวลาของแต่ละประเภทรางวัลตรวจอีกครั้งในแต่ละแถว
+	if _, err := h.academicYearService.CheckSubmissionWindow(c.UserContext(), user.CampusID, nil, time.Now()); err != nil {
+		var windowClosed *usecase.WindowClosedError
+		if errors.As(err, &windowClosed) {
+			return errorResponse(c, fiber.StatusForbidden, windowClosed.Error())
+		}
+		if strings.Contains(err.Error(), "no open registration period") {
+			return errorResponse(c, fiber.StatusForbidden, "ขณะนี้ไม่มีภาคเรียนที่เปิดรับฟอร์ม")
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "file is required")
+	}
+	if strings.ToLower(filepath.Ext(fileHeader.Filename)) != ".csv" {
+		return errorResponse(c, fiber.StatusBadRequest, "file must be a .csv file")
+	}
+	if fileHeader.Size > maxBulkFileSize {
+		return errorResponse(c, fiber.StatusBadRequest, "file must not exceed 2 MB")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Failed to read file")
+	}
+	defer file.Close()
+
+	result, err := h.useCase.BulkNominate(c.UserContext(), user.UserID, file)
+	if err != nil {
+		if msg := err.Error(); strings.Contains(msg, "nomination file") {
+			return errorResponse(c, fiber.StatusBadRequest, msg)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+// GetMyPendingConsents handles GET /api/awards/my/consents (การเสนอชื่อที่รอนิสิตยินยอม)
+func (h *NominationHandler) GetMyPendingConsents(c *fiber.Ctx) error {
+	user, ok := studentFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	forms, err := h.useCase.GetPendingConsents(c.UserContext(), user.UserID)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   forms,
+	})
+}
+
+// AcceptNomination handles POST /api/awards/consent/:formId/accept
+// body: {"gpa": 3.5, "student_address": "...", "student_date_of_birth": "YYYY-MM-DD", "student_phone_number": "..."}
+func (h *NominationHandler) AcceptNomination(c *fiber.Ctx) error {
+	user, ok := studentFromContext(c)
+	if !ok {
+		return nil
+	}
+	formID, ok := parseFormID(c)
+	if !ok {
+		return nil
+	}
+
+	var req awardformdto.NomineeConsentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := h.useCase.AcceptNomination(c.UserContext(), user.UserID, formID, req); err != nil {
+		return consentError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Nomination accepted and submitted for review",
+	})
+}
+
+// DeclineNomination handles POST /api/awards/consent/:formId/decline (body: {"reason": "..."})
+func (h *NominationHandler) DeclineNomination(c *fiber.Ctx) error {
+	user, ok := studentFromContext(c)
+	if !ok {
+		return nil
+	}
+	formID, ok := parseFormID(c)
+	if !ok {
+		return nil
+	}
+
+	var req awardformdto.NomineeDeclineRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+	if err := h.useCase.DeclineNomination(c.UserContext(), user.UserID, formID, req); err != nil {
+		return consentError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Nomination declined",
+	})
+}
+
+func consentError(c *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return errorResponse(c, fiber.StatusNotFound, msg)
+	case strings.Contains(msg, "forbidden"):
+		return errorResponse(c, fiber.StatusForbidden, msg)
+	case strings.Contains(msg, "not awaiting"):
+		return errorResponse(c, fiber.StatusConflict, msg)
+	case strings.Contains(msg, "required"), strings.Contains(msg, "must be"), strings.Contains(msg, "not eligible"), strings.Contains(msg, "award type"):
+		return errorResponse(c, fiber.StatusBadRequest, msg)
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, msg)
+	}
+}
+
+func parseFormID(c *fiber.Ctx) (uint, bool) {
+	formID, err := strconv.ParseUint(c.Params("formId"), 10, 32)
+	if err != nil || formID == 0 {
+		_ = errorResponse(c, fiber.StatusBadRequest, "Invalid form ID")
+		return 0, false
+	}
+	return uint(formID), true
+}
+
+func errorResponse(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+// organizationFromContext อนุญาตเฉพาะองค์กร (role 8) ถ้าไม่ผ่านจะเขียน response ให้แล้ว
+func organizationFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = errorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+		return nil, false
+	}
+	if user.RoleID != 8 {
+		_ = errorResponse(c, fiber.StatusForbidden, "Only Organization can manage nominations")
+		return nil, false
+	}
+	return user, true
+}
+
+// studentFromContext อนุญาตเฉพาะนิสิต (role 1) ถ้าไม่ผ่านจะเขียน response ให้แล้ว
+func studentFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = errorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+		return nil, false
+	}
+	if user.RoleID != 1 {
+		_ = errorResponse(c, fiber.StatusForbidden, "Only the nominated student can respond to nominations")
+		return nil, false
+	}
+	return user, true
+}
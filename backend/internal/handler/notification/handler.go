@@ -0,0 +1,218 @@
+package notification
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	notificationdto "backend/internal/dto/notification_dto"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	service usecase.NotificationService
+}
+
+func NewNotificationHandler(service usecase.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// GetPreferences handles GET /api/notifications/preferences
+func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	preferences, err := h.service.GetPreferences(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   preferences,
+	})
+}
+
+// UpdatePreferences handles PUT /api/notifications/preferences (body: {"channels": {"email": false}})
+func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	var req notificationdto.UpdatePreferencesRequest
+	if err := c.BodyParser(&req); err != nil || len(req.Channels) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "channels is required",
+		})
+	}
+
+	preferences, err := h.service.UpdatePreferences(c.UserContext(), user.UserID, req)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "unknown channel") {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   preferences,
+	})
+}
+
+// GetInbox handles GET /api/notifications?page=&limit=&unread_only=true
+func (h *NotificationHandler) GetInbox(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := h.service.GetInbox(c.UserContext(), user.UserID, c.QueryBool("unread_only", false), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       notifications,
+		"pagination": awardformdto.PaginationMeta{CurrentPage: page, TotalPages: totalPages, TotalItems: total, Limit: limit},
+	})
+}
+
+// GetUnreadCount handles GET /api/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	count, err := h.service.GetUnreadCount(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   fiber.Map{"unread_count": count},
+	})
+}
+
+// GetBell handles GET /api/notifications/bell (จำนวนที่ยังไม่อ่าน + รายการล่าสุด สำหรับไอคอนกระดิ่ง)
+func (h *NotificationHandler) GetBell(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	bell, err := h.service.GetBell(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   bell,
+	})
+}
+
+// MarkAsRead handles PUT /api/notifications/:notificationId/read
+func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	notificationID, err := strconv.Atoi(c.Params("notificationId"))
+	if err != nil || notificationID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid notificationId",
+		})
+	}
+
+	if err := h.service.MarkAsRead(c.UserContext(), user.UserID, uint(notificationID)); err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "notification marked as read",
+	})
+}
+
+// MarkAllAsRead handles PUT /api/notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	updated, err := h.service.MarkAllAsRead(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   fiber.Map{"updated": updated},
+	})
+}
+
+// userFromContext ดึง current_user ถ้าไม่มีจะเขียน response 401 ให้แล้ว
+func userFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+		return nil, false
+	}
+	return user, true
+}
@@ -0,0 +1,177 @@
+package privacy
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	privacydto "backend/internal/dto/privacy_dto"
+	"backend/internal/middleware"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrivacyHandler struct {
+	service usecase.PrivacyService
+}
+
+func NewPrivacyHandler(service usecase.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{service: service}
+}
+
+// ExportMyData handles GET /api/privacy/export (zip: data.json + ไฟล์แนบ)
+func (h *PrivacyHandler) ExportMyData(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	content, fileName, err := h.service.ExportPersonalData(c.UserContext(), user.UserID)
+	if err != nil {
+		return privacyError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(content)
+}
+
+// RequestErasure handles POST /api/privacy/erasure-requests (body: {"reason": "..."})
+func (h *PrivacyHandler) RequestErasure(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	var req privacydto.CreateErasureRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	request, err := h.service.RequestErasure(c.UserContext(), user, req.Reason)
+	if err != nil {
+		return privacyError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   request,
+	})
+}
+
+// GetMyErasureRequests handles GET /api/privacy/erasure-requests/my
+func (h *PrivacyHandler) GetMyErasureRequests(c *fiber.Ctx) error {
+	user, ok := userFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	requests, err := h.service.GetMyErasureRequests(c.UserContext(), user.UserID)
+	if err != nil {
+		return privacyError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   requests,
+	})
+}
+
+// GetErasureRequests handles GET /api/privacy/erasure-requests?status=&page=&limit= (กองพัฒนานิสิต)
+func (h *PrivacyHandler) GetErasureRequests(c *fiber.Ctx) error {
+	var req privacydto.ErasureRequestListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	requests, total, err := h.service.GetErasureRequests(c.UserContext(), strings.TrimSpace(req.Status), req.Page, req.Limit)
+	if err != nil {
+		return privacyError(c, err)
+	}
+
+	totalPages := int(total) / req.Limit
+	if int(total)%req.Limit > 0 {
+		totalPages++
+	}
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       requests,
+		"pagination": awardformdto.PaginationMeta{CurrentPage: req.Page, TotalPages: totalPages, TotalItems: total, Limit: req.Limit},
+	})
+}
+
+// ApproveErasure handles POST /api/privacy/erasure-requests/:requestId/approve (body: {"note": "..."})
+func (h *PrivacyHandler) ApproveErasure(c *fiber.Ctx) error {
+	return h.review(c, h.service.ApproveErasure)
+}
+
+// RejectErasure handles POST /api/privacy/erasure-requests/:requestId/reject (body: {"note": "..."} ต้องระบุเหตุผล)
+func (h *PrivacyHandler) RejectErasure(c *fiber.Ctx) error {
+	return h.review(c, h.service.RejectErasure)
+}
+
+func (h *PrivacyHandler) review(c *fiber.Ctx, action func(ctx context.Context, requestID uint, reviewedBy uint, note string) (*models.ErasureRequest, error)) error {
+	user := middleware.CurrentUser(c)
+
+	requestID, err := strconv.ParseUint(c.Params("requestId"), 10, 32)
+	if err != nil || requestID == 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid requestId")
+	}
+
+	var req privacydto.ReviewErasureRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	request, err := action(c.UserContext(), uint(requestID), user.UserID, req.Note)
+	if err != nil {
+		return privacyError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   request,
+	})
+}
+
+func privacyError(c *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return errorResponse(c, fiber.StatusNotFound, msg)
+	case strings.Contains(msg, "forbidden"):
+		return errorResponse(c, fiber.StatusForbidden, msg)
+	case strings.Contains(msg, "already pending"), strings.Contains(msg, "not pending"):
+		return errorResponse(c, fiber.StatusConflict, msg)
+	case strings.Contains(msg, "must"), strings.Contains(msg, "required"):
+		return errorResponse(c, fiber.StatusBadRequest, msg)
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, msg)
+	}
+}
+
+func errorResponse(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+func userFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = errorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+		return nil, false
+	}
+	return user, true
+}
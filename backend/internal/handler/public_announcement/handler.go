@@ -0,0 +1,197 @@
+package publicannouncement
+
+import (
+	announcementdto "backend/internal/dto/announcement_dto"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PublicAnnouncementHandler struct {
+	service usecase.PublicAnnouncementService
+	maxAge  time.Duration
+}
+
+func NewPublicAnnouncementHandler(service usecase.PublicAnnouncementService, maxAge time.Duration) *PublicAnnouncementHandler {
+	return &PublicAnnouncementHandler{service: service, maxAge: maxAge}
+}
+
+// GetAnnouncements handles GET /api/public/announcements?campus_id=&academic_year=&semester=&page=&limit= (ไม่ต้อง login)
+func (h *PublicAnnouncementHandler) GetAnnouncements(c *fiber.Ctx) error {
+	var query announcementdto.PublicAnnouncementQuery
+	if err := c.QueryParser(&query); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if query.CampusID < 0 || query.AcademicYear < 0 || query.Semester < 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Filter values must not be negative")
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	result, err := h.service.GetList(c.UserContext(), query)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	var lastModified time.Time
+	for _, announcement := range result.Data {
+		if announcement.PublishedAt.After(lastModified) {
+			lastModified = announcement.PublishedAt
+		}
+	}
+	return h.sendJSON(c, fiber.Map{
+		"status":     "success",
+		"data":       result.Data,
+		"pagination": result.Pagination,
+	}, lastModified)
+}
+
+// GetAnnouncement handles GET /api/public/announcements/:id (ไม่ต้อง login)
+func (h *PublicAnnouncementHandler) GetAnnouncement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid announcement ID")
+	}
+
+	announcement, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errorResponse(c, fiber.StatusNotFound, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return h.sendJSON(c, fiber.Map{
+		"status": "success",
+		"data":   announcement,
+	}, announcement.PublishedAt)
+}
+
+// GetRSSFeed handles GET /api/public/campuses/:campusId/announcements.rss
+func (h *PublicAnnouncementHandler) GetRSSFeed(c *fiber.Ctx) error {
+	return h.sendFeed(c, usecase.FeedFormatRSS, "application/rss+xml; charset=utf-8")
+}
+
+// GetAtomFeed handles GET /api/public/campuses/:campusId/announcements.atom
+func (h *PublicAnnouncementHandler) GetAtomFeed(c *fiber.Ctx) error {
+	return h.sendFeed(c, usecase.FeedFormatAtom, "application/atom+xml; charset=utf-8")
+}
+
+func (h *PublicAnnouncementHandler) sendFeed(c *fiber.Ctx, format, contentType string) error {
+	campusID, err := strconv.ParseUint(c.Params("campusId"), 10, 32)
+	if err != nil || campusID == 0 {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid campus ID")
+	}
+
+	body, lastModified, err := h.service.GetFeed(c.UserContext(), uint(campusID), format, c.BaseURL(), c.BaseURL()+c.OriginalURL())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errorResponse(c, fiber.StatusNotFound, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return h.sendCached(c, body, contentType, lastModified)
+}
+
+// GetMyPublicationPreference handles GET /api/awards/my/publication-preference (นิสิต)
+func (h *PublicAnnouncementHandler) GetMyPublicationPreference(c *fiber.Ctx) error {
+	user, ok := studentFromContext(c)
+	if !ok {
+		return nil
+	}
+	preference, err := h.service.GetPublicationPreference(c.UserContext(), user.UserID)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   preference,
+	})
+}
+
+// UpdateMyPublicationPreference handles PUT /api/awards/my/publication-preference (body: {"opt_out": true})
+func (h *PublicAnnouncementHandler) UpdateMyPublicationPreference(c *fiber.Ctx) error {
+	user, ok := studentFromContext(c)
+	if !ok {
+		return nil
+	}
+	var req announcementdto.UpdatePublicationPreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	preference, err := h.service.UpdatePublicationPreference(c.UserContext(), user.UserID, req)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "required") {
+			status = fiber.StatusBadRequest
+		}
+		return errorResponse(c, status, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   preference,
+	})
+}
+
+func (h *PublicAnnouncementHandler) sendJSON(c *fiber.Ctx, data interface{}, lastModified time.Time) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return h.sendCached(c, body, fiber.MIMEApplicationJSONCharsetUTF8, lastModified)
+}
+
+// sendCached ใส่ Cache-Control/ETag/Last-Modified และตอบ 304 เมื่อ client มีข้อมูลล่าสุดอยู่แล้ว
+// ETag คำนวณจากเนื้อหา จึงเปลี่ยนเมื่อมีนิสิตขอไม่เผยแพร่ชื่อ แม้วันที่เผยแพร่จะไม่เปลี่ยน
+func (h *PublicAnnouncementHandler) sendCached(c *fiber.Ctx, body []byte, contentType string, lastModified time.Time) error {
+	sum := sha256.Sum256(body)
+	c.Set(fiber.HeaderETag, fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16])))
+	if h.maxAge > 0 {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+	} else {
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+	if !lastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Send(body)
+}
+
+func errorResponse(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+// studentFromContext อนุญาตเฉพาะนิสิต (role 1) ถ้าไม่ผ่านจะเขียน response ให้แล้ว
+func studentFromContext(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		_ = errorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+		return nil, false
+	}
+	if user.RoleID != 1 {
+		_ = errorResponse(c, fiber.StatusForbidden, "Only students can change publication consent")
+		return nil, false
+	}
+	return user, true
+}
@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// heartbeatInterval ส่ง comment เปล่าเป็นระยะ เพื่อไม่ให้ proxy ตัดการเชื่อมต่อที่ไม่มีข้อมูล
+const heartbeatInterval = 25 * time.Second
+
+type RealtimeHandler struct {
+	service usecase.RealtimeService
+}
+
+func NewRealtimeHandler(service usecase.RealtimeService) *RealtimeHandler {
+	return &RealtimeHandler{service: service}
+}
+
+// Stream handles GET /api/realtime/stream (Server-Sent Events)
+// event ที่ส่ง: vote_tally, form_status, notification (data เป็น JSON)
+func (h *RealtimeHandler) Stream(c *fiber.Ctx) error {
+	user, ok := c.Locals("current_user").(*models.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	events, unsubscribe := h.service.Subscribe(user)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		// retry บอก EventSource ให้เชื่อมต่อใหม่หลัง 3 วินาทีเมื่อหลุด
+		fmt.Fprintf(w, "retry: 3000\nevent: ready\ndata: {\"user_id\":%d}\n\n", user.UserID)
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event := <-events:
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			// Flush error หมายถึง client ปิดการเชื่อมต่อแล้ว
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package retention
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	retentiondto "backend/internal/dto/retention_dto"
+	"backend/internal/middleware"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RetentionHandler struct {
+	service usecase.RetentionService
+}
+
+func NewRetentionHandler(service usecase.RetentionService) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// GetPolicies handles GET /api/retention/policies
+func (h *RetentionHandler) GetPolicies(c *fiber.Ctx) error {
+	policies, err := h.service.GetPolicies(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   policies,
+	})
+}
+
+// UpdatePolicy handles PUT /api/retention/policies/:dataClass
+func (h *RetentionHandler) UpdatePolicy(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	var req retentiondto.UpdateRetentionPolicyRequest
+	if err := c.BodyParser(&req); err != nil || req.RetainYears == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "retain_years is required",
+		})
+	}
+
+	policy, err := h.service.UpdatePolicy(c.UserContext(), c.Params("dataClass"), *req.RetainYears, user.UserID)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if strings.Contains(err.Error(), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   policy,
+	})
+}
+
+// RunPurge handles POST /api/retention/purge (body: {"dry_run": true} เพื่อดูรายงานก่อนลบจริง)
+func (h *RetentionHandler) RunPurge(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	var req retentiondto.RunPurgeRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Invalid request body",
+			})
+		}
+	}
+
+	run, err := h.service.RunPurge(c.UserContext(), &user.UserID, req.DryRun)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "in progress") {
+			status = fiber.StatusConflict
+		} else if strings.Contains(err.Error(), "not configured") {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   run,
+	})
+}
+
+// GetRuns handles GET /api/retention/runs?page=&limit=
+func (h *RetentionHandler) GetRuns(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	runs, total, err := h.service.GetRuns(c.UserContext(), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       runs,
+		"pagination": awardformdto.PaginationMeta{CurrentPage: page, TotalPages: totalPages, TotalItems: total, Limit: limit},
+	})
+}
+
+// GetRunByID handles GET /api/retention/runs/:runId (รายงานพร้อมรายการที่ถูกลบ)
+func (h *RetentionHandler) GetRunByID(c *fiber.Ctx) error {
+	runID, err := strconv.Atoi(c.Params("runId"))
+	if err != nil || runID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid runId",
+		})
+	}
+
+	run, err := h.service.GetRunByID(c.UserContext(), uint(runID))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   run,
+	})
+}
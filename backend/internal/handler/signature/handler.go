@@ -0,0 +1,118 @@
+package signature
+
+import (
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SignatureHandler struct {
+	service usecase.SignatureService
+}
+
+func NewSignatureHandler(service usecase.SignatureService) *SignatureHandler {
+	return &SignatureHandler{service: service}
+}
+
+// VerifyForm handles GET /api/awards/signatures/verify/:formId
+// ตรวจว่าข้อมูลฟอร์มและไฟล์แนบไม่ถูกแก้ไขหลังลงนาม และลายมือชื่อถูกต้องตามกุญแจของผู้ลงนาม
+func (h *SignatureHandler) VerifyForm(c *fiber.Ctx) error {
+	formID, err := strconv.Atoi(c.Params("formId"))
+	if err != nil || formID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid formId",
+		})
+	}
+
+	result, err := h.service.VerifyForm(c.UserContext(), uint(formID))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+// GetMyKeys handles GET /api/awards/signatures/keys/me
+func (h *SignatureHandler) GetMyKeys(c *fiber.Ctx) error {
+	user, ok := signerFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	keys, err := h.service.GetKeysByUserID(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   keys,
+	})
+}
+
+// RotateMyKey handles POST /api/awards/signatures/keys/rotate
+func (h *SignatureHandler) RotateMyKey(c *fiber.Ctx) error {
+	user, ok := signerFromContext(c)
+	if !ok {
+		return nil
+	}
+
+	key, err := h.service.RotateKey(c.UserContext(), user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   key,
+	})
+}
+
+// signerFromContext อนุญาตเฉพาะผู้ลงนาม (คณะกรรมการ role 6 และอธิการบดี role 7)
+// ถ้าไม่ผ่านจะเขียน error response ให้แล้ว และคืนค่า false
+func signerFromContext(c *fiber.Ctx) (*models.User, bool) {
+	currentUser := c.Locals("current_user")
+	if currentUser == nil {
+		_ = c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+		return nil, false
+	}
+	user, ok := currentUser.(*models.User)
+	if !ok {
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid user data",
+		})
+		return nil, false
+	}
+	if user.RoleID != 6 && user.RoleID != 7 {
+		_ = c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Only signers can manage signing keys",
+		})
+		return nil, false
+	}
+	return user, true
+}
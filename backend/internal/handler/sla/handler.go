@@ -0,0 +1,111 @@
+package sla
+
+import (
+	sladto "backend/internal/dto/sla_dto"
+	"backend/internal/middleware"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SLAHandler struct {
+	service usecase.SLAService
+}
+
+func NewSLAHandler(service usecase.SLAService) *SLAHandler {
+	return &SLAHandler{service: service}
+}
+
+// GetPolicies handles GET /api/sla/policies
+func (h *SLAHandler) GetPolicies(c *fiber.Ctx) error {
+	policies, err := h.service.GetPolicies(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   policies,
+	})
+}
+
+// UpdatePolicy handles PUT /api/sla/policies/:formStatusId
+func (h *SLAHandler) UpdatePolicy(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	formStatusID, err := strconv.Atoi(c.Params("formStatusId"))
+	if err != nil || formStatusID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid formStatusId",
+		})
+	}
+
+	var req sladto.UpdateSLAPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	policy, err := h.service.UpdatePolicy(c.UserContext(), formStatusID, req, user.UserID)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if strings.Contains(err.Error(), "not found") {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   policy,
+	})
+}
+
+// GetOverdue handles GET /api/sla/overdue?form_status_id= (ฟอร์มเกินกำหนดของวิทยาเขตตัวเอง แยกตามขั้นและผู้พิจารณา)
+func (h *SLAHandler) GetOverdue(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	dashboard, err := h.service.GetOverdue(c.UserContext(), user.CampusID, c.QueryInt("form_status_id", 0))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   dashboard,
+	})
+}
+
+// RunCheck handles POST /api/sla/check (สั่งตรวจทันทีโดยไม่รอ scheduler)
+func (h *SLAHandler) RunCheck(c *fiber.Ctx) error {
+	result, err := h.service.RunCheck(c.UserContext())
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if strings.Contains(err.Error(), "in progress") {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   result,
+	})
+}
@@ -0,0 +1,122 @@
+package verification
+
+import (
+	verificationdto "backend/internal/dto/verification_dto"
+	"backend/internal/middleware"
+	"backend/internal/models"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type VerificationHandler struct {
+	service usecase.VerificationService
+}
+
+func NewVerificationHandler(service usecase.VerificationService) *VerificationHandler {
+	return &VerificationHandler{service: service}
+}
+
+// Verify handles GET /api/verify/:code (สาธารณะ ไม่ต้อง login)
+func (h *VerificationHandler) Verify(c *fiber.Ctx) error {
+	result, err := h.service.Verify(c.UserContext(), c.Params("code"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "not found") {
+			status = fiber.StatusNotFound
+		} else if strings.Contains(msg, "invalid") {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+// GetByFormID handles GET /api/awards/verification/:formId (เจ้าของฟอร์ม หรือ กองพัฒนานิสิต)
+func (h *VerificationHandler) GetByFormID(c *fiber.Ctx) error {
+	currentUser := c.Locals("current_user")
+	if currentUser == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized: User not found",
+		})
+	}
+	user, ok := currentUser.(*models.User)
+	if !ok {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid user data",
+		})
+	}
+
+	formID, err := strconv.Atoi(c.Params("formId"))
+	if err != nil || formID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid formId",
+		})
+	}
+
+	result, err := h.service.GetByFormID(c.UserContext(), uint(formID), user)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "not found") {
+			status = fiber.StatusNotFound
+		} else if strings.Contains(msg, "forbidden") {
+			status = fiber.StatusForbidden
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+// Revoke handles PUT /api/awards/verification/revoke/:formId (เฉพาะกองพัฒนานิสิต role 5 ตรวจที่ router)
+func (h *VerificationHandler) Revoke(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	formID, err := strconv.Atoi(c.Params("formId"))
+	if err != nil || formID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid formId",
+		})
+	}
+
+	var req verificationdto.RevokeVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := h.service.Revoke(c.UserContext(), uint(formID), req.Reason, user.UserID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "award revoked",
+	})
+}
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	webhookdto "backend/internal/dto/webhook_dto"
+	"backend/internal/middleware"
+	"backend/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	service usecase.WebhookService
+}
+
+func NewWebhookHandler(service usecase.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// GetEndpoints handles GET /api/webhooks
+func (h *WebhookHandler) GetEndpoints(c *fiber.Ctx) error {
+	endpoints, err := h.service.GetEndpoints(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   endpoints,
+	})
+}
+
+// GetEndpoint handles GET /api/webhooks/:endpointId
+func (h *WebhookHandler) GetEndpoint(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	endpoint, err := h.service.GetEndpoint(c.UserContext(), endpointID)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   endpoint,
+	})
+}
+
+// CreateEndpoint handles POST /api/webhooks (secret แสดงครั้งเดียวใน response นี้)
+func (h *WebhookHandler) CreateEndpoint(c *fiber.Ctx) error {
+	user := middleware.CurrentUser(c)
+
+	var req webhookdto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	endpoint, err := h.service.CreateEndpoint(c.UserContext(), req, user.UserID)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   endpoint,
+	})
+}
+
+// UpdateEndpoint handles PUT /api/webhooks/:endpointId
+func (h *WebhookHandler) UpdateEndpoint(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	var req webhookdto.UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(c.UserContext(), endpointID, req)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   endpoint,
+	})
+}
+
+// DeleteEndpoint handles DELETE /api/webhooks/:endpointId
+func (h *WebhookHandler) DeleteEndpoint(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	if err := h.service.DeleteEndpoint(c.UserContext(), endpointID); err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "webhook endpoint deleted",
+	})
+}
+
+// RotateSecret handles POST /api/webhooks/:endpointId/rotate-secret
+func (h *WebhookHandler) RotateSecret(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	endpoint, err := h.service.RotateSecret(c.UserContext(), endpointID)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   endpoint,
+	})
+}
+
+// SendTest handles POST /api/webhooks/:endpointId/test
+func (h *WebhookHandler) SendTest(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	delivery, err := h.service.SendTest(c.UserContext(), endpointID)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   delivery,
+	})
+}
+
+// GetDeliveries handles GET /api/webhooks/:endpointId/deliveries?status=&page=&limit=
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	endpointID, ok := idFromParams(c, "endpointId")
+	if !ok {
+		return nil
+	}
+
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	deliveries, total, err := h.service.GetDeliveries(c.UserContext(), endpointID, strings.TrimSpace(c.Query("status")), page, limit)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"data":       deliveries,
+		"pagination": awardformdto.PaginationMeta{CurrentPage: page, TotalPages: totalPages, TotalItems: total, Limit: limit},
+	})
+}
+
+// Redeliver handles POST /api/webhooks/deliveries/:deliveryId/redeliver
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	deliveryID, ok := idFromParams(c, "deliveryId")
+	if !ok {
+		return nil
+	}
+
+	delivery, err := h.service.Redeliver(c.UserContext(), deliveryID)
+	if err != nil {
+		return respondWebhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status": "success",
+		"data":   delivery,
+	})
+}
+
+func idFromParams(c *fiber.Ctx, name string) (uint, bool) {
+	id, err := strconv.Atoi(c.Params(name))
+	if err != nil || id <= 0 {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid " + name,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondWebhookError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusBadRequest
+	if strings.Contains(err.Error(), "not found") {
+		status = fiber.StatusNotFound
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"message": err.Error(),
+	})
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRole อนุญาตเฉพาะผู้ใช้ที่มี role ตามที่กำหนด ใช้ต่อจาก RequireAuth
+// เช่น RequireRole(5) = เฉพาะกองพัฒนานิสิต
+func RequireRole(roleIDs ...int) fiber.Handler {
+	allowed := make(map[int]bool, len(roleIDs))
+	for _, roleID := range roleIDs {
+		allowed[roleID] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		user := CurrentUser(c)
+		if user == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Unauthorized: User not found",
+			})
+		}
+		if !allowed[user.RoleID] {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Forbidden: your role cannot access this resource",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// CurrentUser ผู้ใช้ที่ RequireAuth ใส่ไว้ใน context (nil = ยังไม่ผ่าน RequireAuth)
+func CurrentUser(c *fiber.Ctx) *models.User {
+	user, _ := c.Locals("current_user").(*models.User)
+	return user
+}
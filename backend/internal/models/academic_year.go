@@ -4,10 +4,25 @@ import (
 	"time"
 )
 
+// สถานะของภาคเรียน: upcoming -> open -> review -> closed -> archived (review กลับเป็น open ได้)
+// open = รับฟอร์มและพิจารณา, review = ปิดรับฟอร์มแต่ยังพิจารณาต่อ, closed/archived = แก้ไขไม่ได้อีก
+const (
+	AcademicYearStatusUpcoming = "upcoming"
+	AcademicYearStatusOpen     = "open"
+	AcademicYearStatusReview   = "review"
+	AcademicYearStatusClosed   = "closed"
+	AcademicYearStatusArchived = "archived"
+)
+
+// AcademicYear ภาคเรียนในปฏิทินของแต่ละวิทยาเขต
+// CampusID = 0 คือปฏิทินกลาง ใช้กับวิทยาเขตที่ยังไม่ได้กำหนดปฏิทินของตัวเอง
+// Status ของภาคเรียนเดิมก่อนมีสถานะถือว่า open ภาคเรียนที่สร้างใหม่เริ่มที่ upcoming
 type AcademicYear struct {
 	AcademicYearID uint      `gorm:"primaryKey;column:academic_year_id" json:"academic_year_id"`
+	CampusID       int       `gorm:"column:campus_id;not null;default:0;index" json:"campus_id"`
 	Year           int       `gorm:"column:year" json:"year"`
 	Semester       int       `gorm:"column:semester" json:"semester"`
+	Status         string    `gorm:"column:status;type:varchar(16);not null;default:'open'" json:"status"`
 	StartDate      time.Time `gorm:"type:date;column:start_date" json:"start_date"` // เก็บแค่วันที่
 	EndDate        time.Time `gorm:"type:date;column:end_date" json:"end_date"`     // เก็บแค่วันที่
 	// CreatedAt      time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
@@ -0,0 +1,14 @@
+package models
+
+// AcademicYearCommittee รายชื่อคณะกรรมการที่ปฏิบัติหน้าที่ในภาคเรียน
+// บันทึกจากตาราง Committee เมื่อเปิดภาคเรียน และคัดลอกต่อไปยังภาคเรียนถัดไปเมื่อ rollover
+// การโหวตและการลงนามของคณะกรรมการใช้รายชื่อของภาคเรียนของฟอร์ม (ดู AwardRepository.formCommittee)
+type AcademicYearCommittee struct {
+	AcademicYearID uint `gorm:"primaryKey;autoIncrement:false;column:academic_year_id" json:"academic_year_id"`
+	UserID         uint `gorm:"primaryKey;autoIncrement:false;column:user_id" json:"user_id"`
+	IsChairman     bool `gorm:"column:is_chairman;not null;default:false" json:"is_chairman"`
+}
+
+func (AcademicYearCommittee) TableName() string {
+	return "Academic_Year_Committee"
+}
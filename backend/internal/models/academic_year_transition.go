@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// AcademicYearTransition รายงานการเปลี่ยนสถานะของภาคเรียนแต่ละครั้ง (รวมถึงการสร้างภาคเรียนถัดไปด้วย rollover)
+// Summary เป็นภาพรวมของภาคเรียนหลังเปลี่ยนสถานะ
+type AcademicYearTransition struct {
+	TransitionID   uint        `gorm:"primaryKey;column:transition_id" json:"transition_id"`
+	AcademicYearID uint        `gorm:"column:academic_year_id;not null;index" json:"academic_year_id"`
+	FromStatus     string      `gorm:"column:from_status;type:varchar(16)" json:"from_status"` // ค่าว่าง = ภาคเรียนถูกสร้างด้วย rollover
+	ToStatus       string      `gorm:"column:to_status;type:varchar(16);not null" json:"to_status"`
+	Note           string      `gorm:"column:note;type:text" json:"note,omitempty"`
+	Summary        TermSummary `gorm:"column:summary;type:jsonb;serializer:json" json:"summary"`
+	PerformedBy    uint        `gorm:"column:performed_by" json:"performed_by"`
+	PerformedAt    time.Time   `gorm:"column:performed_at;not null" json:"performed_at"`
+}
+
+func (AcademicYearTransition) TableName() string {
+	return "Academic_Year_Transition"
+}
+
+// TermSummary จำนวนฟอร์มของภาคเรียนแยกตามผล (FormsByStatus key = form_status_id)
+type TermSummary struct {
+	TotalForms       int64            `json:"total_forms"`
+	FormsByStatus    map[int]int64    `json:"forms_by_status"`
+	Completed        int64            `json:"completed"`
+	Rejected         int64            `json:"rejected"`
+	Declined         int64            `json:"declined"`
+	Expired          int64            `json:"expired"`
+	Unfinished       int64            `json:"unfinished"`
+	ExpiredNow       int              `json:"expired_now"` // ฟอร์มที่ถูกปิดในการเปลี่ยนสถานะครั้งนี้
+	CommitteeMembers int64            `json:"committee_members"`
+	Windows          int64            `json:"windows"`
+	Rollover         *RolloverSummary `json:"rollover,omitempty"`
+}
+
+// RolloverSummary ค่าที่คัดลอกจากภาคเรียนก่อนหน้าไปยังภาคเรียนใหม่
+type RolloverSummary struct {
+	SourceAcademicYearID    uint `json:"source_academic_year_id"`
+	WindowsCopied           int  `json:"windows_copied"`
+	CommitteeMembersCopied  int  `json:"committee_members_copied"`
+	AwardTypeSettingsCopied int  `json:"award_type_settings_copied"`
+}
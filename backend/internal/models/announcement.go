@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// สถานะของประกาศผล
+const (
+	AnnouncementStatusDraft     = "draft"     // ยังไม่เคยเผยแพร่
+	AnnouncementStatusScheduled = "scheduled" // ตั้งเวลาเผยแพร่ครั้งแรกไว้แล้ว
+	AnnouncementStatusPublished = "published"
+	AnnouncementStatusRetracted = "retracted"
+)
+
+// Announcement ประกาศผลรางวัลของวิทยาเขตต่อภาคเรียน (หนึ่งประกาศต่อวิทยาเขต/ปี/ภาคเรียน)
+// เนื้อหาอยู่ใน AnnouncementVersion ฉบับที่เผยแพร่คือ PublishedVersionID ฉบับที่กำลังแก้ไขคือ DraftVersionID
+type Announcement struct {
+	AnnouncementID     uint       `gorm:"primaryKey;column:announcement_id" json:"announcement_id"`
+	CampusID           int        `gorm:"column:campus_id;not null;uniqueIndex:idx_announcement_term" json:"campus_id"`
+	AcademicYear       int        `gorm:"column:academic_year;not null;uniqueIndex:idx_announcement_term" json:"academic_year"`
+	Semester           int        `gorm:"column:semester;not null;uniqueIndex:idx_announcement_term" json:"semester"`
+	Title              string     `gorm:"column:title;type:text" json:"title"`
+	Status             string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"`
+	PublishedVersionID *uint      `gorm:"column:published_version_id" json:"published_version_id"`
+	DraftVersionID     *uint      `gorm:"column:draft_version_id" json:"draft_version_id"`
+	PublishedAt        *time.Time `gorm:"column:published_at" json:"published_at"`
+	RetractedAt        *time.Time `gorm:"column:retracted_at" json:"retracted_at"`
+	RetractReason      string     `gorm:"column:retract_reason;type:text" json:"retract_reason,omitempty"`
+	CreatedBy          uint       `gorm:"column:created_by" json:"created_by"`
+	UpdatedBy          uint       `gorm:"column:updated_by" json:"updated_by"`
+	CreatedAt          time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt          time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Announcement) TableName() string {
+	return "Announcement"
+}
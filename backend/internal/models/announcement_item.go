@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// AnnouncementItem ฟอร์มที่อยู่ในประกาศฉบับหนึ่ง เก็บข้อมูลที่ใช้แสดงผลไว้ ณ เวลาที่เพิ่มเข้าประกาศ
+// เพื่อให้ประกาศที่เผยแพร่แล้วไม่เปลี่ยนตามสถานะหรือข้อมูลปัจจุบันของฟอร์ม
+type AnnouncementItem struct {
+	ItemID           uint      `gorm:"primaryKey;column:item_id" json:"item_id"`
+	VersionID        uint      `gorm:"column:version_id;not null;uniqueIndex:idx_announcement_item" json:"version_id"`
+	FormID           uint      `gorm:"column:form_id;not null;uniqueIndex:idx_announcement_item;index" json:"form_id"`
+	AwardType        string    `gorm:"column:award_type" json:"award_type"`
+	AwardCategory    string    `gorm:"column:award_category;type:varchar(30)" json:"award_category"` // หมวดของประเภทรางวัล (หมวดของหน้าประกาศผล)
+	FacultyID        int       `gorm:"column:faculty_id" json:"faculty_id"`
+	FacultyName      string    `gorm:"column:faculty_name" json:"faculty_name"`
+	StudentNumber    string    `gorm:"column:student_number" json:"student_number"`
+	Prefix           string    `gorm:"column:prefix" json:"prefix"`
+	StudentFirstname string    `gorm:"column:student_firstname" json:"student_firstname"`
+	StudentLastname  string    `gorm:"column:student_lastname" json:"student_lastname"`
+	FormCreatedAt    time.Time `gorm:"column:form_created_at" json:"form_created_at"`
+}
+
+func (AnnouncementItem) TableName() string {
+	return "Announcement_Item"
+}
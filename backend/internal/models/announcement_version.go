@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// สถานะของแต่ละฉบับของประกาศ
+const (
+	AnnouncementVersionDraft      = "draft"
+	AnnouncementVersionScheduled  = "scheduled"
+	AnnouncementVersionPublished  = "published"
+	AnnouncementVersionSuperseded = "superseded" // ถูกแทนที่ด้วยฉบับแก้ไข
+	AnnouncementVersionRetracted  = "retracted"
+)
+
+// AnnouncementVersion ฉบับหนึ่งของประกาศ ฉบับที่เผยแพร่แล้วจะไม่ถูกแก้ไข การแก้ไขต้องสร้างฉบับใหม่
+type AnnouncementVersion struct {
+	VersionID      uint       `gorm:"primaryKey;column:version_id" json:"version_id"`
+	AnnouncementID uint       `gorm:"column:announcement_id;not null;uniqueIndex:idx_announcement_version" json:"announcement_id"`
+	Version        int        `gorm:"column:version;not null;uniqueIndex:idx_announcement_version" json:"version"`
+	Status         string     `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Note           string     `gorm:"column:note;type:text" json:"note,omitempty"` // เหตุผลการแก้ไข
+	ItemCount      int        `gorm:"column:item_count" json:"item_count"`
+	ScheduledAt    *time.Time `gorm:"column:scheduled_at" json:"scheduled_at"`
+	PublishedAt    *time.Time `gorm:"column:published_at" json:"published_at"`
+	PublishedBy    *uint      `gorm:"column:published_by" json:"published_by"`
+	CreatedBy      uint       `gorm:"column:created_by" json:"created_by"`
+	CreatedAt      time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (AnnouncementVersion) TableName() string {
+	return "Announcement_Version"
+}
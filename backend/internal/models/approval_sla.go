@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ปลายทางของการ escalate เมื่อฟอร์มค้างเกินกำหนด
+const (
+	SLAEscalateNextLevel          = "next_level"          // ผู้พิจารณาขั้นถัดไป (หัวหน้าภาค -> รองคณบดี -> คณบดี -> กองพัฒนานิสิต)
+	SLAEscalateStudentDevelopment = "student_development" // กองพัฒนานิสิต (role 5) ของวิทยาเขต
+)
+
+// ApprovalSLA ระยะเวลาที่ฟอร์มอยู่ในแต่ละขั้นได้ นับจาก step_started_at ของฟอร์ม
+// FormStatusID คือสถานะที่รอการพิจารณา (เช่น 1 = รอหัวหน้าภาค) DurationHours เป็น 0 = ไม่กำหนด SLA
+type ApprovalSLA struct {
+	FormStatusID        int       `gorm:"primaryKey;autoIncrement:false;column:form_status_id" json:"form_status_id"`
+	StepName            string    `gorm:"column:step_name;type:varchar(100)" json:"step_name"`
+	DurationHours       int       `gorm:"column:duration_hours;not null;default:0" json:"duration_hours"`
+	ReminderBeforeHours int       `gorm:"column:reminder_before_hours;not null;default:0" json:"reminder_before_hours"`
+	EscalateTo          string    `gorm:"column:escalate_to;type:varchar(32);not null;default:'student_development'" json:"escalate_to"`
+	UpdatedBy           *uint     `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	UpdatedAt           time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (ApprovalSLA) TableName() string {
+	return "Approval_SLA"
+}
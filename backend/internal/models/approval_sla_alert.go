@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ชนิดของการแจ้งเตือน SLA
+const (
+	SLAAlertReminder   = "reminder"
+	SLAAlertEscalation = "escalation"
+)
+
+// ApprovalSLAAlert บันทึกว่าส่งการเตือน/escalate ของฟอร์มในขั้นนั้นไปแล้ว (กันส่งซ้ำทุกรอบของ scheduler)
+// StepStartedAt คือ step_started_at ของฟอร์มตอนที่เข้าสู่ขั้นนั้น ถ้าฟอร์มเปลี่ยนขั้นจะนับใหม่
+type ApprovalSLAAlert struct {
+	AlertID        uint      `gorm:"primaryKey;column:alert_id" json:"alert_id"`
+	FormID         uint      `gorm:"column:form_id;not null;uniqueIndex:idx_sla_alert_step" json:"form_id"`
+	FormStatusID   int       `gorm:"column:form_status_id;not null;uniqueIndex:idx_sla_alert_step" json:"form_status_id"`
+	StepStartedAt  time.Time `gorm:"column:step_started_at;not null;uniqueIndex:idx_sla_alert_step" json:"step_started_at"`
+	Kind           string    `gorm:"column:kind;type:varchar(16);not null;uniqueIndex:idx_sla_alert_step" json:"kind"`
+	RecipientCount int       `gorm:"column:recipient_count" json:"recipient_count"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (ApprovalSLAAlert) TableName() string {
+	return "Approval_SLA_Alert"
+}
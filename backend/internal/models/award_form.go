@@ -6,32 +6,52 @@ import (
 
 type AwardForm struct {
 	FormID             uint      `gorm:"primaryKey;column:form_id" json:"form_id"`
-	UserID             uint      `gorm:"uniqueIndex:idx_user_semester;column:user_id" json:"user_id"`
+	UserID             uint      `gorm:"column:user_id;index" json:"user_id"` // ผู้ส่งฟอร์ม: นิสิตเอง หรือองค์กรที่เสนอชื่อ (ดู SubmitterRoleID)
 	StudentFirstname   string    `gorm:"column:student_firstname" json:"student_firstname"`
 	StudentLastname    string    `gorm:"column:student_lastname" json:"student_lastname"`
 	StudentEmail       string    `gorm:"column:student_email" json:"student_email"`
-	StudentNumber      string    `gorm:"column:student_number" json:"student_number"`
+	StudentNumber      string    `gorm:"uniqueIndex:idx_nominee_award_term,where:form_status_id <> 14;column:student_number" json:"student_number"`
 	FacultyID          int       `gorm:"column:faculty_id" json:"faculty_id"`
 	DepartmentID       int       `gorm:"column:department_id" json:"department_id"`
 	CampusID           int       `gorm:"column:campus_id" json:"campus_id"`
-	AcademicYear       int       `gorm:"uniqueIndex:idx_user_semester;column:academic_year" json:"academic_year"`
-	Semester           int       `gorm:"uniqueIndex:idx_user_semester;column:semester" json:"semester"`
+	AcademicYear       int       `gorm:"uniqueIndex:idx_nominee_award_term;column:academic_year" json:"academic_year"`
+	Semester           int       `gorm:"uniqueIndex:idx_nominee_award_term;column:semester" json:"semester"`
 	FormStatusID       int       `gorm:"column:form_status_id" json:"form_status"`
 	AwardType          string    `gorm:"column:award_type" json:"award_type"`
+	AwardTypeID        *uint     `gorm:"uniqueIndex:idx_nominee_award_term;column:award_type_id;index" json:"award_type_id"` // FK -> Award_Type (award_type เก็บชื่อภาษาไทยขณะส่ง)
 	CreatedAt          time.Time `gorm:"column:created_at" json:"created_at"`
 	LatestUpdate       time.Time `gorm:"column:latest_update" json:"latest_update"`
+	StepStartedAt      time.Time `gorm:"column:step_started_at" json:"step_started_at"` // เวลาที่เข้าสู่สถานะปัจจุบัน เปลี่ยนเฉพาะตอนเปลี่ยนสถานะ (ใช้นับ SLA)
 	StudentYear        int       `gorm:"column:student_year" json:"student_year"`
 	AdvisorName        string    `gorm:"column:advisor_name" json:"advisor_name"`
-	StudentPhoneNumber string    `gorm:"column:student_phone_number" json:"student_phone_number"`
-	StudentAddress     string    `gorm:"column:student_address" json:"student_address"`
-	GPA                float64   `gorm:"column:gpa" json:"gpa"`
-	StudentDateOfBirth time.Time `gorm:"column:student_date_of_birth;type:date" json:"student_date_of_birth"`
+	// ข้อมูลส่วนบุคคล 4 ฟิลด์นี้เข้ารหัสแบบ envelope ในฐานข้อมูล (internal/fieldcrypto) คอลัมน์จึงเป็น text
+	StudentPhoneNumber string    `gorm:"column:student_phone_number;type:text;serializer:encrypted" json:"student_phone_number"`
+	StudentAddress     string    `gorm:"column:student_address;type:text;serializer:encrypted" json:"student_address"`
+	GPA                float64   `gorm:"column:gpa;type:text;serializer:encrypted" json:"gpa"`
+	StudentDateOfBirth time.Time `gorm:"column:student_date_of_birth;type:text;serializer:encrypted" json:"student_date_of_birth"`
 	OrgName            string    `gorm:"column:org_name" json:"org_name"`
 	OrgType            string    `gorm:"column:org_type" json:"org_type"`
 	OrgLocation        string    `gorm:"column:org_location" json:"org_location"`
 	OrgPhoneNumber     string    `gorm:"column:org_phone_number" json:"org_phone_number"`
 	FormDetail         string    `gorm:"column:form_detail" json:"form_detail"`
-	RejectReason       string    `gorm:"column:reject_reason" json:"reject_reason"`
+
+	// FormAnswers คำตอบของฟิลด์ตาม AwardType.FormFields (ตรวจสอบแล้วตอนส่งฟอร์ม)
+	FormAnswers map[string]interface{} `gorm:"column:form_answers;type:jsonb;serializer:json" json:"form_answers"`
+
+	RejectReason string `gorm:"column:reject_reason" json:"reject_reason"`
+
+	// PersonalDataPurgedAt เวลาที่ข้อมูลส่วนบุคคลถูกลบตามนโยบายการเก็บรักษา (nil = ยังไม่ถูกลบ)
+	PersonalDataPurgedAt *time.Time `gorm:"column:personal_data_purged_at" json:"personal_data_purged_at,omitempty"`
+
+	// ผู้ถูกเสนอชื่อ: 1 นิสิตได้ 1 ฟอร์มต่อประเภทรางวัลต่อภาคเรียน (idx_nominee_award_term)
+	// ฟอร์มที่นิสิตปฏิเสธการเสนอชื่อ (สถานะ 14) ไม่นับ จึงเสนอชื่อใหม่ได้
+	// NomineeStudentID/NomineeUserID ผูกกับ Student ตาม StudentNumber (nil = ยังไม่มีบัญชีนิสิตในระบบ)
+	SubmitterRoleID  int   `gorm:"column:submitter_role_id;not null;default:1" json:"submitter_role_id"` // 1 นิสิตส่งเอง, 8 องค์กรเสนอชื่อ
+	NomineeStudentID *uint `gorm:"column:nominee_student_id;index" json:"nominee_student_id"`
+	NomineeUserID    *uint `gorm:"column:nominee_user_id;index" json:"nominee_user_id"`
+
+	// NomineeRespondedAt เวลาที่นิสิตตอบรับ/ปฏิเสธการเสนอชื่อขององค์กร (nil = ยังไม่ตอบ หรือส่งเอง)
+	NomineeRespondedAt *time.Time `gorm:"column:nominee_responded_at" json:"nominee_responded_at,omitempty"`
 
 	// Relationships
 	AwardFiles []AwardFileDirectory `gorm:"foreignKey:FormID" json:"award_files"`
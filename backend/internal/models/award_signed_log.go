@@ -7,6 +7,13 @@ type AwardSignedLog struct {
 	FormID      uint      `gorm:"column:form_id;not null;index" json:"form_id"`
 	UserID      uint      `gorm:"column:user_id;not null;index" json:"user_id"`
 	SignedAt    time.Time `gorm:"column:signed_at;not null" json:"signed_at"`
+
+	// ลายมือชื่อดิจิทัลของ canonical hash (ฟอร์ม + ไฟล์แนบ) ณ เวลาที่ลงนาม
+	FormStatusID int    `gorm:"column:form_status_id" json:"form_status_id"`
+	KeyID        uint   `gorm:"column:key_id" json:"key_id"`
+	Algorithm    string `gorm:"column:algorithm;type:varchar(20)" json:"algorithm"`
+	ContentHash  string `gorm:"column:content_hash;type:varchar(64)" json:"content_hash"` // hex sha256
+	Signature    string `gorm:"column:signature;type:text" json:"signature"`              // base64
 }
 
 func (AwardSignedLog) TableName() string {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AwardStatusLog ประวัติการเปลี่ยนสถานะของฟอร์มทุกครั้ง ใช้คำนวณระยะเวลาที่ฟอร์มอยู่ในแต่ละขั้น
+type AwardStatusLog struct {
+	StatusLogID  uint `gorm:"primaryKey;column:status_log_id" json:"status_log_id"`
+	FormID       uint `gorm:"column:form_id;not null;index" json:"form_id"`
+	FromStatusID int  `gorm:"column:from_status_id;index" json:"from_status_id"`
+	ToStatusID   int  `gorm:"column:to_status_id" json:"to_status_id"`
+	// EnteredAt เวลาที่ฟอร์มเข้าสู่ FromStatusID (เวลาเปลี่ยนสถานะครั้งก่อน หรือเวลาส่งฟอร์ม)
+	EnteredAt time.Time `gorm:"column:entered_at;not null" json:"entered_at"`
+	ChangedAt time.Time `gorm:"column:changed_at;not null" json:"changed_at"`
+}
+
+func (AwardStatusLog) TableName() string {
+	return "Award_Status_Log"
+}
@@ -1,15 +1,78 @@
 package models
 
+import "time"
+
+// หมวดของประเภทรางวัล ใช้จัดกลุ่มในการค้นหาและหน้าประกาศผล
+const (
+	AwardCategoryExtracurricular = "extracurricular"
+	AwardCategoryCreativity      = "creativity"
+	AwardCategoryBehavior        = "behavior"
+	AwardCategoryOther           = "other"
+)
+
+// AwardType ประเภทรางวัลในแคตตาล็อก พร้อมเงื่อนไขคุณสมบัติผู้มีสิทธิ์ถูกเสนอชื่อ
+// เงื่อนไขที่ว่าง (nil/ไม่มีรายการ) = ไม่จำกัด
 type AwardType struct {
-    AwardTypeID uint   `gorm:"primaryKey;column:award_type_id" json:"award_type_id"`
-    AwardName   string `gorm:"column:award_name" json:"award_name"`
-    Description string `gorm:"column:description" json:"description"`
+	AwardTypeID uint   `gorm:"primaryKey;column:award_type_id" json:"award_type_id"`
+	Code        string `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	NameTH      string `gorm:"column:name_th;not null" json:"name_th"`
+	NameEN      string `gorm:"column:name_en" json:"name_en"`
+	Description string `gorm:"column:description;type:text" json:"description"`
+	Category    string `gorm:"column:category;type:varchar(30);not null;default:'other'" json:"category"`
+	// IsActive ค่าเริ่มต้นของทุกปีการศึกษา ปีที่ตั้งค่าไว้ใน AwardTypeYear จะใช้ค่าของปีนั้นแทน
+	IsActive bool `gorm:"column:is_active;not null;default:true" json:"is_active"`
+
+	MinGPA              *float64 `gorm:"column:min_gpa" json:"min_gpa"`
+	AllowedStudentYears []int    `gorm:"column:allowed_student_years;type:jsonb;serializer:json" json:"allowed_student_years"`
+	AllowedRoles        []int    `gorm:"column:allowed_roles;type:jsonb;serializer:json" json:"allowed_roles"` // role ของผู้ส่งฟอร์ม (1 นิสิต, 8 องค์กร)
+
+	// LegacyNames ชื่อที่เคยใช้ใน Award_Form.award_type ก่อนมีแคตตาล็อก ใช้จับคู่ข้อมูลเก่าและค่าที่ frontend ส่งมา
+	LegacyNames []string `gorm:"column:legacy_names;type:jsonb;serializer:json" json:"legacy_names"`
+
+	// FormFields ฟิลด์เพิ่มเติมของฟอร์มสำหรับประเภทรางวัลนี้ คำตอบเก็บใน Award_Form.form_answers
+	FormFields []AwardFormField `gorm:"column:form_fields;type:jsonb;serializer:json" json:"form_fields"`
+
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
 
 	// Relationship
-	AwardForms []AwardForm `gorm:"foreignKey:AwardTypeID" json:"award_forms,omitempty"`
+	AwardForms []AwardForm `gorm:"foreignKey:AwardTypeID" json:"-"`
+}
+
+// ชนิดของฟิลด์ในฟอร์มของแต่ละประเภทรางวัล
+const (
+	FormFieldText        = "text"
+	FormFieldTextarea    = "textarea"
+	FormFieldNumber      = "number"
+	FormFieldInteger     = "integer"
+	FormFieldBoolean     = "boolean"
+	FormFieldDate        = "date" // YYYY-MM-DD
+	FormFieldEmail       = "email"
+	FormFieldPhone       = "phone"
+	FormFieldSelect      = "select"
+	FormFieldMultiSelect = "multiselect"
+	FormFieldList        = "list" // รายการของ object ตาม Fields (ซ้อนได้ชั้นเดียว)
+)
+
+// AwardFormField นิยามฟิลด์หนึ่งในฟอร์ม (คล้าย JSON schema แบบย่อ)
+// ค่าจำกัดที่เป็น nil = ไม่จำกัด
+type AwardFormField struct {
+	Key         string           `json:"key"`
+	Label       string           `json:"label"`
+	Type        string           `json:"type"`
+	Required    bool             `json:"required"`
+	Description string           `json:"description,omitempty"`
+	Options     []string         `json:"options,omitempty"` // select, multiselect
+	MinLength   *int             `json:"min_length,omitempty"`
+	MaxLength   *int             `json:"max_length,omitempty"`
+	Min         *float64         `json:"min,omitempty"` // number, integer
+	Max         *float64         `json:"max,omitempty"`
+	MinItems    *int             `json:"min_items,omitempty"` // multiselect, list
+	MaxItems    *int             `json:"max_items,omitempty"`
+	Fields      []AwardFormField `json:"fields,omitempty"` // list
 }
 
 // TableName กำหนดชื่อตารางให้เป็น "Award_Type"
 func (AwardType) TableName() string {
 	return "Award_Type"
-}
\ No newline at end of file
+}
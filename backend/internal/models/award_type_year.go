@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// AwardTypeYear เปิด/ปิดการรับเสนอชื่อประเภทรางวัลในแต่ละปีการศึกษา (ไม่มี record = ใช้ AwardType.IsActive)
+type AwardTypeYear struct {
+	AwardTypeID  uint      `gorm:"primaryKey;column:award_type_id" json:"award_type_id"`
+	AcademicYear int       `gorm:"primaryKey;column:academic_year" json:"academic_year"`
+	IsActive     bool      `gorm:"column:is_active;not null" json:"is_active"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (AwardTypeYear) TableName() string {
+	return "Award_Type_Year"
+}
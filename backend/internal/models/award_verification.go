@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AwardVerification เก็บรหัสตรวจสอบเกียรติบัตรของฟอร์มที่เสร็จสิ้นแล้ว (1 ฟอร์ม ต่อ 1 รหัส)
+type AwardVerification struct {
+	VerificationID uint       `gorm:"primaryKey;column:verification_id" json:"verification_id"`
+	FormID         uint       `gorm:"column:form_id;not null;uniqueIndex" json:"form_id"`
+	Code           string     `gorm:"column:code;type:varchar(32);not null;uniqueIndex" json:"code"`
+	IssuedAt       time.Time  `gorm:"column:issued_at;not null" json:"issued_at"`
+	IsRevoked      bool       `gorm:"column:is_revoked;default:false" json:"is_revoked"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+	RevokedBy      *uint      `gorm:"column:revoked_by" json:"revoked_by,omitempty"`
+	RevokeReason   string     `gorm:"column:revoke_reason;type:text" json:"revoke_reason,omitempty"`
+}
+
+func (AwardVerification) TableName() string {
+	return "Award_Verification"
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// สถานะคำขอลบข้อมูลส่วนบุคคล
+const (
+	ErasureStatusPending   = "pending"
+	ErasureStatusRejected  = "rejected"
+	ErasureStatusCompleted = "completed"
+)
+
+// ErasureRequest คำขอลบข้อมูลส่วนบุคคลของเจ้าของข้อมูล (PDPA) กองพัฒนานิสิตตรวจก่อนดำเนินการ
+// เมื่ออนุมัติ ผู้ใช้จะถูกทำให้ไม่ระบุตัวตน ฟอร์มที่ยังไม่เสร็จสิ้นถูกลบ ส่วนฟอร์มที่เสร็จสิ้นแล้วเก็บไว้โดยไม่มีข้อมูลระบุตัวตน
+type ErasureRequest struct {
+	RequestID   uint       `gorm:"primaryKey;column:request_id" json:"request_id"`
+	UserID      uint       `gorm:"column:user_id;not null;index" json:"user_id"`
+	Reason      string     `gorm:"column:reason;type:text" json:"reason"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"` // pending | rejected | completed
+	RequestedAt time.Time  `gorm:"column:requested_at;not null" json:"requested_at"`
+	ReviewedBy  *uint      `gorm:"column:reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `gorm:"column:reviewed_at" json:"reviewed_at,omitempty"`
+	ReviewNote  string     `gorm:"column:review_note;type:text" json:"review_note,omitempty"`
+	Summary     string     `gorm:"column:summary;type:text" json:"summary,omitempty"` // สรุปสิ่งที่ถูกลบ/ทำให้ไม่ระบุตัวตน
+}
+
+func (ErasureRequest) TableName() string {
+	return "Erasure_Request"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// สถานะของงานในคิว (dead = ลองครบจำนวนครั้งแล้วยังล้มเหลว รอผู้ดูแลสั่ง retry)
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job งานเบื้องหลังที่ worker ดึงไปทำ (ตาราง "Job" ใช้เป็นคิวด้วย SELECT ... FOR UPDATE SKIP LOCKED)
+// UniqueKey ใช้กันการสร้างงานซ้ำ เช่น งานจาก cron รอบเดียวกันที่หลาย worker สร้างพร้อมกัน
+type Job struct {
+	JobID       uint       `gorm:"primaryKey;column:job_id" json:"job_id"`
+	Type        string     `gorm:"column:type;type:varchar(64);not null;index" json:"type"`
+	Payload     string     `gorm:"column:payload;type:jsonb;not null;default:'{}'" json:"payload"`
+	Status      string     `gorm:"column:status;type:varchar(16);not null;index:idx_job_status_run_at,priority:1" json:"status"`
+	RunAt       time.Time  `gorm:"column:run_at;not null;index:idx_job_status_run_at,priority:2" json:"run_at"`
+	Attempts    int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	MaxAttempts int        `gorm:"column:max_attempts;not null;default:5" json:"max_attempts"`
+	LastError   string     `gorm:"column:last_error;type:text" json:"last_error,omitempty"`
+	LockedBy    string     `gorm:"column:locked_by;type:varchar(128)" json:"locked_by,omitempty"`
+	LockedAt    *time.Time `gorm:"column:locked_at" json:"locked_at,omitempty"`
+	UniqueKey   *string    `gorm:"column:unique_key;type:varchar(191);uniqueIndex" json:"unique_key,omitempty"`
+	CreatedBy   *uint      `gorm:"column:created_by" json:"created_by,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	FinishedAt  *time.Time `gorm:"column:finished_at" json:"finished_at,omitempty"`
+}
+
+func (Job) TableName() string {
+	return "Job"
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// JobSchedule งานที่สร้างตามรอบเวลา CronExpr เป็นรูปแบบ 5 ช่อง (นาที ชั่วโมง วัน เดือน วันในสัปดาห์) ตามเวลาของ server
+type JobSchedule struct {
+	Name      string     `gorm:"primaryKey;column:name;type:varchar(64)" json:"name"`
+	Type      string     `gorm:"column:type;type:varchar(64);not null" json:"type"`
+	Payload   string     `gorm:"column:payload;type:jsonb;not null;default:'{}'" json:"payload"`
+	CronExpr  string     `gorm:"column:cron_expr;type:varchar(64);not null" json:"cron_expr"`
+	Enabled   bool       `gorm:"column:enabled;not null;default:false" json:"enabled"`
+	NextRunAt *time.Time `gorm:"column:next_run_at" json:"next_run_at,omitempty"`
+	LastRunAt *time.Time `gorm:"column:last_run_at" json:"last_run_at,omitempty"`
+	UpdatedBy *uint      `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (JobSchedule) TableName() string {
+	return "Job_Schedule"
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Notification การแจ้งเตือนในระบบ (inbox) ของผู้ใช้แต่ละคน
+type Notification struct {
+	NotificationID uint       `gorm:"primaryKey;column:notification_id" json:"notification_id"`
+	UserID         uint       `gorm:"column:user_id;not null;index:idx_notification_user_read" json:"user_id"`
+	FormID         *uint      `gorm:"column:form_id;index" json:"form_id,omitempty"`
+	EventType      string     `gorm:"column:event_type;type:varchar(40);not null" json:"event_type"`
+	Title          string     `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Message        string     `gorm:"column:message;type:text" json:"message"`
+	IsRead         bool       `gorm:"column:is_read;default:false;index:idx_notification_user_read" json:"is_read"`
+	ReadAt         *time.Time `gorm:"column:read_at" json:"read_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:created_at" json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "Notification"
+}
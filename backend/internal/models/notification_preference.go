@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// NotificationPreference การเปิด/ปิดช่องทางแจ้งเตือนของผู้ใช้ (ไม่มี record = ใช้ค่าเริ่มต้นคือเปิด)
+type NotificationPreference struct {
+	UserID    uint      `gorm:"primaryKey;column:user_id" json:"user_id"`
+	Channel   string    `gorm:"primaryKey;column:channel;type:varchar(20)" json:"channel"` // in_app | email
+	Enabled   bool      `gorm:"column:enabled;not null" json:"enabled"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "Notification_Preference"
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PublicationPreference ความยินยอมให้แสดงชื่อในประกาศผลสาธารณะของนิสิต (ไม่มี record = ยินยอม)
+type PublicationPreference struct {
+	UserID    uint      `gorm:"primaryKey;column:user_id" json:"user_id"`
+	OptOut    bool      `gorm:"column:opt_out;not null;default:false" json:"opt_out"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (PublicationPreference) TableName() string {
+	return "Publication_Preference"
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ประเภทข้อมูลที่อยู่ภายใต้นโยบายการเก็บรักษา
+const (
+	RetentionClassContact     = "contact"       // ที่อยู่และเบอร์โทรศัพท์ของนิสิตในฟอร์ม
+	RetentionClassDateOfBirth = "date_of_birth" // วันเกิดของนิสิตในฟอร์ม
+	RetentionClassGPA         = "gpa"           // เกรดเฉลี่ยในฟอร์ม
+	RetentionClassAttachments = "attachments"   // ไฟล์แนบ (Award_File_Directory)
+	RetentionClassIdentity    = "identity"      // ชื่อ อีเมล และรหัสนิสิตในฟอร์ม (แทนด้วยนามแฝง ไม่ลบฟอร์ม)
+)
+
+// RetentionPolicy ระยะเวลาเก็บข้อมูลส่วนบุคคลแต่ละประเภท (ตาม PDPA)
+// RetainYears คือจำนวนปีการศึกษาที่เก็บไว้ก่อนถูกลบ ถ้าเป็น 0 จะไม่ลบข้อมูลประเภทนี้
+type RetentionPolicy struct {
+	DataClass   string    `gorm:"primaryKey;column:data_class;type:varchar(32)" json:"data_class"`
+	RetainYears int       `gorm:"column:retain_years;not null;default:0" json:"retain_years"`
+	Description string    `gorm:"column:description;type:text" json:"description"`
+	UpdatedBy   *uint     `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (RetentionPolicy) TableName() string {
+	return "Retention_Policy"
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// RetentionPurgeRun รายงานการลบข้อมูลตามนโยบายการเก็บรักษาแต่ละครั้ง
+// TriggeredBy เป็น nil เมื่อรันจาก scheduler
+type RetentionPurgeRun struct {
+	RunID         uint       `gorm:"primaryKey;column:run_id" json:"run_id"`
+	StartedAt     time.Time  `gorm:"column:started_at;not null" json:"started_at"`
+	FinishedAt    *time.Time `gorm:"column:finished_at" json:"finished_at,omitempty"`
+	TriggeredBy   *uint      `gorm:"column:triggered_by" json:"triggered_by,omitempty"`
+	DryRun        bool       `gorm:"column:dry_run;default:false" json:"dry_run"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null" json:"status"` // running | success | failed
+	CurrentYear   int        `gorm:"column:current_year" json:"current_year"`
+	FormsAffected int        `gorm:"column:forms_affected" json:"forms_affected"`
+	FilesDeleted  int        `gorm:"column:files_deleted" json:"files_deleted"`
+	BytesFreed    int64      `gorm:"column:bytes_freed" json:"bytes_freed"`
+	ErrorMessage  string     `gorm:"column:error_message;type:text" json:"error_message,omitempty"`
+
+	// Relationships
+	Items []RetentionPurgeItem `gorm:"foreignKey:RunID" json:"items,omitempty"`
+}
+
+func (RetentionPurgeRun) TableName() string {
+	return "Retention_Purge_Run"
+}
+
+// RetentionPurgeItem รายการที่ถูกลบในแต่ละรอบ (1 ฟอร์ม ต่อ 1 ประเภทข้อมูล หรือ 1 ไฟล์)
+type RetentionPurgeItem struct {
+	ItemID       uint   `gorm:"primaryKey;column:item_id" json:"item_id"`
+	RunID        uint   `gorm:"column:run_id;not null;index" json:"run_id"`
+	FormID       uint   `gorm:"column:form_id;not null;index" json:"form_id"`
+	AcademicYear int    `gorm:"column:academic_year" json:"academic_year"`
+	DataClass    string `gorm:"column:data_class;type:varchar(32)" json:"data_class"`
+	Detail       string `gorm:"column:detail;type:text" json:"detail"`
+	BytesFreed   int64  `gorm:"column:bytes_freed" json:"bytes_freed"`
+}
+
+func (RetentionPurgeItem) TableName() string {
+	return "Retention_Purge_Item"
+}
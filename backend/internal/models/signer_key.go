@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SignerKey กุญแจลงนามของผู้ลงนาม (ประธานคณะกรรมการ / อธิการบดี) ที่ระบบสร้างและดูแลให้
+// PrivateKey ถูกเข้ารหัสด้วย master key ของระบบก่อนบันทึก
+type SignerKey struct {
+	KeyID               uint       `gorm:"primaryKey;column:key_id" json:"key_id"`
+	UserID              uint       `gorm:"column:user_id;not null;index" json:"user_id"`
+	Algorithm           string     `gorm:"column:algorithm;type:varchar(20);not null" json:"algorithm"`
+	PublicKey           string     `gorm:"column:public_key;type:text;not null" json:"public_key"` // base64
+	EncryptedPrivateKey string     `gorm:"column:encrypted_private_key;type:text;not null" json:"-"`
+	Fingerprint         string     `gorm:"column:fingerprint;type:varchar(64);not null" json:"fingerprint"`
+	IsActive            bool       `gorm:"column:is_active;default:true" json:"is_active"`
+	CreatedAt           time.Time  `gorm:"column:created_at" json:"created_at"`
+	RetiredAt           *time.Time `gorm:"column:retired_at" json:"retired_at,omitempty"`
+}
+
+func (SignerKey) TableName() string {
+	return "Signer_Key"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ขั้นของช่วงเวลาใน SubmissionWindow: 0 = ส่งฟอร์ม, ค่าอื่นคือ role ของผู้พิจารณาขั้นนั้น (2-7)
+const SubmissionStageSubmit = 0
+
+// SubmissionWindow ช่วงเวลาเปิดรับ/พิจารณาภายในภาคเรียนของวิทยาเขต
+// AwardTypeID = nil ใช้กับทุกประเภทรางวัล ถ้ามีทั้งสองแบบ ช่วงเวลาของประเภทรางวัลมีผลก่อน
+type SubmissionWindow struct {
+	WindowID       uint      `gorm:"primaryKey;column:window_id" json:"window_id"`
+	AcademicYearID uint      `gorm:"column:academic_year_id;not null;index" json:"academic_year_id"`
+	AwardTypeID    *uint     `gorm:"column:award_type_id" json:"award_type_id,omitempty"`
+	Stage          int       `gorm:"column:stage;not null;default:0" json:"stage"`
+	StartDate      time.Time `gorm:"type:date;column:start_date" json:"start_date"`
+	EndDate        time.Time `gorm:"type:date;column:end_date" json:"end_date"`
+	UpdatedBy      *uint     `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	UpdatedAt      time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (SubmissionWindow) TableName() string {
+	return "Submission_Window"
+}
@@ -0,0 +1,17 @@
+package models
+
+// TermType ประเภทภาคการศึกษาในปฏิทินของวิทยาเขต เช่น ภาคเรียนที่ 1, ภาคฤดูร้อน, ไตรภาคที่ 3
+// Semester คือเลขที่เก็บใน Academic_Year และ Award_Form, Sequence คือลำดับภายในปีการศึกษา
+// CampusID = 0 คือค่ากลาง ใช้กับวิทยาเขตที่ยังไม่ได้กำหนดเอง, IsOptional = ข้ามได้เมื่อขึ้นภาคเรียนถัดไป (เช่น ภาคฤดูร้อน)
+type TermType struct {
+	TermTypeID uint   `gorm:"primaryKey;column:term_type_id" json:"term_type_id"`
+	CampusID   int    `gorm:"column:campus_id;not null;default:0;uniqueIndex:idx_term_type_campus_semester" json:"campus_id"`
+	Semester   int    `gorm:"column:semester;not null;uniqueIndex:idx_term_type_campus_semester" json:"semester"`
+	Sequence   int    `gorm:"column:sequence;not null" json:"sequence"`
+	Name       string `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	IsOptional bool   `gorm:"column:is_optional;not null;default:false" json:"is_optional"`
+}
+
+func (TermType) TableName() string {
+	return "Term_Type"
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// สถานะการส่ง webhook (retrying = ล้มเหลวแล้วรอลองใหม่ตาม backoff, failed = ลองครบแล้ว)
+const (
+	WebhookDeliveryPending  = "pending"
+	WebhookDeliverySuccess  = "success"
+	WebhookDeliveryRetrying = "retrying"
+	WebhookDeliveryFailed   = "failed"
+)
+
+// WebhookDelivery บันทึกการส่ง event หนึ่งรายการไปยัง endpoint หนึ่งปลายทาง (ผลของความพยายามครั้งล่าสุด)
+// EventID เหมือนกันทุก endpoint ของ event เดียวกัน ผู้รับใช้กันการประมวลผลซ้ำได้
+type WebhookDelivery struct {
+	DeliveryID     uint       `gorm:"primaryKey;column:delivery_id" json:"delivery_id"`
+	EndpointID     uint       `gorm:"column:endpoint_id;not null;index" json:"endpoint_id"`
+	EventID        string     `gorm:"column:event_id;type:varchar(64);not null;index" json:"event_id"`
+	EventType      string     `gorm:"column:event_type;type:varchar(64);not null" json:"event_type"`
+	Payload        string     `gorm:"column:payload;type:jsonb;not null" json:"payload"`
+	Status         string     `gorm:"column:status;type:varchar(16);not null;index" json:"status"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	ResponseStatus int        `gorm:"column:response_status" json:"response_status,omitempty"`
+	ResponseBody   string     `gorm:"column:response_body;type:text" json:"response_body,omitempty"`
+	Error          string     `gorm:"column:error;type:text" json:"error,omitempty"`
+	DurationMs     int64      `gorm:"column:duration_ms" json:"duration_ms"`
+	IsTest         bool       `gorm:"column:is_test;not null;default:false" json:"is_test"`
+	CreatedAt      time.Time  `gorm:"column:created_at" json:"created_at"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at" json:"delivered_at,omitempty"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "Webhook_Delivery"
+}
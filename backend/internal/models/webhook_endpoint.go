@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// เหตุการณ์ที่ส่งออกไปยัง webhook
+const (
+	WebhookEventAwardSubmitted     = "award.submitted"
+	WebhookEventAwardStatusChanged = "award.status_changed"
+	WebhookEventAwardCompleted     = "award.completed"
+	WebhookEventCommitteeVoteCast  = "committee.vote_cast"
+	WebhookEventTest               = "webhook.test"
+)
+
+// WebhookEndpoint ปลายทางที่ผู้ดูแลลงทะเบียนไว้ Events คือรายชื่อเหตุการณ์คั่นด้วย comma
+// Secret ใช้สร้างลายเซ็น HMAC-SHA256 ของ payload (ไม่ส่งออกทาง JSON)
+type WebhookEndpoint struct {
+	EndpointID uint      `gorm:"primaryKey;column:endpoint_id" json:"endpoint_id"`
+	Name       string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	URL        string    `gorm:"column:url;type:text;not null" json:"url"`
+	Secret     string    `gorm:"column:secret;type:varchar(128);not null" json:"-"`
+	Events     string    `gorm:"column:events;type:text;not null" json:"events"`
+	IsActive   bool      `gorm:"column:is_active;not null;default:true" json:"is_active"`
+	CreatedBy  uint      `gorm:"column:created_by" json:"created_by"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "Webhook_Endpoint"
+}
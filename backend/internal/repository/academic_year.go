@@ -3,18 +3,61 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// สถานะของฟอร์ม (ดู SeedFormStatus): ฟอร์มที่ไม่อยู่ในสถานะสุดท้ายถือว่ายังพิจารณาไม่เสร็จ
+const (
+	formStatusCompleted = 12
+	formStatusDeclined  = 14
+	formStatusExpired   = 15
+)
+
+var (
+	formRejectedStatuses = []int{3, 5, 7, 10}
+	formFinalStatuses    = []int{3, 5, 7, 10, formStatusCompleted, formStatusDeclined, formStatusExpired}
+)
+
+// ปฏิทินกลางครอบคลุมวิทยาเขตที่ไม่มีภาคเรียนเดียวกันในปฏิทินของตัวเอง
+const defaultCalendarCampusFilter = `campus_id NOT IN (SELECT campus_id FROM "Academic_Year" WHERE year = ? AND semester = ? AND campus_id <> 0)`
+
+// termSequenceOrder ลำดับภาคการศึกษาภายในปีตาม Term_Type ของวิทยาเขต (ไม่มีใช้ของปฏิทินกลาง ไม่มีทั้งคู่ใช้เลขภาคเรียน)
+const termSequenceOrder = `COALESCE((SELECT tt.sequence FROM "Term_Type" tt WHERE tt.semester = "Academic_Year".semester AND tt.campus_id IN ("Academic_Year".campus_id, 0) ORDER BY tt.campus_id DESC LIMIT 1), "Academic_Year".semester)`
+
 type AcademicYearRepository interface {
 	Create(ctx context.Context, academicYear *models.AcademicYear) error
 	GetByID(ctx context.Context, id uint) (*models.AcademicYear, error)
 	GetAll(ctx context.Context) ([]models.AcademicYear, error)
+	GetByCampus(ctx context.Context, campusID int) ([]models.AcademicYear, error)
 	Update(ctx context.Context, academicYear *models.AcademicYear) error
 	Delete(ctx context.Context, id uint) error
-	GetCurrentSemester(ctx context.Context) (*models.AcademicYear, error)
-	GetLatestAbleRegister(ctx context.Context) (*models.AcademicYear, error)
+	GetCurrentSemester(ctx context.Context, campusID int) (*models.AcademicYear, error)
+	GetLatestAbleRegister(ctx context.Context, campusID int) (*models.AcademicYear, error)
+	GetTerm(ctx context.Context, campusID int, year int, semester int) (*models.AcademicYear, error)
+	// GetTermTypes ประเภทภาคการศึกษาที่วิทยาเขตกำหนดเอง (ไม่รวมปฏิทินกลาง) เรียงตามลำดับในปี
+	GetTermTypes(ctx context.Context, campusID int) ([]models.TermType, error)
+	// ReplaceTermTypes แทนที่ประเภทภาคการศึกษาทั้งหมดของวิทยาเขตใน transaction เดียว
+	ReplaceTermTypes(ctx context.Context, campusID int, termTypes []models.TermType) error
+
+	GetTermSummary(ctx context.Context, term *models.AcademicYear) (*models.TermSummary, error)
+	CountUnfinishedForms(ctx context.Context, term *models.AcademicYear) (int64, error)
+	// Transition เปลี่ยนสถานะภาคเรียนและบันทึกรายงานใน transaction เดียวกัน
+	// expireReason ไม่ว่าง = ฟอร์มที่ยังไม่เสร็จถูกปิดเป็นสถานะหมดเวลา (15) ด้วยเหตุผลนี้
+	Transition(ctx context.Context, term *models.AcademicYear, toStatus string, expireReason string, record *models.AcademicYearTransition) error
+	// Rollover สร้างภาคเรียน next และคัดลอกช่วงเวลา (เลื่อนตามวันเริ่มภาคเรียน) คณะกรรมการ และการเปิดรับประเภทรางวัลรายปีจาก source
+	Rollover(ctx context.Context, source *models.AcademicYear, next *models.AcademicYear, record *models.AcademicYearTransition) error
+	GetTransitions(ctx context.Context, academicYearID uint) ([]models.AcademicYearTransition, error)
+	GetCommittee(ctx context.Context, academicYearID uint) ([]models.AcademicYearCommittee, error)
+
+	CreateWindow(ctx context.Context, window *models.SubmissionWindow) error
+	GetWindowByID(ctx context.Context, id uint) (*models.SubmissionWindow, error)
+	GetWindowsByAcademicYear(ctx context.Context, academicYearID uint) ([]models.SubmissionWindow, error)
+	UpdateWindow(ctx context.Context, window *models.SubmissionWindow) error
+	DeleteWindow(ctx context.Context, id uint) error
 }
 
 type academicYearRepository struct {
@@ -47,36 +90,409 @@ func (r *academicYearRepository) GetAll(ctx context.Context) ([]models.AcademicY
 	return academicYears, nil
 }
 
+// GetByCampus: ภาคเรียนในปฏิทินของวิทยาเขต (ไม่รวมปฏิทินกลาง) เรียงตามปีและลำดับภาคการศึกษา
+func (r *academicYearRepository) GetByCampus(ctx context.Context, campusID int) ([]models.AcademicYear, error) {
+	var academicYears []models.AcademicYear
+	err := r.db.WithContext(ctx).
+		Where("campus_id = ?", campusID).
+		Order("year ASC").
+		Order(termSequenceOrder + " ASC").
+		Find(&academicYears).Error
+	if err != nil {
+		return nil, err
+	}
+	return academicYears, nil
+}
+
 func (r *academicYearRepository) Update(ctx context.Context, academicYear *models.AcademicYear) error {
 	return r.db.WithContext(ctx).Save(academicYear).Error
 }
 
+// Delete: ลบภาคเรียนพร้อมช่วงเวลาเปิดรับ คณะกรรมการ และรายงานของภาคเรียนนั้น
 func (r *academicYearRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.AcademicYear{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.SubmissionWindow{}, &models.AcademicYearCommittee{}, &models.AcademicYearTransition{}} {
+			if err := tx.Where("academic_year_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.AcademicYear{}, id).Error
+	})
+}
+
+// GetCurrentSemester: ดึงข้อมูล academic year ล่าสุด (เรียงปี/เทอมล่าสุด) ของวิทยาเขตที่เริ่มแล้ว (ไม่ใช่ upcoming)
+// ถ้าวิทยาเขตยังไม่มีปฏิทินของตัวเอง ใช้ปฏิทินกลาง (campus_id = 0)
+func (r *academicYearRepository) GetCurrentSemester(ctx context.Context, campusID int) (*models.AcademicYear, error) {
+	return r.latestForCampus(ctx, campusID, "status <> ?", models.AcademicYearStatusUpcoming)
+}
+
+// GetLatestAbleRegister: ดึงข้อมูล academic year ล่าสุดที่เปิดรับสมัคร (status = open) ของวิทยาเขต
+func (r *academicYearRepository) GetLatestAbleRegister(ctx context.Context, campusID int) (*models.AcademicYear, error) {
+	return r.latestForCampus(ctx, campusID, "status = ?", models.AcademicYearStatusOpen)
 }
 
-// GetCurrentSemester: ดึงข้อมูล academic year ล่าสุด (เรียงปี/เทอมล่าสุด)
-func (r *academicYearRepository) GetCurrentSemester(ctx context.Context) (*models.AcademicYear, error) {
+// GetTerm: ภาคเรียนตามปี/เทอมของวิทยาเขต ถ้าไม่มีใช้ของปฏิทินกลาง
+func (r *academicYearRepository) GetTerm(ctx context.Context, campusID int, year int, semester int) (*models.AcademicYear, error) {
 	var academicYear models.AcademicYear
 	err := r.db.WithContext(ctx).
-		Order("year DESC").
-		Order("semester DESC").
+		Where("campus_id = ? AND year = ? AND semester = ?", campusID, year, semester).
 		First(&academicYear).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) && campusID != 0 {
+		return r.GetTerm(ctx, 0, year, semester)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &academicYear, nil
 }
 
-// GetLatestAbleRegister: ดึงข้อมูล academic year ล่าสุดที่ใช้รับสมัคร
-func (r *academicYearRepository) GetLatestAbleRegister(ctx context.Context) (*models.AcademicYear, error) {
+func (r *academicYearRepository) GetTermTypes(ctx context.Context, campusID int) ([]models.TermType, error) {
+	var termTypes []models.TermType
+	err := r.db.WithContext(ctx).
+		Where("campus_id = ?", campusID).
+		Order("sequence ASC").
+		Find(&termTypes).Error
+	if err != nil {
+		return nil, err
+	}
+	return termTypes, nil
+}
+
+func (r *academicYearRepository) ReplaceTermTypes(ctx context.Context, campusID int, termTypes []models.TermType) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("campus_id = ?", campusID).Delete(&models.TermType{}).Error; err != nil {
+			return err
+		}
+		if len(termTypes) == 0 {
+			return nil
+		}
+		return tx.Create(&termTypes).Error
+	})
+}
+
+// latestForCampus วิทยาเขตที่มีปฏิทินของตัวเองแล้วจะไม่ใช้ปฏิทินกลาง แม้ไม่มีภาคเรียนที่ตรงเงื่อนไข
+func (r *academicYearRepository) latestForCampus(ctx context.Context, campusID int, query string, args ...interface{}) (*models.AcademicYear, error) {
+	if campusID != 0 {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&models.AcademicYear{}).Where("campus_id = ?", campusID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			campusID = 0
+		}
+	}
+
 	var academicYear models.AcademicYear
 	err := r.db.WithContext(ctx).
+		Where("campus_id = ?", campusID).
+		Where(query, args...).
 		Order("year DESC").
-		Order("semester DESC").
+		Order(termSequenceOrder + " DESC").
 		First(&academicYear).Error
 	if err != nil {
 		return nil, err
 	}
 	return &academicYear, nil
 }
+
+func (r *academicYearRepository) GetTermSummary(ctx context.Context, term *models.AcademicYear) (*models.TermSummary, error) {
+	summary, err := termSummary(r.db.WithContext(ctx), term)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (r *academicYearRepository) CountUnfinishedForms(ctx context.Context, term *models.AcademicYear) (int64, error) {
+	var count int64
+	err := termForms(r.db.WithContext(ctx), term).
+		Where("form_status_id NOT IN ?", formFinalStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *academicYearRepository) Transition(ctx context.Context, term *models.AcademicYear, toStatus string, expireReason string, record *models.AcademicYearTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// ล็อกภาคเรียนกันการเปลี่ยนสถานะซ้อนกัน
+		var current models.AcademicYear
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("academic_year_id = ?", term.AcademicYearID).
+			Take(&current).Error; err != nil {
+			return err
+		}
+		if current.Status != record.FromStatus {
+			return errors.New("academic year status has changed, please reload")
+		}
+
+		expired := 0
+		if expireReason != "" {
+			var err error
+			if expired, err = expireUnfinishedForms(tx, term, expireReason, record.PerformedAt); err != nil {
+				return err
+			}
+		}
+
+		// บันทึกรายชื่อคณะกรรมการของภาคเรียนเมื่อเปิดครั้งแรก
+		if toStatus == models.AcademicYearStatusOpen {
+			var count int64
+			if err := tx.Model(&models.AcademicYearCommittee{}).Where("academic_year_id = ?", term.AcademicYearID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				if _, err := copyCommittee(tx, term, term.AcademicYearID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Model(&models.AcademicYear{}).
+			Where("academic_year_id = ?", term.AcademicYearID).
+			Update("status", toStatus).Error; err != nil {
+			return err
+		}
+		term.Status = toStatus
+
+		summary, err := termSummary(tx, term)
+		if err != nil {
+			return err
+		}
+		summary.ExpiredNow = expired
+		record.AcademicYearID = term.AcademicYearID
+		record.ToStatus = toStatus
+		record.Summary = summary
+		return tx.Create(record).Error
+	})
+}
+
+func (r *academicYearRepository) Rollover(ctx context.Context, source *models.AcademicYear, next *models.AcademicYear, record *models.AcademicYearTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rollover := &models.RolloverSummary{SourceAcademicYearID: source.AcademicYearID}
+
+		// ช่วงเวลาเปิดรับ/พิจารณา เลื่อนตามระยะห่างของวันเริ่มภาคเรียน
+		var windows []models.SubmissionWindow
+		if err := tx.Where("academic_year_id = ?", source.AcademicYearID).Find(&windows).Error; err != nil {
+			return err
+		}
+		offset := next.StartDate.Sub(source.StartDate)
+		for _, window := range windows {
+			window.WindowID = 0
+			window.AcademicYearID = next.AcademicYearID
+			window.StartDate = window.StartDate.Add(offset)
+			window.EndDate = window.EndDate.Add(offset)
+			window.UpdatedBy = &record.PerformedBy
+			window.UpdatedAt = record.PerformedAt
+			if err := tx.Create(&window).Error; err != nil {
+				return err
+			}
+			rollover.WindowsCopied++
+		}
+
+		copied, err := copyCommittee(tx, source, next.AcademicYearID)
+		if err != nil {
+			return err
+		}
+		rollover.CommitteeMembersCopied = copied
+
+		// การเปิด/ปิดประเภทรางวัลเก็บรายปีการศึกษา คัดลอกเมื่อขึ้นปีใหม่ ค่าที่ตั้งไว้แล้วในปีใหม่ไม่ถูกทับ
+		if next.Year != source.Year {
+			var settings []models.AwardTypeYear
+			if err := tx.Where("academic_year = ?", source.Year).Find(&settings).Error; err != nil {
+				return err
+			}
+			if len(settings) > 0 {
+				for i := range settings {
+					settings[i].AcademicYear = next.Year
+					settings[i].UpdatedAt = record.PerformedAt
+				}
+				result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&settings)
+				if result.Error != nil {
+					return result.Error
+				}
+				rollover.AwardTypeSettingsCopied = int(result.RowsAffected)
+			}
+		}
+
+		summary, err := termSummary(tx, next)
+		if err != nil {
+			return err
+		}
+		summary.Rollover = rollover
+		record.AcademicYearID = next.AcademicYearID
+		record.ToStatus = next.Status
+		record.Summary = summary
+		return tx.Create(record).Error
+	})
+}
+
+func (r *academicYearRepository) GetTransitions(ctx context.Context, academicYearID uint) ([]models.AcademicYearTransition, error) {
+	var transitions []models.AcademicYearTransition
+	err := r.db.WithContext(ctx).
+		Where("academic_year_id = ?", academicYearID).
+		Order("performed_at ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *academicYearRepository) GetCommittee(ctx context.Context, academicYearID uint) ([]models.AcademicYearCommittee, error) {
+	var committee []models.AcademicYearCommittee
+	err := r.db.WithContext(ctx).
+		Where("academic_year_id = ?", academicYearID).
+		Order("is_chairman DESC").
+		Order("user_id ASC").
+		Find(&committee).Error
+	return committee, err
+}
+
+// termForms ฟอร์มของภาคเรียน (วิทยาเขตเดียวกัน หรือวิทยาเขตที่ใช้ปฏิทินกลาง)
+func termForms(db *gorm.DB, term *models.AcademicYear) *gorm.DB {
+	query := db.Model(&models.AwardForm{}).Where("academic_year = ? AND semester = ?", term.Year, term.Semester)
+	if term.CampusID != 0 {
+		return query.Where("campus_id = ?", term.CampusID)
+	}
+	return query.Where(defaultCalendarCampusFilter, term.Year, term.Semester)
+}
+
+func termSummary(db *gorm.DB, term *models.AcademicYear) (models.TermSummary, error) {
+	summary := models.TermSummary{FormsByStatus: make(map[int]int64)}
+
+	var rows []struct {
+		FormStatusID int
+		Count        int64
+	}
+	if err := termForms(db, term).
+		Select("form_status_id, COUNT(*) AS count").
+		Group("form_status_id").
+		Scan(&rows).Error; err != nil {
+		return summary, err
+	}
+	for _, row := range rows {
+		summary.FormsByStatus[row.FormStatusID] = row.Count
+		summary.TotalForms += row.Count
+		switch {
+		case row.FormStatusID == formStatusCompleted:
+			summary.Completed += row.Count
+		case row.FormStatusID == formStatusDeclined:
+			summary.Declined += row.Count
+		case row.FormStatusID == formStatusExpired:
+			summary.Expired += row.Count
+		case containsStatus(formRejectedStatuses, row.FormStatusID):
+			summary.Rejected += row.Count
+		default:
+			summary.Unfinished += row.Count
+		}
+	}
+
+	if err := db.Model(&models.AcademicYearCommittee{}).Where("academic_year_id = ?", term.AcademicYearID).Count(&summary.CommitteeMembers).Error; err != nil {
+		return summary, err
+	}
+	if err := db.Model(&models.SubmissionWindow{}).Where("academic_year_id = ?", term.AcademicYearID).Count(&summary.Windows).Error; err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// expireUnfinishedForms ปิดฟอร์มที่ยังไม่เสร็จของภาคเรียนเป็นสถานะหมดเวลา พร้อมบันทึกประวัติสถานะ
+func expireUnfinishedForms(tx *gorm.DB, term *models.AcademicYear, reason string, now time.Time) (int, error) {
+	var forms []models.AwardForm
+	if err := termForms(tx, term).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("form_id", "form_status_id", "created_at").
+		Where("form_status_id NOT IN ?", formFinalStatuses).
+		Find(&forms).Error; err != nil {
+		return 0, err
+	}
+
+	for i := range forms {
+		if err := tx.Model(&models.AwardForm{}).
+			Where("form_id = ?", forms[i].FormID).
+			Updates(map[string]interface{}{
+				"form_status_id":  formStatusExpired,
+				"reject_reason":   reason,
+				"latest_update":   now,
+				"step_started_at": now,
+			}).Error; err != nil {
+			return 0, err
+		}
+		if err := createStatusLog(tx, &forms[i], formStatusExpired, now); err != nil {
+			return 0, err
+		}
+	}
+	return len(forms), nil
+}
+
+// copyCommittee คัดลอกรายชื่อคณะกรรมการของ source ไปยังภาคเรียน targetID
+// ถ้า source ยังไม่มีรายชื่อ ใช้คณะกรรมการปัจจุบันของวิทยาเขต (ตาราง Committee)
+func copyCommittee(tx *gorm.DB, source *models.AcademicYear, targetID uint) (int, error) {
+	var members []models.AcademicYearCommittee
+	if err := tx.Where("academic_year_id = ?", source.AcademicYearID).Find(&members).Error; err != nil {
+		return 0, err
+	}
+	if len(members) == 0 {
+		query := tx.Table(`"Committee" c`).
+			Joins(`JOIN "User" u ON u.user_id = c.user_id`).
+			Where("u.role_id = ?", 6)
+		if source.CampusID != 0 {
+			query = query.Where("u.campus_id = ?", source.CampusID)
+		} else {
+			query = query.Where("u."+defaultCalendarCampusFilter, source.Year, source.Semester)
+		}
+		if err := query.Select("c.user_id, c.is_chairman").Scan(&members).Error; err != nil {
+			return 0, err
+		}
+	}
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	for i := range members {
+		members[i].AcademicYearID = targetID
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&members)
+	return int(result.RowsAffected), result.Error
+}
+
+func containsStatus(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *academicYearRepository) CreateWindow(ctx context.Context, window *models.SubmissionWindow) error {
+	return r.db.WithContext(ctx).Create(window).Error
+}
+
+func (r *academicYearRepository) GetWindowByID(ctx context.Context, id uint) (*models.SubmissionWindow, error) {
+	var window models.SubmissionWindow
+	err := r.db.WithContext(ctx).Where("window_id = ?", id).First(&window).Error
+	if err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+func (r *academicYearRepository) GetWindowsByAcademicYear(ctx context.Context, academicYearID uint) ([]models.SubmissionWindow, error) {
+	var windows []models.SubmissionWindow
+	err := r.db.WithContext(ctx).
+		Where("academic_year_id = ?", academicYearID).
+		Order("stage ASC").
+		Order("award_type_id ASC NULLS FIRST").
+		Find(&windows).Error
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (r *academicYearRepository) UpdateWindow(ctx context.Context, window *models.SubmissionWindow) error {
+	return r.db.WithContext(ctx).Save(window).Error
+}
+
+func (r *academicYearRepository) DeleteWindow(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.SubmissionWindow{}, id).Error
+}
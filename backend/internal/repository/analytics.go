@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsFilter เงื่อนไขกรองสถิติ ค่า 0 หรือค่าว่าง = ไม่กรอง
+type AnalyticsFilter struct {
+	CampusID     int
+	FacultyID    int
+	DepartmentID int
+	AwardType    string
+	AcademicYear int
+	Semester     int
+}
+
+// AnalyticsGroupRow จำนวนฟอร์มของหนึ่งกลุ่ม แยกตามผลการพิจารณา
+type AnalyticsGroupRow struct {
+	Key      string `gorm:"column:group_key"`
+	Label    string `gorm:"column:group_label"`
+	Total    int64  `gorm:"column:total"`
+	Approved int64  `gorm:"column:approved"`
+	Rejected int64  `gorm:"column:rejected"`
+	Pending  int64  `gorm:"column:pending"`
+}
+
+// AnalyticsReasonRow เหตุผลการตีกลับ/ไม่อนุมัติ แยกตามสถานะที่ถูกตีกลับ
+type AnalyticsReasonRow struct {
+	Reason         string `gorm:"column:reason"`
+	FormStatusID   int    `gorm:"column:form_status_id"`
+	FormStatusName string `gorm:"column:form_status_name"`
+	Count          int64  `gorm:"column:count"`
+}
+
+// AnalyticsStepDurationRow ระยะเวลาที่ฟอร์มอยู่ในแต่ละขั้น (ชั่วโมง)
+type AnalyticsStepDurationRow struct {
+	FormStatusID   int     `gorm:"column:form_status_id"`
+	FormStatusName string  `gorm:"column:form_status_name"`
+	Transitions    int64   `gorm:"column:transitions"`
+	AvgHours       float64 `gorm:"column:avg_hours"`
+	MedianHours    float64 `gorm:"column:median_hours"`
+	MaxHours       float64 `gorm:"column:max_hours"`
+}
+
+// analyticsGroupColumns มิติที่ใช้จัดกลุ่มได้ (key = ค่าที่ client ส่งมา)
+var analyticsGroupColumns = map[string]struct {
+	key   string
+	label string
+	join  string
+}{
+	"status":        {"af.form_status_id::text", "COALESCE(fs.form_status_name, af.form_status_id::text)", `LEFT JOIN "Form_Status" fs ON fs.form_status_id = af.form_status_id`},
+	"award_type":    {"af.award_type", "af.award_type", ""},
+	"campus":        {"af.campus_id::text", "COALESCE(c.campus_name, af.campus_id::text)", `LEFT JOIN "Campuses" c ON c.campus_id = af.campus_id`},
+	"faculty":       {"af.faculty_id::text", "COALESCE(f.faculty_name, af.faculty_id::text)", `LEFT JOIN "Faculty" f ON f.faculty_id = af.faculty_id`},
+	"department":    {"af.department_id::text", "COALESCE(d.department_name, af.department_id::text)", `LEFT JOIN "Department" d ON d.department_id = af.department_id`},
+	"academic_year": {"af.academic_year::text", "af.academic_year::text", ""},
+	"semester":      {"CONCAT(af.academic_year, '/', af.semester)", "CONCAT(af.semester, '/', af.academic_year)", ""},
+	"month":         {"TO_CHAR(af.created_at, 'YYYY-MM')", "TO_CHAR(af.created_at, 'YYYY-MM')", ""},
+	"student_year":  {"af.student_year::text", "af.student_year::text", ""},
+}
+
+// IsAnalyticsGroupBy ตรวจว่ามิติที่ขอจัดกลุ่มได้หรือไม่
+func IsAnalyticsGroupBy(groupBy string) bool {
+	_, ok := analyticsGroupColumns[groupBy]
+	return ok
+}
+
+// สถานะสุดท้ายของฟอร์ม: 12 = อนุมัติเสร็จสิ้น, 3/5/7/10 = ตีกลับ/ไม่อนุมัติ, 15 = หมดเวลาเมื่อปิดภาคเรียน ที่เหลือคือระหว่างพิจารณา
+const (
+	analyticsApprovedExpr = "COUNT(*) FILTER (WHERE af.form_status_id = 12)"
+	analyticsRejectedExpr = "COUNT(*) FILTER (WHERE af.form_status_id IN (3, 5, 7, 10))"
+	analyticsPendingExpr  = "COUNT(*) FILTER (WHERE af.form_status_id NOT IN (3, 5, 7, 10, 12, 15))"
+)
+
+type AnalyticsRepository interface {
+	GetTotals(ctx context.Context, filter AnalyticsFilter) (*AnalyticsGroupRow, error)
+	GetGroupedCounts(ctx context.Context, filter AnalyticsFilter, groupBy string) ([]AnalyticsGroupRow, error)
+	GetRejectionReasons(ctx context.Context, filter AnalyticsFilter, limit int) ([]AnalyticsReasonRow, error)
+	GetStepDurations(ctx context.Context, filter AnalyticsFilter) ([]AnalyticsStepDurationRow, error)
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+func (r *analyticsRepository) forms(ctx context.Context, filter AnalyticsFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Table(`"Award_Form" af`)
+	if filter.CampusID > 0 {
+		query = query.Where("af.campus_id = ?", filter.CampusID)
+	}
+	if filter.FacultyID > 0 {
+		query = query.Where("af.faculty_id = ?", filter.FacultyID)
+	}
+	if filter.DepartmentID > 0 {
+		query = query.Where("af.department_id = ?", filter.DepartmentID)
+	}
+	if filter.AwardType != "" {
+		query = query.Where("af.award_type = ?", filter.AwardType)
+	}
+	if filter.AcademicYear > 0 {
+		query = query.Where("af.academic_year = ?", filter.AcademicYear)
+	}
+	if filter.Semester > 0 {
+		query = query.Where("af.semester = ?", filter.Semester)
+	}
+	return query
+}
+
+func (r *analyticsRepository) GetTotals(ctx context.Context, filter AnalyticsFilter) (*AnalyticsGroupRow, error) {
+	var row AnalyticsGroupRow
+	err := r.forms(ctx, filter).
+		Select("'all' AS group_key, 'all' AS group_label, COUNT(*) AS total, " +
+			analyticsApprovedExpr + " AS approved, " +
+			analyticsRejectedExpr + " AS rejected, " +
+			analyticsPendingExpr + " AS pending").
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// GetGroupedCounts นับฟอร์มแยกตามมิติ groupBy (ต้องผ่าน IsAnalyticsGroupBy แล้ว)
+func (r *analyticsRepository) GetGroupedCounts(ctx context.Context, filter AnalyticsFilter, groupBy string) ([]AnalyticsGroupRow, error) {
+	column := analyticsGroupColumns[groupBy]
+
+	query := r.forms(ctx, filter)
+	if column.join != "" {
+		query = query.Joins(column.join)
+	}
+
+	rows := make([]AnalyticsGroupRow, 0)
+	err := query.
+		Select(column.key + " AS group_key, " + column.label + " AS group_label, COUNT(*) AS total, " +
+			analyticsApprovedExpr + " AS approved, " +
+			analyticsRejectedExpr + " AS rejected, " +
+			analyticsPendingExpr + " AS pending").
+		Group("1, 2").
+		Order("1 ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetRejectionReasons เหตุผลที่พบบ่อยจากฟอร์มที่อยู่ในสถานะตีกลับ/ไม่อนุมัติ
+// รวมเหตุผลที่เขียนต่างกันแค่ช่องว่าง/ตัวพิมพ์ไว้ด้วยกัน
+func (r *analyticsRepository) GetRejectionReasons(ctx context.Context, filter AnalyticsFilter, limit int) ([]AnalyticsReasonRow, error) {
+	rows := make([]AnalyticsReasonRow, 0)
+	err := r.forms(ctx, filter).
+		Joins(`LEFT JOIN "Form_Status" fs ON fs.form_status_id = af.form_status_id`).
+		Where("af.form_status_id IN ?", []int{3, 5, 7, 10}).
+		Select(`
+			MIN(COALESCE(NULLIF(TRIM(af.reject_reason), ''), '-')) AS reason,
+			af.form_status_id,
+			COALESCE(MAX(fs.form_status_name), '') AS form_status_name,
+			COUNT(*) AS count
+		`).
+		Group("LOWER(REGEXP_REPLACE(TRIM(COALESCE(af.reject_reason, '')), '\\s+', ' ', 'g')), af.form_status_id").
+		Order("count DESC, af.form_status_id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetStepDurations ระยะเวลาเฉลี่ย/มัธยฐาน/สูงสุด ที่ฟอร์มอยู่ในแต่ละขั้นก่อนถูกเปลี่ยนสถานะ (จาก Award_Status_Log)
+func (r *analyticsRepository) GetStepDurations(ctx context.Context, filter AnalyticsFilter) ([]AnalyticsStepDurationRow, error) {
+	rows := make([]AnalyticsStepDurationRow, 0)
+	err := r.forms(ctx, filter).
+		Joins(`JOIN "Award_Status_Log" sl ON sl.form_id = af.form_id`).
+		Joins(`LEFT JOIN "Form_Status" fs ON fs.form_status_id = sl.from_status_id`).
+		Select(`
+			sl.from_status_id AS form_status_id,
+			COALESCE(MAX(fs.form_status_name), '') AS form_status_name,
+			COUNT(*) AS transitions,
+			AVG(EXTRACT(EPOCH FROM (sl.changed_at - sl.entered_at)) / 3600) AS avg_hours,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (sl.changed_at - sl.entered_at)) / 3600) AS median_hours,
+			MAX(EXTRACT(EPOCH FROM (sl.changed_at - sl.entered_at)) / 3600) AS max_hours
+		`).
+		Group("sl.from_status_id").
+		Order("sl.from_status_id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
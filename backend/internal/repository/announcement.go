@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnnouncementRepository interface {
+	// Create สร้างประกาศพร้อมฉบับร่างแรกและรายการฟอร์ม
+	Create(ctx context.Context, announcement *models.Announcement, version *models.AnnouncementVersion, items []models.AnnouncementItem) error
+	Update(ctx context.Context, announcement *models.Announcement) error
+	Delete(ctx context.Context, announcementID uint) error
+	GetByID(ctx context.Context, announcementID uint) (*models.Announcement, error)
+	GetByTerm(ctx context.Context, campusID, academicYear, semester int) (*models.Announcement, error)
+	GetList(ctx context.Context, campusID int, status string, limit, offset int) ([]models.Announcement, int64, error)
+
+	// CreateVersion สร้างฉบับร่างใหม่ (ฉบับแก้ไข) และตั้งเป็น DraftVersionID ของประกาศ
+	CreateVersion(ctx context.Context, announcement *models.Announcement, version *models.AnnouncementVersion, items []models.AnnouncementItem) error
+	UpdateVersion(ctx context.Context, version *models.AnnouncementVersion) error
+	GetVersion(ctx context.Context, versionID uint) (*models.AnnouncementVersion, error)
+	GetVersions(ctx context.Context, announcementID uint) ([]models.AnnouncementVersion, error)
+	GetItems(ctx context.Context, versionID uint) ([]models.AnnouncementItem, error)
+	ReplaceItems(ctx context.Context, version *models.AnnouncementVersion, items []models.AnnouncementItem) error
+
+	// BuildItems สร้างข้อมูลแสดงผลจากฟอร์มที่อนุมัติเสร็จสิ้นของวิทยาเขต/ภาคเรียน (formIDs ว่าง = ทุกฟอร์ม)
+	BuildItems(ctx context.Context, campusID, academicYear, semester int, formIDs []uint) ([]models.AnnouncementItem, error)
+
+	// Publish เผยแพร่ฉบับ versionID แทนฉบับเดิม (ฉบับเดิมเป็น superseded)
+	Publish(ctx context.Context, announcementID, versionID uint, publishedBy *uint, publishedAt time.Time) error
+	Retract(ctx context.Context, announcement *models.Announcement) error
+
+	// GetPublishedList ประกาศที่เผยแพร่อยู่ เรียงจากเผยแพร่ล่าสุด (ค่า 0 = ไม่กรอง)
+	GetPublishedList(ctx context.Context, campusID, academicYear, semester int, limit, offset int) ([]models.Announcement, int64, error)
+	// GetPublicItems รายการของฉบับ versionID โดยตัดนิสิตที่ไม่ยินยอมให้เผยแพร่ออก
+	GetPublicItems(ctx context.Context, versionID uint) ([]models.AnnouncementItem, error)
+	GetPublicationPreference(ctx context.Context, userID uint) (*models.PublicationPreference, error)
+	SavePublicationPreference(ctx context.Context, preference *models.PublicationPreference) error
+}
+
+type announcementRepository struct {
+	db *gorm.DB
+}
+
+func NewAnnouncementRepository(db *gorm.DB) AnnouncementRepository {
+	return &announcementRepository{db: db}
+}
+
+func (r *announcementRepository) Create(ctx context.Context, announcement *models.Announcement, version *models.AnnouncementVersion, items []models.AnnouncementItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(announcement).Error; err != nil {
+			return err
+		}
+		return createVersionTx(tx, announcement, version, items)
+	})
+}
+
+func (r *announcementRepository) CreateVersion(ctx context.Context, announcement *models.Announcement, version *models.AnnouncementVersion, items []models.AnnouncementItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createVersionTx(tx, announcement, version, items)
+	})
+}
+
+func createVersionTx(tx *gorm.DB, announcement *models.Announcement, version *models.AnnouncementVersion, items []models.AnnouncementItem) error {
+	version.AnnouncementID = announcement.AnnouncementID
+	version.ItemCount = len(items)
+	if err := tx.Create(version).Error; err != nil {
+		return err
+	}
+	if err := insertItemsTx(tx, version.VersionID, items); err != nil {
+		return err
+	}
+	announcement.DraftVersionID = &version.VersionID
+	return tx.Save(announcement).Error
+}
+
+func insertItemsTx(tx *gorm.DB, versionID uint, items []models.AnnouncementItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	for i := range items {
+		items[i].ItemID = 0
+		items[i].VersionID = versionID
+	}
+	return tx.CreateInBatches(items, 200).Error
+}
+
+func (r *announcementRepository) Update(ctx context.Context, announcement *models.Announcement) error {
+	return r.db.WithContext(ctx).Save(announcement).Error
+}
+
+// Delete ลบประกาศพร้อมทุกฉบับและรายการฟอร์ม
+func (r *announcementRepository) Delete(ctx context.Context, announcementID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		versionIDs := tx.Model(&models.AnnouncementVersion{}).Select("version_id").Where("announcement_id = ?", announcementID)
+		if err := tx.Where("version_id IN (?)", versionIDs).Delete(&models.AnnouncementItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("announcement_id = ?", announcementID).Delete(&models.AnnouncementVersion{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Announcement{}, announcementID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *announcementRepository) GetByID(ctx context.Context, announcementID uint) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := r.db.WithContext(ctx).First(&announcement, announcementID).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *announcementRepository) GetByTerm(ctx context.Context, campusID, academicYear, semester int) (*models.Announcement, error) {
+	var announcement models.Announcement
+	err := r.db.WithContext(ctx).
+		Where("campus_id = ? AND academic_year = ? AND semester = ?", campusID, academicYear, semester).
+		First(&announcement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *announcementRepository) GetList(ctx context.Context, campusID int, status string, limit, offset int) ([]models.Announcement, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Announcement{})
+	if campusID > 0 {
+		query = query.Where("campus_id = ?", campusID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var announcements []models.Announcement
+	err := query.Order("academic_year DESC, semester DESC, campus_id ASC").Limit(limit).Offset(offset).Find(&announcements).Error
+	return announcements, total, err
+}
+
+func (r *announcementRepository) UpdateVersion(ctx context.Context, version *models.AnnouncementVersion) error {
+	return r.db.WithContext(ctx).Save(version).Error
+}
+
+func (r *announcementRepository) GetVersion(ctx context.Context, versionID uint) (*models.AnnouncementVersion, error) {
+	var version models.AnnouncementVersion
+	if err := r.db.WithContext(ctx).First(&version, versionID).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *announcementRepository) GetVersions(ctx context.Context, announcementID uint) ([]models.AnnouncementVersion, error) {
+	var versions []models.AnnouncementVersion
+	err := r.db.WithContext(ctx).Where("announcement_id = ?", announcementID).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+func (r *announcementRepository) GetItems(ctx context.Context, versionID uint) ([]models.AnnouncementItem, error) {
+	items := make([]models.AnnouncementItem, 0)
+	err := r.db.WithContext(ctx).
+		Where("version_id = ?", versionID).
+		Order("award_type ASC, student_firstname ASC, student_lastname ASC").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *announcementRepository) ReplaceItems(ctx context.Context, version *models.AnnouncementVersion, items []models.AnnouncementItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version_id = ?", version.VersionID).Delete(&models.AnnouncementItem{}).Error; err != nil {
+			return err
+		}
+		if err := insertItemsTx(tx, version.VersionID, items); err != nil {
+			return err
+		}
+		version.ItemCount = len(items)
+		return tx.Save(version).Error
+	})
+}
+
+func (r *announcementRepository) BuildItems(ctx context.Context, campusID, academicYear, semester int, formIDs []uint) ([]models.AnnouncementItem, error) {
+	query := r.db.WithContext(ctx).
+		Table(`"Award_Form" af`).
+		Joins(`LEFT JOIN "Faculty" f ON f.faculty_id = af.faculty_id`).
+		Joins(`LEFT JOIN "User" u ON u.user_id = COALESCE(af.nominee_user_id, af.user_id)`).
+		Joins(`LEFT JOIN "Award_Type" t ON t.award_type_id = af.award_type_id`).
+		Where("af.campus_id = ? AND af.academic_year = ? AND af.semester = ?", campusID, academicYear, semester).
+		Where("af.form_status_id = ?", 12)
+	if len(formIDs) > 0 {
+		query = query.Where("af.form_id IN ?", formIDs)
+	}
+
+	items := make([]models.AnnouncementItem, 0)
+	err := query.Select(`
+		af.form_id,
+		af.award_type,
+		COALESCE(t.category, ?) AS award_category,
+		af.faculty_id,
+		COALESCE(f.faculty_name, '') AS faculty_name,
+		af.student_number,
+		COALESCE(u.prefix, '') AS prefix,
+		af.student_firstname,
+		af.student_lastname,
+		af.created_at AS form_created_at
+	`, models.AwardCategoryOther).
+		Order("af.form_id ASC").
+		Scan(&items).Error
+	return items, err
+}
+
+func (r *announcementRepository) Publish(ctx context.Context, announcementID, versionID uint, publishedBy *uint, publishedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var announcement models.Announcement
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&announcement, announcementID).Error; err != nil {
+			return err
+		}
+
+		if announcement.PublishedVersionID != nil && *announcement.PublishedVersionID != versionID {
+			if err := tx.Model(&models.AnnouncementVersion{}).
+				Where("version_id = ?", *announcement.PublishedVersionID).
+				Updates(map[string]interface{}{"status": models.AnnouncementVersionSuperseded, "updated_at": publishedAt}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.AnnouncementVersion{}).
+			Where("version_id = ?", versionID).
+			Updates(map[string]interface{}{
+				"status":       models.AnnouncementVersionPublished,
+				"published_at": publishedAt,
+				"published_by": publishedBy,
+				"updated_at":   publishedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		announcement.Status = models.AnnouncementStatusPublished
+		announcement.PublishedVersionID = &versionID
+		announcement.DraftVersionID = nil
+		announcement.PublishedAt = &publishedAt
+		announcement.RetractedAt = nil
+		announcement.RetractReason = ""
+		if publishedBy != nil {
+			announcement.UpdatedBy = *publishedBy
+		}
+		announcement.UpdatedAt = publishedAt
+		return tx.Save(&announcement).Error
+	})
+}
+
+// Retract ถอนประกาศ ฉบับที่เผยแพร่อยู่จะเป็น retracted (ข้อมูลยังอยู่เพื่อเป็นหลักฐาน)
+func (r *announcementRepository) Retract(ctx context.Context, announcement *models.Announcement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if announcement.PublishedVersionID != nil {
+			if err := tx.Model(&models.AnnouncementVersion{}).
+				Where("version_id = ?", *announcement.PublishedVersionID).
+				Updates(map[string]interface{}{"status": models.AnnouncementVersionRetracted, "updated_at": announcement.UpdatedAt}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(announcement).Error
+	})
+}
+
+func (r *announcementRepository) GetPublishedList(ctx context.Context, campusID, academicYear, semester int, limit, offset int) ([]models.Announcement, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.Announcement{}).
+		Where("status = ? AND published_version_id IS NOT NULL", models.AnnouncementStatusPublished)
+	if campusID > 0 {
+		query = query.Where("campus_id = ?", campusID)
+	}
+	if academicYear > 0 {
+		query = query.Where("academic_year = ?", academicYear)
+	}
+	if semester > 0 {
+		query = query.Where("semester = ?", semester)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var announcements []models.Announcement
+	err := query.
+		Order("published_at DESC, announcement_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&announcements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return announcements, total, nil
+}
+
+func (r *announcementRepository) GetPublicItems(ctx context.Context, versionID uint) ([]models.AnnouncementItem, error) {
+	var items []models.AnnouncementItem
+	err := r.db.WithContext(ctx).
+		Table(`"Announcement_Item" ai`).
+		Select("ai.*").
+		Joins(`LEFT JOIN "Award_Form" af ON af.form_id = ai.form_id`).
+		Joins(`LEFT JOIN "Publication_Preference" pp ON pp.user_id = COALESCE(af.nominee_user_id, af.user_id)`).
+		Where("ai.version_id = ?", versionID).
+		Where("COALESCE(pp.opt_out, FALSE) = FALSE").
+		Order("ai.award_type ASC, ai.student_firstname ASC, ai.student_lastname ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *announcementRepository) GetPublicationPreference(ctx context.Context, userID uint) (*models.PublicationPreference, error) {
+	preference := models.PublicationPreference{UserID: userID}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&preference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *announcementRepository) SavePublicationPreference(ctx context.Context, preference *models.PublicationPreference) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"opt_out", "updated_at"}),
+		}).
+		Create(preference).Error
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AwardRepository struct {
@@ -19,9 +22,12 @@ type AwardSearchFilter struct {
 	Keyword              string
 	Date                 string
 	StudentYear          int
+	AcademicYear         int
+	Semester             int
 	AwardType            string
-	AwardTypes           []string
-	IsOtherAwardType     bool
+	AwardTypeIDs         []uint // ประเภทรางวัลในแคตตาล็อก (ถ้ามีจะใช้แทน AwardType)
+	IsOtherAwardType     bool   // true = ฟอร์มที่ไม่อยู่ใน AwardTypeIDs (หมวด "อื่นๆ")
+	AnswerFilters        []AnswerFilter
 	ExcludeVotedByUserID *uint
 	FacultyID            *int
 	DepartmentID         *int
@@ -32,6 +38,32 @@ type AwardSearchFilter struct {
 	Limit                int
 }
 
+// วิธีเทียบค่าของ AnswerFilter
+const (
+	AnswerMatchExact    = "exact"    // ค่าตรงกันทั้งหมด
+	AnswerMatchContains = "contains" // ข้อความมีคำค้น (ไม่สนตัวพิมพ์)
+	AnswerMatchIncludes = "includes" // array ของคำตอบมีค่านี้ (multiselect)
+)
+
+// AnswerFilter กรองด้วยคำตอบใน form_answers ตาม key ของฟิลด์ระดับบน
+type AnswerFilter struct {
+	Key   string
+	Value string
+	Match string
+}
+
+// NominationFilter รายการเสนอชื่อขององค์กร (ฟอร์มที่ NominatorUserID เป็นผู้ส่ง)
+type NominationFilter struct {
+	NominatorUserID uint
+	AcademicYear    int
+	Semester        int
+	FormStatusID    int
+	AwardTypeID     uint
+	Keyword         string
+	Page            int
+	Limit           int
+}
+
 type ApprovalLogSearchFilter struct {
 	UserID    uint
 	CampusID  int
@@ -105,7 +137,7 @@ func NewAwardRepository(db *gorm.DB) *AwardRepository {
 
 // ปรับปรุง: เพิ่มพารามิเตอร์ files เพื่อรองรับการบันทึกไฟล์แนบ (ถ้ามี)
 func (r *AwardRepository) CreateWithTransaction(ctx context.Context, form *models.AwardForm, files []models.AwardFileDirectory) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// 1. บันทึกตารางหลัก (Award_Form)
 		if err := tx.Create(form).Error; err != nil {
 			return err
@@ -126,6 +158,19 @@ func (r *AwardRepository) CreateWithTransaction(ctx context.Context, form *model
 	})
 }
 
+// applyAnswerFilter ใช้ ->> / @> ของ jsonb (เลี่ยง operator ? ที่ชนกับ placeholder ของ GORM)
+func applyAnswerFilter(query *gorm.DB, answer AnswerFilter) *gorm.DB {
+	switch answer.Match {
+	case AnswerMatchExact:
+		return query.Where("form_answers ->> ? = ?", answer.Key, answer.Value)
+	case AnswerMatchIncludes:
+		included, _ := json.Marshal([]string{answer.Value})
+		return query.Where("form_answers -> ? @> ?::jsonb", answer.Key, string(included))
+	default:
+		return query.Where("form_answers ->> ? ILIKE ?", answer.Key, "%"+answer.Value+"%")
+	}
+}
+
 // GetByKeyword ค้นหาและกรองพร้อม pagination ตาม role scope
 func (r *AwardRepository) GetByKeyword(ctx context.Context, filter AwardSearchFilter) ([]models.AwardForm, int64, error) {
 	var list []models.AwardForm
@@ -168,17 +213,30 @@ func (r *AwardRepository) GetByKeyword(ctx context.Context, filter AwardSearchFi
 		query = query.Where("student_year = ?", filter.StudentYear)
 	}
 
-	// กรองตามประเภทรางวัล (รองรับ single type และ grouped type)
-	if len(filter.AwardTypes) > 0 {
-		if filter.IsOtherAwardType {
-			query = query.Where("award_type NOT IN ?", filter.AwardTypes)
-		} else {
-			query = query.Where("award_type IN ?", filter.AwardTypes)
+	// กรองตามปีการศึกษาและภาคการศึกษา (ถ้ามี)
+	if filter.AcademicYear > 0 {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if filter.Semester > 0 {
+		query = query.Where("semester = ?", filter.Semester)
+	}
+
+	// กรองตามประเภทรางวัล (รองรับ single type และหมวดของแคตตาล็อก)
+	if filter.IsOtherAwardType {
+		if len(filter.AwardTypeIDs) > 0 {
+			query = query.Where("(award_type_id IS NULL OR award_type_id NOT IN ?)", filter.AwardTypeIDs)
 		}
+	} else if len(filter.AwardTypeIDs) > 0 {
+		query = query.Where("award_type_id IN ?", filter.AwardTypeIDs)
 	} else if filter.AwardType != "" {
 		query = query.Where("award_type = ?", filter.AwardType)
 	}
 
+	// กรองตามคำตอบของฟอร์มเฉพาะประเภทรางวัล
+	for _, answer := range filter.AnswerFilters {
+		query = applyAnswerFilter(query, answer)
+	}
+
 	if filter.FacultyID != nil {
 		query = query.Where("faculty_id = ?", *filter.FacultyID)
 	}
@@ -224,42 +282,54 @@ func (r *AwardRepository) GetByKeyword(ctx context.Context, filter AwardSearchFi
 	return list, total, err
 }
 
+// publishedAnnouncements ประกาศที่เผยแพร่อยู่ของวิทยาเขต (หน้าประกาศผลแสดงเฉพาะฟอร์มในประกาศเหล่านี้)
+func (r *AwardRepository) publishedAnnouncements(ctx context.Context, campusID int) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&models.Announcement{}).
+		Where("campus_id = ? AND status = ? AND published_version_id IS NOT NULL", campusID, models.AnnouncementStatusPublished)
+}
+
+// publishedAnnouncementForms ข้อมูลฟอร์มจากฉบับที่เผยแพร่ของประกาศ ใช้ชื่อคอลัมน์เดียวกับ Award_Form
+// เพื่อให้เงื่อนไขค้นหาและการเรียงลำดับเดิมใช้ได้ (ไม่ขึ้นกับสถานะปัจจุบันของฟอร์ม)
+func (r *AwardRepository) publishedAnnouncementForms(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table(`"Announcement_Item" ai`).
+		Joins(`JOIN "Announcement" a ON a.published_version_id = ai.version_id`).
+		Where("a.status = ?", models.AnnouncementStatusPublished).
+		Select(`
+			ai.form_id,
+			a.campus_id,
+			a.academic_year,
+			a.semester,
+			ai.award_type,
+			ai.award_category,
+			ai.faculty_id,
+			ai.faculty_name,
+			ai.student_number,
+			ai.prefix,
+			ai.student_firstname,
+			ai.student_lastname,
+			ai.form_created_at AS created_at
+		`)
+}
+
 func (r *AwardRepository) GetAnnouncementDefaults(ctx context.Context, campusID int) (int, int, []string, error) {
-	var latestYear int
-	err := r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
-		Where("campus_id = ? AND form_status_id = ?", campusID, 12).
-		Select("COALESCE(MAX(academic_year), 0)").
-		Scan(&latestYear).Error
+	var latest models.Announcement
+	err := r.publishedAnnouncements(ctx, campusID).
+		Order("academic_year DESC, semester DESC").
+		Limit(1).
+		Find(&latest).Error
 	if err != nil {
 		return 0, 0, nil, err
 	}
-
-	var latestSemester int
-	if latestYear > 0 {
-		err = r.db.WithContext(ctx).
-			Model(&models.AwardForm{}).
-			Where("campus_id = ? AND form_status_id = ? AND academic_year = ?", campusID, 12, latestYear).
-			Select("COALESCE(MAX(semester), 0)").
-			Scan(&latestSemester).Error
-		if err != nil {
-			return 0, 0, nil, err
-		}
+	if latest.AnnouncementID == 0 {
+		return 0, 0, []string{}, nil
 	}
 
 	var topAwardTypes []string
-	baseQuery := r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
-		Where("campus_id = ? AND form_status_id = ?", campusID, 12)
-
-	if latestYear > 0 {
-		baseQuery = baseQuery.Where("academic_year = ?", latestYear)
-	}
-	if latestSemester > 0 {
-		baseQuery = baseQuery.Where("semester = ?", latestSemester)
-	}
-
-	err = baseQuery.
+	err = r.db.WithContext(ctx).
+		Model(&models.AnnouncementItem{}).
+		Where("version_id = ?", *latest.PublishedVersionID).
 		Distinct("award_type").
 		Order("award_type ASC").
 		Limit(3).
@@ -268,14 +338,12 @@ func (r *AwardRepository) GetAnnouncementDefaults(ctx context.Context, campusID
 		return 0, 0, nil, err
 	}
 
-	return latestYear, latestSemester, topAwardTypes, nil
+	return latest.AcademicYear, latest.Semester, topAwardTypes, nil
 }
 
 func (r *AwardRepository) GetAnnouncementAcademicYears(ctx context.Context, campusID int) ([]int, error) {
 	years := make([]int, 0)
-	err := r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
-		Where("campus_id = ? AND form_status_id = ?", campusID, 12).
+	err := r.publishedAnnouncements(ctx, campusID).
 		Distinct("academic_year").
 		Order("academic_year DESC").
 		Pluck("academic_year", &years).Error
@@ -287,9 +355,7 @@ func (r *AwardRepository) GetAnnouncementAcademicYears(ctx context.Context, camp
 
 func (r *AwardRepository) GetAnnouncementSemesters(ctx context.Context, campusID int, academicYear int) ([]int, error) {
 	semesters := make([]int, 0)
-	query := r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
-		Where("campus_id = ? AND form_status_id = ?", campusID, 12)
+	query := r.publishedAnnouncements(ctx, campusID)
 
 	if academicYear > 0 {
 		query = query.Where("academic_year = ?", academicYear)
@@ -313,7 +379,7 @@ func (r *AwardRepository) GetAnnouncementAwards(ctx context.Context, filter Anno
 	query := r.db.WithContext(ctx).
 		Table(`"Award_Form" af`).
 		Joins(`LEFT JOIN "Faculty" f ON f.faculty_id = af.faculty_id`).
-		Joins(`LEFT JOIN "User" u ON u.user_id = af.user_id`).
+		Joins(`LEFT JOIN "User" u ON u.user_id = COALESCE(af.nominee_user_id, af.user_id)`).
 		Where("af.campus_id = ?", filter.CampusID).
 		Where("af.form_status_id = ?", 12)
 
@@ -392,16 +458,15 @@ func (r *AwardRepository) GetAnnouncementAwards(ctx context.Context, filter Anno
 	return rows, total, nil
 }
 
-func (r *AwardRepository) GetAnnouncementAwardsByCategory(ctx context.Context, filter AnnouncementFilter, categoryAwardTypes []string, isOther bool) ([]AnnouncementAwardRow, int64, error) {
+// GetAnnouncementAwardsByCategory รายชื่อในประกาศที่เผยแพร่ของหมวด category (หมวด other รวมรายการที่ไม่มีหมวด)
+func (r *AwardRepository) GetAnnouncementAwardsByCategory(ctx context.Context, filter AnnouncementFilter, category string) ([]AnnouncementAwardRow, int64, error) {
 	rows := make([]AnnouncementAwardRow, 0)
 	var total int64
 
 	query := r.db.WithContext(ctx).
-		Table(`"Award_Form" af`).
+		Table("(?) af", r.publishedAnnouncementForms(ctx)).
 		Joins(`LEFT JOIN "Faculty" f ON f.faculty_id = af.faculty_id`).
-		Joins(`LEFT JOIN "User" u ON u.user_id = af.user_id`).
-		Where("af.campus_id = ?", filter.CampusID).
-		Where("af.form_status_id = ?", 12)
+		Where("af.campus_id = ?", filter.CampusID)
 
 	if filter.AcademicYear > 0 {
 		query = query.Where("af.academic_year = ?", filter.AcademicYear)
@@ -421,12 +486,10 @@ func (r *AwardRepository) GetAnnouncementAwardsByCategory(ctx context.Context, f
 		)
 	}
 
-	if len(categoryAwardTypes) > 0 {
-		if isOther {
-			query = query.Where("af.award_type NOT IN ?", categoryAwardTypes)
-		} else {
-			query = query.Where("af.award_type IN ?", categoryAwardTypes)
-		}
+	if category == models.AwardCategoryOther {
+		query = query.Where("COALESCE(af.award_category, '') IN ?", []string{models.AwardCategoryOther, ""})
+	} else {
+		query = query.Where("af.award_category = ?", category)
 	}
 
 	if err := query.Count(&total).Error; err != nil {
@@ -445,9 +508,9 @@ func (r *AwardRepository) GetAnnouncementAwardsByCategory(ctx context.Context, f
 		af.semester,
 		af.award_type,
 		af.faculty_id,
-		COALESCE(f.faculty_name, '') AS faculty_name,
+		COALESCE(NULLIF(af.faculty_name, ''), f.faculty_name, '') AS faculty_name,
 		af.student_number,
-		COALESCE(u.prefix, '') AS prefix,
+		af.prefix,
 		af.student_firstname,
 		af.student_lastname,
 		FALSE AS is_other_type
@@ -460,7 +523,7 @@ func (r *AwardRepository) GetAnnouncementAwardsByCategory(ctx context.Context, f
 		return nil, 0, err
 	}
 
-	if isOther {
+	if category == models.AwardCategoryOther {
 		for i := range rows {
 			rows[i].IsOtherType = true
 		}
@@ -642,10 +705,17 @@ func (r *AwardRepository) GetByType(ctx context.Context, awardType string, campu
 	return list, err
 }
 
+// ownedByUser ฟอร์มที่ผู้ใช้เป็นผู้ส่ง หรือเป็นนิสิตที่ถูกองค์กรเสนอชื่อ
+func ownedByUser(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(user_id = ? OR nominee_user_id = ?)", userID, userID)
+	}
+}
+
 func (r *AwardRepository) GetByUserID(ctx context.Context, userID uint) ([]models.AwardForm, error) {
 	var list []models.AwardForm
 	err := r.db.WithContext(ctx).
-		Where("user_id = ?", userID).
+		Scopes(ownedByUser(userID)).
 		Preload("AwardFiles").
 		Order("created_at desc").
 		Find(&list).Error
@@ -655,7 +725,8 @@ func (r *AwardRepository) GetByUserID(ctx context.Context, userID uint) ([]model
 func (r *AwardRepository) GetByUserIDAndYear(ctx context.Context, userID uint, year int) ([]models.AwardForm, error) {
 	var list []models.AwardForm
 	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND academic_year = ?", userID, year).
+		Scopes(ownedByUser(userID)).
+		Where("academic_year = ?", year).
 		Preload("AwardFiles").
 		Order("created_at desc").
 		Find(&list).Error
@@ -665,7 +736,8 @@ func (r *AwardRepository) GetByUserIDAndYear(ctx context.Context, userID uint, y
 func (r *AwardRepository) GetByUserIDAndSemester(ctx context.Context, userID uint, year int, semester int) ([]models.AwardForm, error) {
 	var list []models.AwardForm
 	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND academic_year = ? AND semester = ?", userID, year, semester).
+		Scopes(ownedByUser(userID)).
+		Where("academic_year = ? AND semester = ?", year, semester).
 		Preload("AwardFiles").
 		Order("created_at desc").
 		Find(&list).Error
@@ -674,7 +746,7 @@ func (r *AwardRepository) GetByUserIDAndSemester(ctx context.Context, userID uin
 
 func (r *AwardRepository) GetByUserIDWithYearSort(ctx context.Context, userID uint, years []int) ([]models.AwardForm, error) {
 	var list []models.AwardForm
-	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	query := r.db.WithContext(ctx).Scopes(ownedByUser(userID))
 	if len(years) > 0 {
 		query = query.Where("academic_year IN ?", years)
 	}
@@ -689,7 +761,7 @@ func (r *AwardRepository) GetByUserIDWithYearPaged(ctx context.Context, userID u
 	var list []models.AwardForm
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&models.AwardForm{}).Where("user_id = ?", userID)
+	query := r.db.WithContext(ctx).Model(&models.AwardForm{}).Scopes(ownedByUser(userID))
 	if len(years) > 0 {
 		query = query.Where("academic_year IN ?", years)
 	}
@@ -721,7 +793,7 @@ func (r *AwardRepository) GetByStudentID(ctx context.Context, studentID int) ([]
 
 func (r *AwardRepository) GetByFormID(ctx context.Context, formID int) (*models.AwardForm, error) {
 	var form models.AwardForm
-	err := r.db.WithContext(ctx).
+	err := dbFor(ctx, r.db).
 		Where("form_id = ?", formID).
 		Preload("AwardFiles").
 		First(&form).Error
@@ -731,11 +803,29 @@ func (r *AwardRepository) GetByFormID(ctx context.Context, formID int) (*models.
 	return &form, nil
 }
 
-func (r *AwardRepository) CheckDuplicate(userID uint, year int, semester int) (bool, error) {
+// GetAwardTypeFormFields นิยามฟิลด์ของประเภทรางวัล (ใช้ซ่อนคำตอบที่เป็นข้อมูลติดต่อ) ไม่พบประเภทรางวัลคืนรายการว่าง
+func (r *AwardRepository) GetAwardTypeFormFields(ctx context.Context, awardTypeID uint) ([]models.AwardFormField, error) {
+	var awardType models.AwardType
+	err := r.db.WithContext(ctx).
+		Select("award_type_id", "form_fields").
+		Where("award_type_id = ?", awardTypeID).
+		Take(&awardType).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return awardType.FormFields, nil
+}
+
+// CheckDuplicate ผู้ถูกเสนอชื่อ (รหัสนิสิต) มีฟอร์มของประเภทรางวัลนี้ในภาคการศึกษาเดียวกันแล้วหรือไม่ (idx_nominee_award_term)
+// semester เป็นเลขตาม Term_Type ของวิทยาเขต ภาคฤดูร้อน/ไตรภาคนับแยกจากภาคเรียนปกติ
+// ฟอร์มที่นิสิตปฏิเสธการเสนอชื่อ (สถานะ 14) ไม่นับ เหมือน index
+func (r *AwardRepository) CheckDuplicate(ctx context.Context, studentNumber string, awardTypeID uint, year int, semester int) (bool, error) {
 	var count int64
-	// เช็คในตาราง AwardForm ว่ามีข้อมูลที่ user_id, academic_year, semester ตรงกันไหม
-	err := r.db.Model(&models.AwardForm{}).
-		Where("user_id = ? AND academic_year = ? AND semester = ?", userID, year, semester).
+	err := r.db.WithContext(ctx).Model(&models.AwardForm{}).
+		Where("student_number = ? AND award_type_id = ? AND academic_year = ? AND semester = ? AND form_status_id <> 14", studentNumber, awardTypeID, year, semester).
 		Count(&count).Error
 
 	if err != nil {
@@ -745,37 +835,146 @@ func (r *AwardRepository) CheckDuplicate(userID uint, year int, semester int) (b
 	return count > 0, nil
 }
 
-func (r *AwardRepository) UpdateAwardType(ctx context.Context, formID uint, awardType string) error {
+func (r *AwardRepository) UpdateAwardType(ctx context.Context, formID uint, awardTypeID uint, awardType string) error {
 	return r.db.WithContext(ctx).
 		Model(&models.AwardForm{}).
 		Where("form_id = ?", formID).
 		Updates(map[string]interface{}{
+			"award_type_id": awardTypeID,
 			"award_type":    awardType,
 			"latest_update": time.Now(),
 		}).Error
 }
 
+// UpdateFormStatus เปลี่ยนสถานะฟอร์มพร้อมบันทึก Award_Status_Log ใน transaction เดียวกัน
 func (r *AwardRepository) UpdateFormStatus(ctx context.Context, formID uint, formStatus int, rejectReason string) error {
-	return r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		_, err := updateFormStatusTx(tx, formID, formStatus, rejectReason)
+		return err
+	})
+}
+
+// UpdateFormStatusAndSign เปลี่ยนสถานะและบันทึกลายมือชื่อใน transaction เดียวกัน
+// sign ได้รับฟอร์มที่ล็อกไว้พร้อมไฟล์แนบ และ ctx ที่ผูก transaction นี้ ถ้าลงนามไม่สำเร็จสถานะจะไม่เปลี่ยน
+func (r *AwardRepository) UpdateFormStatusAndSign(ctx context.Context, formID uint, formStatus int, rejectReason string, sign func(ctx context.Context, form *models.AwardForm) (*models.AwardSignedLog, error)) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if _, err := updateFormStatusTx(tx, formID, formStatus, rejectReason); err != nil {
+			return err
+		}
+
+		var form models.AwardForm
+		if err := tx.Preload("AwardFiles").Where("form_id = ?", formID).Take(&form).Error; err != nil {
+			return err
+		}
+		signedLog, err := sign(WithTx(ctx, tx), &form)
+		if err != nil {
+			return err
+		}
+		return tx.Create(signedLog).Error
+	})
+}
+
+// Transaction เรียก fn ใน transaction เดียว ctx ที่ fn ได้รับผูก transaction ไว้ (ดู WithTx)
+// ใช้ให้การเปลี่ยนสถานะกับ outbox (webhook delivery/job) commit หรือ rollback พร้อมกัน
+func (r *AwardRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
+
+// updateFormStatusTx ล็อกฟอร์ม เปลี่ยนสถานะ และบันทึก Award_Status_Log คืนข้อมูลฟอร์มก่อนเปลี่ยน
+func updateFormStatusTx(tx *gorm.DB, formID uint, formStatus int, rejectReason string) (*models.AwardForm, error) {
+	var form models.AwardForm
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("form_id", "form_status_id", "created_at").
 		Where("form_id = ?", formID).
-		Updates(map[string]interface{}{
-			"form_status_id": formStatus,
-			"reject_reason":  rejectReason,
-			"latest_update":  time.Now(),
-		}).Error
+		Take(&form).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"form_status_id": formStatus,
+		"reject_reason":  rejectReason,
+		"latest_update":  now,
+	}
+	if form.FormStatusID != formStatus {
+		updates["step_started_at"] = now
+	}
+	if err := tx.Model(&models.AwardForm{}).
+		Where("form_id = ?", formID).
+		Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	if form.FormStatusID == formStatus {
+		return &form, nil
+	}
+	return &form, createStatusLog(tx, &form, formStatus, now)
 }
 
-func (r *AwardRepository) CreateAwardApprovalLog(ctx context.Context, log *models.AwardApprovalLog) error {
-	return r.db.WithContext(ctx).Create(log).Error
+// createStatusLog บันทึกการเปลี่ยนสถานะ form คือข้อมูลก่อนเปลี่ยน (ต้องมี form_id, form_status_id, created_at)
+func createStatusLog(tx *gorm.DB, form *models.AwardForm, formStatus int, now time.Time) error {
+	// เวลาที่เข้าสู่สถานะปัจจุบัน = เวลาเปลี่ยนสถานะครั้งล่าสุด ถ้ายังไม่เคยเปลี่ยนใช้เวลาส่งฟอร์ม
+	enteredAt := form.CreatedAt
+	var last models.AwardStatusLog
+	err := tx.Where("form_id = ?", form.FormID).Order("changed_at DESC").Take(&last).Error
+	if err == nil {
+		enteredAt = last.ChangedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return tx.Create(&models.AwardStatusLog{
+		FormID:       form.FormID,
+		FromStatusID: form.FormStatusID,
+		ToStatusID:   formStatus,
+		EnteredAt:    enteredAt,
+		ChangedAt:    now,
+	}).Error
 }
 
-func (r *AwardRepository) CreateAwardSignedLog(ctx context.Context, log *models.AwardSignedLog) error {
-	return r.db.WithContext(ctx).Create(log).Error
+// RespondNomination บันทึกการตอบรับ/ปฏิเสธของนิสิตผู้ถูกเสนอชื่อ พร้อมเปลี่ยนสถานะใน transaction เดียวกัน
+// ฟอร์มต้องยังอยู่ในสถานะ fromStatus และ nominee_user_id ต้องเป็น nomineeUserID, columns คือคอลัมน์จาก changes ที่ต้องบันทึกเพิ่ม
+func (r *AwardRepository) RespondNomination(ctx context.Context, formID uint, nomineeUserID uint, fromStatus int, toStatus int, changes models.AwardForm, columns ...string) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var form models.AwardForm
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("form_id", "form_status_id", "created_at", "nominee_user_id").
+			Where("form_id = ?", formID).
+			Take(&form).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("form not found")
+			}
+			return err
+		}
+		if form.NomineeUserID == nil || *form.NomineeUserID != nomineeUserID {
+			return errors.New("forbidden: only the nominee can respond to this nomination")
+		}
+		if form.FormStatusID != fromStatus {
+			return errors.New("form is not awaiting nominee consent")
+		}
+
+		// อัปเดตผ่าน struct เพื่อให้ฟิลด์ที่เข้ารหัสผ่าน serializer
+		now := time.Now()
+		changes.FormStatusID = toStatus
+		changes.NomineeRespondedAt = &now
+		changes.LatestUpdate = now
+		changes.StepStartedAt = now
+		columns = append(columns, "form_status_id", "nominee_responded_at", "latest_update", "step_started_at")
+		if err := tx.Model(&models.AwardForm{}).Where("form_id = ?", formID).Select(columns).Updates(&changes).Error; err != nil {
+			return err
+		}
+		return createStatusLog(tx, &form, toStatus, now)
+	})
+}
+
+func (r *AwardRepository) CreateAwardApprovalLog(ctx context.Context, log *models.AwardApprovalLog) error {
+	return dbFor(ctx, r.db).Create(log).Error
 }
 
 func (r *AwardRepository) SaveAwardTypeLog(ctx context.Context, log *models.AwardTypeLog) error {
-	return r.db.WithContext(ctx).Create(log).Error
+	return dbFor(ctx, r.db).Create(log).Error
 }
 
 func (r *AwardRepository) GetAwardTypeLogs(ctx context.Context, filter AwardTypeLogFilter) ([]models.AwardTypeLog, error) {
@@ -805,21 +1004,63 @@ func (r *AwardRepository) GetAwardTypeLogs(ctx context.Context, filter AwardType
 	return logs, nil
 }
 
-func (r *AwardRepository) IsCommitteeNonChairman(ctx context.Context, userID uint) (bool, error) {
-	var count int64
-	err := r.db.WithContext(ctx).
-		Table("\"Committee\" c").
-		Joins("JOIN \"User\" u ON u.user_id = c.user_id").
-		Where("c.user_id = ?", userID).
-		Where("u.role_id = ?", 6).
-		Where("c.is_chairman = ?", false).
-		Count(&count).Error
+// formCommittee query รายชื่อคณะกรรมการที่พิจารณาฟอร์มนี้ (alias c มี user_id, is_chairman)
+// ใช้ Academic_Year_Committee ของภาคเรียนของฟอร์ม (ของวิทยาเขต ถ้าไม่มีใช้ปฏิทินกลาง)
+// ถ้าภาคเรียนนั้นยังไม่มีรายชื่อ (เช่น ยังไม่เคยเปิดภาคเรียนผ่านระบบ) ใช้คณะกรรมการปัจจุบันในตาราง Committee
+func (r *AwardRepository) formCommittee(ctx context.Context, form *models.AwardForm) (*gorm.DB, error) {
+	db := r.db.WithContext(ctx)
+
+	var termIDs []uint
+	if err := db.Model(&models.AcademicYear{}).
+		Where("year = ? AND semester = ? AND campus_id IN ?", form.AcademicYear, form.Semester, []int{form.CampusID, 0}).
+		Order("campus_id DESC").
+		Limit(1).
+		Pluck("academic_year_id", &termIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(termIDs) > 0 {
+		var count int64
+		if err := db.Model(&models.AcademicYearCommittee{}).Where("academic_year_id = ?", termIDs[0]).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return db.Table(`"Academic_Year_Committee" c`).
+				Joins(`JOIN "User" u ON u.user_id = c.user_id`).
+				Where("c.academic_year_id = ?", termIDs[0]).
+				Where("u.role_id = ?", 6), nil
+		}
+	}
+	return db.Table(`"Committee" c`).
+		Joins(`JOIN "User" u ON u.user_id = c.user_id`).
+		Where("u.role_id = ?", 6), nil
+}
+
+// IsFormCommitteeMember ผู้ใช้เป็นคณะกรรมการ (chairman = ประธาน) ของภาคเรียนของฟอร์มหรือไม่
+func (r *AwardRepository) IsFormCommitteeMember(ctx context.Context, form *models.AwardForm, userID uint, chairman bool) (bool, error) {
+	query, err := r.formCommittee(ctx, form)
 	if err != nil {
 		return false, err
 	}
+	var count int64
+	if err := query.Where("c.user_id = ? AND c.is_chairman = ?", userID, chairman).Count(&count).Error; err != nil {
+		return false, err
+	}
 	return count > 0, nil
 }
 
+// CountFormNonChairmanCommittee จำนวนกรรมการ (ไม่รวมประธาน) ที่มีสิทธิ์โหวตฟอร์มนี้
+func (r *AwardRepository) CountFormNonChairmanCommittee(ctx context.Context, form *models.AwardForm) (int64, error) {
+	query, err := r.formCommittee(ctx, form)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	if err := query.Where("c.is_chairman = ?", false).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
 func (r *AwardRepository) IsCommitteeChairman(ctx context.Context, userID uint) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
@@ -859,20 +1100,6 @@ func (r *AwardRepository) UpsertCommitteeVoteLog(ctx context.Context, formID uin
 	return r.db.WithContext(ctx).Save(&existing).Error
 }
 
-func (r *AwardRepository) CountNonChairmanCommittees(ctx context.Context) (int64, error) {
-	var total int64
-	err := r.db.WithContext(ctx).
-		Table("\"Committee\" c").
-		Joins("JOIN \"User\" u ON u.user_id = c.user_id").
-		Where("u.role_id = ?", 6).
-		Where("c.is_chairman = ?", false).
-		Count(&total).Error
-	if err != nil {
-		return 0, err
-	}
-	return total, nil
-}
-
 func (r *AwardRepository) CountCommitteeVotesByOperation(ctx context.Context, formID uint, operation string) (int64, error) {
 	var total int64
 	err := r.db.WithContext(ctx).
@@ -957,18 +1184,151 @@ func (r *AwardRepository) GetApprovalLogByID(ctx context.Context, approvalLogID
 	return &log, nil
 }
 
-// GetAllAwardTypes ดึง award_type ทั้งหมดที่มีในตาราง (ไม่ซ้ำกัน)
-func (r *AwardRepository) GetAllAwardTypes(ctx context.Context) ([]string, error) {
-	var awardTypes []string
+// FormTimelineRow เหตุการณ์หนึ่งรายการในประวัติของฟอร์ม (อนุมัติ/ตีกลับ, โหวต, เปลี่ยนประเภทรางวัล, ลงนาม)
+type FormTimelineRow struct {
+	EventType  string    `gorm:"column:event_type"` // "approval" | "vote" | "award_type" | "signed"
+	Operation  string    `gorm:"column:operation"`
+	OldValue   string    `gorm:"column:old_value"`
+	NewValue   string    `gorm:"column:new_value"`
+	Reason     string    `gorm:"column:reason"`
+	UserID     uint      `gorm:"column:user_id"`
+	ActorName  string    `gorm:"column:actor_name"`
+	ActorRole  int       `gorm:"column:actor_role"`
+	OccurredAt time.Time `gorm:"column:occurred_at"`
+}
+
+// FormLookupNames ชื่อคณะ/ภาควิชา/วิทยาเขต/สถานะ ของฟอร์ม สำหรับแสดงผลในเอกสาร
+type FormLookupNames struct {
+	FacultyName    string `gorm:"column:faculty_name"`
+	DepartmentName string `gorm:"column:department_name"`
+	CampusName     string `gorm:"column:campus_name"`
+	FormStatusName string `gorm:"column:form_status_name"`
+}
+
+// GetFormTimeline รวมประวัติทุกประเภทของฟอร์มเรียงตามเวลา
+func (r *AwardRepository) GetFormTimeline(ctx context.Context, formID uint) ([]FormTimelineRow, error) {
+	rows := make([]FormTimelineRow, 0)
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT t.event_type, t.operation, t.old_value, t.new_value, t.reason, t.user_id,
+			TRIM(CONCAT(COALESCE(u.prefix, ''), ' ', COALESCE(u.firstname, ''), ' ', COALESCE(u.lastname, ''))) AS actor_name,
+			COALESCE(u.role_id, 0) AS actor_role,
+			t.occurred_at
+		FROM (
+			SELECT 'approval' AS event_type, approval_status AS operation, '' AS old_value, '' AS new_value,
+				COALESCE(reject_reason, '') AS reason, user_id, approved_at AS occurred_at
+			FROM "Award_Approval_Log" WHERE form_id = @form_id
+			UNION ALL
+			SELECT 'vote', operation, '', '', '', user_id, voted_at
+			FROM "Committee_Vote_Log" WHERE form_id = @form_id
+			UNION ALL
+			SELECT 'award_type', COALESCE(log_type, ''), COALESCE(old_value, ''), COALESCE(new_value, ''),
+				COALESCE(reject_reason, ''), user_id, changed_at
+			FROM "Award_Type_Log" WHERE form_id = @form_id
+			UNION ALL
+			SELECT 'signed', 'signed', '', '', '', user_id, signed_at
+			FROM "Award_Signed_Log" WHERE form_id = @form_id
+		) t
+		LEFT JOIN "User" u ON u.user_id = t.user_id
+		ORDER BY t.occurred_at ASC
+	`, map[string]interface{}{"form_id": formID}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *AwardRepository) GetFormLookupNames(ctx context.Context, formID uint) (*FormLookupNames, error) {
+	var names FormLookupNames
 	err := r.db.WithContext(ctx).
-		Model(&models.AwardForm{}).
-		Distinct("award_type").
-		Order("award_type ASC").
-		Pluck("award_type", &awardTypes).Error
+		Table(`"Award_Form" af`).
+		Joins(`LEFT JOIN "Faculty" f ON f.faculty_id = af.faculty_id`).
+		Joins(`LEFT JOIN "Department" d ON d.department_id = af.department_id`).
+		Joins(`LEFT JOIN "Campuses" c ON c.campus_id = af.campus_id`).
+		Joins(`LEFT JOIN "Form_Status" fs ON fs.form_status_id = af.form_status_id`).
+		Where("af.form_id = ?", formID).
+		Select(`
+			COALESCE(f.faculty_name, '') AS faculty_name,
+			COALESCE(d.department_name, '') AS department_name,
+			COALESCE(c.campus_name, '') AS campus_name,
+			COALESCE(fs.form_status_name, '') AS form_status_name
+		`).
+		Take(&names).Error
+	if err != nil {
+		return nil, err
+	}
+	return &names, nil
+}
 
+// GetFormIDsByStatus ดึง form_id ทั้งหมดของวิทยาเขตที่อยู่ในสถานะที่กำหนด
+func (r *AwardRepository) GetFormIDsByStatus(ctx context.Context, campusID int, formStatusID int) ([]uint, error) {
+	ids := make([]uint, 0)
+	err := r.db.WithContext(ctx).
+		Model(&models.AwardForm{}).
+		Where("campus_id = ? AND form_status_id = ?", campusID, formStatusID).
+		Order("form_id ASC").
+		Pluck("form_id", &ids).Error
 	if err != nil {
 		return nil, err
 	}
+	return ids, nil
+}
+
+func (r *AwardRepository) GetSignedLogsByFormID(ctx context.Context, formID uint) ([]models.AwardSignedLog, error) {
+	var logs []models.AwardSignedLog
+	err := r.db.WithContext(ctx).
+		Where("form_id = ?", formID).
+		Order("signed_at asc").
+		Find(&logs).Error
+	return logs, err
+}
 
-	return awardTypes, nil
-}
\ No newline at end of file
+// GetNominations ฟอร์มที่องค์กรเสนอชื่อ เรียงจากปีการศึกษา/ภาคเรียนล่าสุด
+func (r *AwardRepository) GetNominations(ctx context.Context, filter NominationFilter) ([]models.AwardForm, int64, error) {
+	var list []models.AwardForm
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.AwardForm{}).
+		Where("user_id = ? AND submitter_role_id = ?", filter.NominatorUserID, 8)
+	if filter.AcademicYear > 0 {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if filter.Semester > 0 {
+		query = query.Where("semester = ?", filter.Semester)
+	}
+	if filter.FormStatusID > 0 {
+		query = query.Where("form_status_id = ?", filter.FormStatusID)
+	}
+	if filter.AwardTypeID > 0 {
+		query = query.Where("award_type_id = ?", filter.AwardTypeID)
+	}
+	if keyword := strings.TrimSpace(filter.Keyword); keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where(
+			"(student_number ILIKE ? OR CONCAT(student_firstname, ' ', student_lastname) ILIKE ?)",
+			like, like,
+		)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("AwardFiles").
+		Order("academic_year desc, semester desc, created_at desc").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&list).Error
+	return list, total, err
+}
+
+// GetAwaitingConsent ฟอร์มที่องค์กรเสนอชื่อนิสิตคนนี้และยังรอการยินยอม
+func (r *AwardRepository) GetAwaitingConsent(ctx context.Context, nomineeUserID uint, formStatus int) ([]models.AwardForm, error) {
+	var list []models.AwardForm
+	err := r.db.WithContext(ctx).
+		Where("nominee_user_id = ? AND form_status_id = ?", nomineeUserID, formStatus).
+		Preload("AwardFiles").
+		Order("created_at desc").
+		Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AwardTypeRepository interface {
+	GetAll(ctx context.Context) ([]models.AwardType, error)
+	GetByID(ctx context.Context, awardTypeID uint) (*models.AwardType, error)
+	GetByCode(ctx context.Context, code string) (*models.AwardType, error)
+	Create(ctx context.Context, awardType *models.AwardType) error
+	Update(ctx context.Context, awardType *models.AwardType) error
+
+	// GetYearSettings การเปิด/ปิดรายปีของทุกประเภทในปีการศึกษา academicYear (key = award_type_id)
+	GetYearSettings(ctx context.Context, academicYear int) (map[uint]bool, error)
+	GetYearSettingsByType(ctx context.Context, awardTypeID uint) ([]models.AwardTypeYear, error)
+	SaveYearSetting(ctx context.Context, setting *models.AwardTypeYear) error
+
+	// LinkForms ผูก award_type_id ให้ฟอร์มที่ยังไม่มี โดยจับคู่จากชื่อใน award_type
+	LinkForms(ctx context.Context, awardTypeID uint, names []string) (int64, error)
+}
+
+type awardTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewAwardTypeRepository(db *gorm.DB) AwardTypeRepository {
+	return &awardTypeRepository{db: db}
+}
+
+func (r *awardTypeRepository) GetAll(ctx context.Context) ([]models.AwardType, error) {
+	var awardTypes []models.AwardType
+	if err := r.db.WithContext(ctx).Order("award_type_id ASC").Find(&awardTypes).Error; err != nil {
+		return nil, err
+	}
+	return awardTypes, nil
+}
+
+func (r *awardTypeRepository) GetByID(ctx context.Context, awardTypeID uint) (*models.AwardType, error) {
+	var awardType models.AwardType
+	if err := r.db.WithContext(ctx).First(&awardType, awardTypeID).Error; err != nil {
+		return nil, err
+	}
+	return &awardType, nil
+}
+
+func (r *awardTypeRepository) GetByCode(ctx context.Context, code string) (*models.AwardType, error) {
+	var awardType models.AwardType
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&awardType).Error; err != nil {
+		return nil, err
+	}
+	return &awardType, nil
+}
+
+func (r *awardTypeRepository) Create(ctx context.Context, awardType *models.AwardType) error {
+	return r.db.WithContext(ctx).Create(awardType).Error
+}
+
+func (r *awardTypeRepository) Update(ctx context.Context, awardType *models.AwardType) error {
+	return r.db.WithContext(ctx).Save(awardType).Error
+}
+
+func (r *awardTypeRepository) GetYearSettings(ctx context.Context, academicYear int) (map[uint]bool, error) {
+	var settings []models.AwardTypeYear
+	if err := r.db.WithContext(ctx).Where("academic_year = ?", academicYear).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]bool, len(settings))
+	for _, setting := range settings {
+		result[setting.AwardTypeID] = setting.IsActive
+	}
+	return result, nil
+}
+
+func (r *awardTypeRepository) GetYearSettingsByType(ctx context.Context, awardTypeID uint) ([]models.AwardTypeYear, error) {
+	var settings []models.AwardTypeYear
+	err := r.db.WithContext(ctx).
+		Where("award_type_id = ?", awardTypeID).
+		Order("academic_year DESC").
+		Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *awardTypeRepository) SaveYearSetting(ctx context.Context, setting *models.AwardTypeYear) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "award_type_id"}, {Name: "academic_year"}},
+			DoUpdates: clause.AssignmentColumns([]string{"is_active", "updated_at"}),
+		}).
+		Create(setting).Error
+}
+
+func (r *awardTypeRepository) LinkForms(ctx context.Context, awardTypeID uint, names []string) (int64, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Model(&models.AwardForm{}).
+		Where("award_type_id IS NULL AND TRIM(award_type) IN ?", names).
+		Update("award_type_id", awardTypeID)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AwardVerificationRepository interface {
+	Create(ctx context.Context, verification *models.AwardVerification) error
+	GetByFormID(ctx context.Context, formID uint) (*models.AwardVerification, error)
+	GetPublicByCode(ctx context.Context, code string) (*PublicVerificationRow, error)
+	Revoke(ctx context.Context, formID uint, revokedBy uint, reason string) error
+}
+
+// PublicVerificationRow ข้อมูลขั้นต่ำที่เปิดเผยได้ผ่าน endpoint สาธารณะ (ไม่มีข้อมูลส่วนบุคคล)
+type PublicVerificationRow struct {
+	Code             string     `gorm:"column:code"`
+	IsRevoked        bool       `gorm:"column:is_revoked"`
+	RevokedAt        *time.Time `gorm:"column:revoked_at"`
+	Prefix           string     `gorm:"column:prefix"`
+	StudentFirstname string     `gorm:"column:student_firstname"`
+	StudentLastname  string     `gorm:"column:student_lastname"`
+	AwardType        string     `gorm:"column:award_type"`
+	AcademicYear     int        `gorm:"column:academic_year"`
+	Semester         int        `gorm:"column:semester"`
+	CampusName       string     `gorm:"column:campus_name"`
+	SignedAt         *time.Time `gorm:"column:signed_at"`
+}
+
+type awardVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewAwardVerificationRepository(db *gorm.DB) AwardVerificationRepository {
+	return &awardVerificationRepository{db: db}
+}
+
+func (r *awardVerificationRepository) Create(ctx context.Context, verification *models.AwardVerification) error {
+	return dbFor(ctx, r.db).Create(verification).Error
+}
+
+func (r *awardVerificationRepository) GetByFormID(ctx context.Context, formID uint) (*models.AwardVerification, error) {
+	var verification models.AwardVerification
+	err := dbFor(ctx, r.db).Where("form_id = ?", formID).First(&verification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetPublicByCode ดึงข้อมูลเกียรติบัตรตามรหัส พร้อมวันที่ลงนามล่าสุดจาก Award_Signed_Log
+func (r *awardVerificationRepository) GetPublicByCode(ctx context.Context, code string) (*PublicVerificationRow, error) {
+	var row PublicVerificationRow
+	err := r.db.WithContext(ctx).
+		Table(`"Award_Verification" av`).
+		Joins(`JOIN "Award_Form" af ON af.form_id = av.form_id`).
+		Joins(`LEFT JOIN "User" u ON u.user_id = COALESCE(af.nominee_user_id, af.user_id)`).
+		Joins(`LEFT JOIN "Campuses" c ON c.campus_id = af.campus_id`).
+		Where("av.code = ?", code).
+		Select(`
+			av.code,
+			av.is_revoked,
+			av.revoked_at,
+			COALESCE(u.prefix, '') AS prefix,
+			af.student_firstname,
+			af.student_lastname,
+			af.award_type,
+			af.academic_year,
+			af.semester,
+			COALESCE(c.campus_name, '') AS campus_name,
+			(SELECT MAX(asl.signed_at) FROM "Award_Signed_Log" asl WHERE asl.form_id = af.form_id) AS signed_at
+		`).
+		Take(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (r *awardVerificationRepository) Revoke(ctx context.Context, formID uint, revokedBy uint, reason string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.AwardVerification{}).
+		Where("form_id = ?", formID).
+		Updates(map[string]interface{}{
+			"is_revoked":    true,
+			"revoked_at":    now,
+			"revoked_by":    revokedBy,
+			"revoke_reason": reason,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 type CampusRepository interface {
 	GetAll(ctx context.Context) ([]models.Campus, error)
+	GetByID(ctx context.Context, campusID uint) (*models.Campus, error)
 }
 
 type campusRepository struct {
@@ -27,3 +28,11 @@ func (r *campusRepository) GetAll(ctx context.Context) ([]models.Campus, error)
 	}
 	return campuses, nil
 }
+
+func (r *campusRepository) GetByID(ctx context.Context, campusID uint) (*models.Campus, error) {
+	var campus models.Campus
+	if err := r.db.WithContext(ctx).First(&campus, campusID).Error; err != nil {
+		return nil, err
+	}
+	return &campus, nil
+}
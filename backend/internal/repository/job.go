@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepository interface {
+	Create(ctx context.Context, job *models.Job) (bool, error)
+	Claim(ctx context.Context, workerID string, jobTypes []string) (*models.Job, error)
+	Complete(ctx context.Context, jobID uint) error
+	Fail(ctx context.Context, jobID uint, errMessage string, retryAt *time.Time) error
+	ReleaseStale(ctx context.Context, lockedBefore time.Time) (int64, error)
+
+	GetJobs(ctx context.Context, filter JobFilter, limit, offset int) ([]models.Job, int64, error)
+	GetJobByID(ctx context.Context, jobID uint) (*models.Job, error)
+	UpdateJobStatus(ctx context.Context, jobID uint, fromStatuses []string, updates map[string]interface{}) (bool, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+
+	GetSchedules(ctx context.Context) ([]models.JobSchedule, error)
+	GetSchedule(ctx context.Context, name string) (*models.JobSchedule, error)
+	UpdateSchedule(ctx context.Context, schedule *models.JobSchedule) error
+	EnqueueDueSchedules(ctx context.Context, now time.Time, nextRun func(schedule models.JobSchedule) (time.Time, error)) (int, error)
+}
+
+// JobFilter ค่าว่าง = ไม่กรอง
+type JobFilter struct {
+	Status string
+	Type   string
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// Create คืน false ถ้ามีงานที่ unique_key ซ้ำอยู่แล้ว (อยู่ใน transaction ที่ผูกกับ ctx ถ้ามี)
+func (r *jobRepository) Create(ctx context.Context, job *models.Job) (bool, error) {
+	result := dbFor(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "unique_key"}}, DoNothing: true}).
+		Create(job)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Claim จองงานที่ถึงเวลาแล้ว 1 งาน (SKIP LOCKED ทำให้หลาย worker ดึงพร้อมกันได้โดยไม่ชนกัน) ไม่มีงานคืน nil
+func (r *jobRepository) Claim(ctx context.Context, workerID string, jobTypes []string) (*models.Job, error) {
+	if len(jobTypes) == 0 {
+		return nil, nil
+	}
+
+	var jobs []models.Job
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE "Job" SET status = ?, locked_by = ?, locked_at = NOW(), attempts = attempts + 1, updated_at = NOW()
+		WHERE job_id = (
+			SELECT job_id FROM "Job"
+			WHERE status = ? AND run_at <= NOW() AND type IN ?
+			ORDER BY run_at ASC, job_id ASC
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`,
+		models.JobStatusRunning, workerID, models.JobStatusPending, jobTypes,
+	).Scan(&jobs).Error
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return &jobs[0], nil
+}
+
+func (r *jobRepository) Complete(ctx context.Context, jobID uint) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("job_id = ? AND status = ?", jobID, models.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":      models.JobStatusSucceeded,
+			"last_error":  "",
+			"locked_by":   "",
+			"locked_at":   nil,
+			"finished_at": now,
+			"updated_at":  now,
+		}).Error
+}
+
+// Fail retryAt เป็น nil = ย้ายไป dead letter (status dead)
+func (r *jobRepository) Fail(ctx context.Context, jobID uint, errMessage string, retryAt *time.Time) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"last_error": errMessage,
+		"locked_by":  "",
+		"locked_at":  nil,
+		"updated_at": now,
+	}
+	if retryAt != nil {
+		updates["status"] = models.JobStatusPending
+		updates["run_at"] = *retryAt
+	} else {
+		updates["status"] = models.JobStatusDead
+		updates["finished_at"] = now
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("job_id = ? AND status = ?", jobID, models.JobStatusRunning).
+		Updates(updates).Error
+}
+
+// ReleaseStale คืนงานที่ worker จองไว้นานเกินกำหนด (เช่น worker ตายกลางทาง) กลับเข้าคิว หรือย้ายไป dead ถ้าลองครบแล้ว
+func (r *jobRepository) ReleaseStale(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE "Job" SET
+			status = CASE WHEN attempts >= max_attempts THEN ? ELSE ? END,
+			finished_at = CASE WHEN attempts >= max_attempts THEN NOW() ELSE NULL END,
+			last_error = 'worker lock expired',
+			locked_by = '', locked_at = NULL, run_at = NOW(), updated_at = NOW()
+		WHERE status = ? AND locked_at < ?`,
+		models.JobStatusDead, models.JobStatusPending, models.JobStatusRunning, lockedBefore,
+	)
+	return result.RowsAffected, result.Error
+}
+
+func (r *jobRepository) GetJobs(ctx context.Context, filter JobFilter, limit, offset int) ([]models.Job, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Job{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []models.Job
+	err := query.Order("job_id DESC").Limit(limit).Offset(offset).Find(&jobs).Error
+	return jobs, total, err
+}
+
+func (r *jobRepository) GetJobByID(ctx context.Context, jobID uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.WithContext(ctx).Where("job_id = ?", jobID).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateJobStatus แก้ไขงานเฉพาะเมื่อสถานะปัจจุบันอยู่ใน fromStatuses (คืน false ถ้าสถานะเปลี่ยนไปแล้ว)
+func (r *jobRepository) UpdateJobStatus(ctx context.Context, jobID uint, fromStatuses []string, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("job_id = ? AND status IN ?", jobID, fromStatuses).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *jobRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Job{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *jobRepository) GetSchedules(ctx context.Context) ([]models.JobSchedule, error) {
+	var schedules []models.JobSchedule
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *jobRepository) GetSchedule(ctx context.Context, name string) (*models.JobSchedule, error) {
+	var schedule models.JobSchedule
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&schedule).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *jobRepository) UpdateSchedule(ctx context.Context, schedule *models.JobSchedule) error {
+	return r.db.WithContext(ctx).Save(schedule).Error
+}
+
+// EnqueueDueSchedules สร้างงานของ schedule ที่ถึงเวลา แล้วเลื่อน next_run_at ในธุรกรรมเดียวกัน
+// ล็อกแถว schedule ด้วย SKIP LOCKED เพื่อให้แต่ละรอบถูกสร้างเพียงครั้งเดียวแม้มีหลาย worker
+// schedule ที่คำนวณรอบถัดไปไม่ได้ (เช่น cron ผิดรูปแบบ) จะถูกปิดแล้วข้ามไป ไม่ทำให้ schedule อื่นล้มตาม
+// error ของ schedule ที่ถูกปิดคืนรวมกันหลัง commit เพื่อให้ผู้เรียกบันทึก log
+func (r *jobRepository) EnqueueDueSchedules(ctx context.Context, now time.Time, nextRun func(schedule models.JobSchedule) (time.Time, error)) (int, error) {
+	enqueued := 0
+	var skipped []error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var schedules []models.JobSchedule
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("enabled = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, now).
+			Find(&schedules).Error
+		if err != nil {
+			return err
+		}
+
+		for _, schedule := range schedules {
+			next, err := nextRun(schedule)
+			if err != nil {
+				if err := tx.Model(&models.JobSchedule{}).
+					Where("name = ?", schedule.Name).
+					Updates(map[string]interface{}{"enabled": false, "next_run_at": nil, "updated_at": now}).Error; err != nil {
+					return err
+				}
+				skipped = append(skipped, fmt.Errorf("%w (schedule disabled)", err))
+				continue
+			}
+
+			uniqueKey := "cron:" + schedule.Name + ":" + schedule.NextRunAt.UTC().Format(time.RFC3339)
+			job := models.Job{
+				Type:        schedule.Type,
+				Payload:     schedule.Payload,
+				Status:      models.JobStatusPending,
+				RunAt:       now,
+				MaxAttempts: 3,
+				UniqueKey:   &uniqueKey,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "unique_key"}}, DoNothing: true}).Create(&job)
+			if result.Error != nil {
+				return result.Error
+			}
+			enqueued += int(result.RowsAffected)
+
+			if err := tx.Model(&models.JobSchedule{}).
+				Where("name = ?", schedule.Name).
+				Updates(map[string]interface{}{"next_run_at": next, "last_run_at": now}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return enqueued, errors.Join(skipped...)
+}
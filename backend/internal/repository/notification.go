@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetByUserID(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	MarkAsRead(ctx context.Context, userID uint, notificationID uint) error
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+
+	GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, preference *models.NotificationPreference) error
+
+	GetUsersByIDs(ctx context.Context, userIDs []uint) ([]models.User, error)
+	GetUserIDByStudentNumber(ctx context.Context, studentNumber string) (uint, error)
+	GetHeadOfDepartmentUserIDs(ctx context.Context, departmentID int) ([]uint, error)
+	GetFacultyApproverUserIDs(ctx context.Context, roleID int, facultyID int) ([]uint, error)
+	GetUserIDsByRoleAndCampus(ctx context.Context, roleID int, campusID int) ([]uint, error)
+	GetCommitteeUserIDs(ctx context.Context, campusID int, isChairman bool) ([]uint, error)
+	GetFormStatusName(ctx context.Context, formStatusID int) (string, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []models.Notification
+	err := query.
+		Order("created_at DESC").
+		Order("notification_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead อ่านได้เฉพาะการแจ้งเตือนของตัวเอง ถ้าไม่พบจะคืน gorm.ErrRecordNotFound
+func (r *notificationRepository) MarkAsRead(ctx context.Context, userID uint, notificationID uint) error {
+	var notification models.Notification
+	err := r.db.WithContext(ctx).
+		Where("notification_id = ? AND user_id = ?", notificationID, userID).
+		First(&notification).Error
+	if err != nil {
+		return err
+	}
+	if notification.IsRead {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&notification).
+		Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()}).Error
+}
+
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("channel ASC").Find(&preferences).Error
+	return preferences, err
+}
+
+func (r *notificationRepository) UpsertPreference(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "channel"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+		}).
+		Create(preference).Error
+}
+
+func (r *notificationRepository) GetUsersByIDs(ctx context.Context, userIDs []uint) ([]models.User, error) {
+	var users []models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&users).Error
+	return users, err
+}
+
+// GetUserIDByStudentNumber หา user ของนิสิตจากรหัสนิสิต (ใช้กับฟอร์มที่องค์กรเสนอชื่อ)
+func (r *notificationRepository) GetUserIDByStudentNumber(ctx context.Context, studentNumber string) (uint, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).Select("user_id").Where("student_number = ?", studentNumber).Take(&student).Error
+	if err != nil {
+		return 0, err
+	}
+	return student.UserID, nil
+}
+
+func (r *notificationRepository) GetHeadOfDepartmentUserIDs(ctx context.Context, departmentID int) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Table(`"Head_Of_Department" h`).
+		Joins(`JOIN "User" u ON u.user_id = h.user_id`).
+		Where("h.department_id = ? AND u.role_id = ?", departmentID, 2).
+		Pluck("u.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// GetFacultyApproverUserIDs รองคณบดี (role 3) หรือ คณบดี (role 4) ของคณะ
+func (r *notificationRepository) GetFacultyApproverUserIDs(ctx context.Context, roleID int, facultyID int) ([]uint, error) {
+	tableName := ""
+	switch roleID {
+	case 3:
+		tableName = `"Associate_Dean"`
+	case 4:
+		tableName = `"Dean"`
+	default:
+		return nil, nil
+	}
+
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Table(tableName+" p").
+		Joins(`JOIN "User" u ON u.user_id = p.user_id`).
+		Where("p.faculty_id = ? AND u.role_id = ?", facultyID, roleID).
+		Pluck("u.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *notificationRepository) GetUserIDsByRoleAndCampus(ctx context.Context, roleID int, campusID int) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("role_id = ? AND campus_id = ?", roleID, campusID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *notificationRepository) GetCommitteeUserIDs(ctx context.Context, campusID int, isChairman bool) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Table(`"Committee" c`).
+		Joins(`JOIN "User" u ON u.user_id = c.user_id`).
+		Where("u.role_id = ? AND u.campus_id = ? AND c.is_chairman = ?", 6, campusID, isChairman).
+		Pluck("u.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *notificationRepository) GetFormStatusName(ctx context.Context, formStatusID int) (string, error) {
+	var status models.FormStatus
+	err := r.db.WithContext(ctx).Where("form_status_id = ?", formStatusID).Take(&status).Error
+	if err != nil {
+		return "", err
+	}
+	return status.FormStatusName, nil
+}
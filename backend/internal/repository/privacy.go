@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// erasedName ชื่อที่ใช้แทนผู้ใช้และนิสิตที่ถูกลบข้อมูล
+const erasedName = "ผู้ใช้ที่ถูกลบข้อมูล"
+
+// formStatusFinalized ฟอร์มที่เสร็จสิ้นแล้ว (เก็บไว้เป็นหลักฐานการมอบรางวัลโดยไม่มีข้อมูลระบุตัวตน)
+const formStatusFinalized = 12
+
+type PrivacyRepository interface {
+	GetUser(ctx context.Context, userID uint) (*models.User, error)
+	GetStudentByUserID(ctx context.Context, userID uint) (*models.Student, error)
+	GetFormsByUser(ctx context.Context, userID uint) ([]models.AwardForm, error)
+	GetStatusLogs(ctx context.Context, formIDs []uint) ([]models.AwardStatusLog, error)
+	GetApprovalLogs(ctx context.Context, formIDs []uint) ([]models.AwardApprovalLog, error)
+	GetTypeLogs(ctx context.Context, formIDs []uint) ([]models.AwardTypeLog, error)
+	GetNotifications(ctx context.Context, userID uint) ([]models.Notification, error)
+	GetNotificationPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	GetPublicationPreference(ctx context.Context, userID uint) (*models.PublicationPreference, error)
+
+	CreateErasureRequest(ctx context.Context, request *models.ErasureRequest) error
+	HasPendingErasureRequest(ctx context.Context, userID uint) (bool, error)
+	GetErasureRequestsByUser(ctx context.Context, userID uint) ([]models.ErasureRequest, error)
+	GetErasureRequests(ctx context.Context, status string, limit, offset int) ([]models.ErasureRequest, int64, error)
+	GetErasureRequestByID(ctx context.Context, requestID uint) (*models.ErasureRequest, error)
+	RejectErasureRequest(ctx context.Context, requestID uint, reviewedBy uint, note string) error
+	EraseUser(ctx context.Context, requestID uint, reviewedBy uint, note string) (*ErasureResult, error)
+	UpdateErasureSummary(ctx context.Context, requestID uint, summary string) error
+}
+
+// ErasureResult ผลการลบข้อมูลในฐานข้อมูล ไฟล์บน disk ให้ usecase ลบต่อหลัง commit
+type ErasureResult struct {
+	FormsDeleted    int
+	FormsAnonymised int
+	FilePaths       []string
+	ImagePath       string
+}
+
+type privacyRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivacyRepository(db *gorm.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+func (r *privacyRepository) GetUser(ctx context.Context, userID uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *privacyRepository) GetStudentByUserID(ctx context.Context, userID uint) (*models.Student, error) {
+	var student models.Student
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&student).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// GetFormsByUser ฟอร์มที่ผู้ใช้ส่งเองหรือถูกเสนอชื่อ พร้อมไฟล์แนบ
+func (r *privacyRepository) GetFormsByUser(ctx context.Context, userID uint) ([]models.AwardForm, error) {
+	var forms []models.AwardForm
+	err := r.db.WithContext(ctx).
+		Preload("AwardFiles").
+		Where("user_id = ? OR nominee_user_id = ?", userID, userID).
+		Order("form_id ASC").
+		Find(&forms).Error
+	return forms, err
+}
+
+func (r *privacyRepository) GetStatusLogs(ctx context.Context, formIDs []uint) ([]models.AwardStatusLog, error) {
+	logs := make([]models.AwardStatusLog, 0)
+	if len(formIDs) == 0 {
+		return logs, nil
+	}
+	err := r.db.WithContext(ctx).Where("form_id IN ?", formIDs).Order("changed_at ASC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *privacyRepository) GetApprovalLogs(ctx context.Context, formIDs []uint) ([]models.AwardApprovalLog, error) {
+	logs := make([]models.AwardApprovalLog, 0)
+	if len(formIDs) == 0 {
+		return logs, nil
+	}
+	err := r.db.WithContext(ctx).Where("form_id IN ?", formIDs).Order("approved_at ASC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *privacyRepository) GetTypeLogs(ctx context.Context, formIDs []uint) ([]models.AwardTypeLog, error) {
+	logs := make([]models.AwardTypeLog, 0)
+	if len(formIDs) == 0 {
+		return logs, nil
+	}
+	err := r.db.WithContext(ctx).Where("form_id IN ?", formIDs).Order("changed_at ASC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *privacyRepository) GetNotifications(ctx context.Context, userID uint) ([]models.Notification, error) {
+	notifications := make([]models.Notification, 0)
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *privacyRepository) GetNotificationPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	prefs := make([]models.NotificationPreference, 0)
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&prefs).Error
+	return prefs, err
+}
+
+func (r *privacyRepository) GetPublicationPreference(ctx context.Context, userID uint) (*models.PublicationPreference, error) {
+	var pref models.PublicationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *privacyRepository) CreateErasureRequest(ctx context.Context, request *models.ErasureRequest) error {
+	return r.db.WithContext(ctx).Create(request).Error
+}
+
+func (r *privacyRepository) HasPendingErasureRequest(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ErasureRequest{}).
+		Where("user_id = ? AND status = ?", userID, models.ErasureStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *privacyRepository) GetErasureRequestsByUser(ctx context.Context, userID uint) ([]models.ErasureRequest, error) {
+	requests := make([]models.ErasureRequest, 0)
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("requested_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *privacyRepository) GetErasureRequests(ctx context.Context, status string, limit, offset int) ([]models.ErasureRequest, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ErasureRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	requests := make([]models.ErasureRequest, 0)
+	err := query.Order("requested_at ASC").Limit(limit).Offset(offset).Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *privacyRepository) GetErasureRequestByID(ctx context.Context, requestID uint) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	if err := r.db.WithContext(ctx).Where("request_id = ?", requestID).First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *privacyRepository) RejectErasureRequest(ctx context.Context, requestID uint, reviewedBy uint, note string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&models.ErasureRequest{}).
+		Where("request_id = ? AND status = ?", requestID, models.ErasureStatusPending).
+		Updates(map[string]interface{}{
+			"status":      models.ErasureStatusRejected,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": now,
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("erasure request is not pending")
+	}
+	return nil
+}
+
+func (r *privacyRepository) UpdateErasureSummary(ctx context.Context, requestID uint, summary string) error {
+	return r.db.WithContext(ctx).Model(&models.ErasureRequest{}).
+		Where("request_id = ?", requestID).
+		Update("summary", summary).Error
+}
+
+// EraseUser ทำให้ผู้ใช้ของคำขอไม่ระบุตัวตนใน transaction เดียว
+//   - ฟอร์มที่ยังไม่เสร็จสิ้น: ลบฟอร์ม ไฟล์แนบ และประวัติทั้งหมด
+//   - ฟอร์มที่เสร็จสิ้นแล้ว: เก็บรางวัล ปีการศึกษา คณะ และลายมือชื่อไว้ แทนชื่อ/รหัสนิสิตด้วยค่านามแฝงและล้างข้อมูลส่วนบุคคล รายละเอียดผลงาน และคำตอบในฟอร์ม
+//   - User: แทนชื่อและอีเมล (token เดิมใช้ไม่ได้อีก), Student/การแจ้งเตือน/การตั้งค่า ถูกลบ
+func (r *privacyRepository) EraseUser(ctx context.Context, requestID uint, reviewedBy uint, note string) (*ErasureResult, error) {
+	result := &ErasureResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var request models.ErasureRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("request_id = ?", requestID).
+			First(&request).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("erasure request not found")
+			}
+			return err
+		}
+		if request.Status != models.ErasureStatusPending {
+			return errors.New("erasure request is not pending")
+		}
+		userID := request.UserID
+
+		var user models.User
+		if err := tx.Where("user_id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+		result.ImagePath = user.ImagePath
+
+		var forms []struct {
+			FormID       uint `gorm:"column:form_id"`
+			FormStatusID int  `gorm:"column:form_status_id"`
+		}
+		if err := tx.Model(&models.AwardForm{}).
+			Select("form_id", "form_status_id").
+			Where("user_id = ? OR nominee_user_id = ?", userID, userID).
+			Find(&forms).Error; err != nil {
+			return err
+		}
+		var deleteIDs, keepIDs, allIDs []uint
+		for _, form := range forms {
+			allIDs = append(allIDs, form.FormID)
+			if form.FormStatusID == formStatusFinalized {
+				keepIDs = append(keepIDs, form.FormID)
+			} else {
+				deleteIDs = append(deleteIDs, form.FormID)
+			}
+		}
+
+		// ไฟล์แนบของทุกฟอร์มเป็นเอกสารส่วนบุคคล ลบทั้งหมด
+		if len(allIDs) > 0 {
+			if err := tx.Model(&models.AwardFileDirectory{}).
+				Where("form_id IN ?", allIDs).
+				Pluck("file_path", &result.FilePaths).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("form_id IN ?", allIDs).Delete(&models.AwardFileDirectory{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(deleteIDs) > 0 {
+			for _, model := range []interface{}{
+				&models.AwardStatusLog{},
+				&models.AwardApprovalLog{},
+				&models.AwardTypeLog{},
+				&models.CommitteeVoteLog{},
+				&models.AwardSignedLog{},
+				&models.AwardVerification{},
+				&models.ApprovalSLAAlert{},
+				&models.AnnouncementItem{},
+				&models.Notification{},
+				&models.AwardForm{},
+			} {
+				if err := tx.Where("form_id IN ?", deleteIDs).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+			result.FormsDeleted = len(deleteIDs)
+		}
+
+		now := time.Now()
+		pseudonym := fmt.Sprintf("ERASED-%d", userID)
+		if len(keepIDs) > 0 {
+			if err := tx.Model(&models.AwardForm{}).Where("form_id IN ?", keepIDs).Updates(map[string]interface{}{
+				"student_firstname":       erasedName,
+				"student_lastname":        "",
+				"student_email":           "",
+				"student_number":          pseudonym,
+				"student_phone_number":    "",
+				"student_address":         "",
+				"gpa":                     nil,
+				"student_date_of_birth":   nil,
+				"advisor_name":            "",
+				"form_detail":             "",
+				"form_answers":            gorm.Expr("'{}'::jsonb"), // คำตอบอาจมีชื่อ อีเมล เบอร์โทร ของผู้ถูกเสนอชื่อ
+				"nominee_student_id":      nil,
+				"personal_data_purged_at": now,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.AnnouncementItem{}).Where("form_id IN ?", keepIDs).Updates(map[string]interface{}{
+				"prefix":            "",
+				"student_firstname": erasedName,
+				"student_lastname":  "",
+				"student_number":    pseudonym,
+			}).Error; err != nil {
+				return err
+			}
+			result.FormsAnonymised = len(keepIDs)
+		}
+
+		for _, model := range []interface{}{
+			&models.Notification{},
+			&models.NotificationPreference{},
+			&models.PublicationPreference{},
+			&models.Student{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.User{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+			"prefix":          "",
+			"firstname":       erasedName,
+			"lastname":        "",
+			"email":           fmt.Sprintf("erased-%d@erased.invalid", userID),
+			"hashed_password": "",
+			"image_path":      "",
+			"latest_update":   now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ErasureRequest{}).Where("request_id = ?", requestID).Updates(map[string]interface{}{
+			"status":      models.ErasureStatusCompleted,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": now,
+			"review_note": note,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RetentionRepository interface {
+	GetPolicies(ctx context.Context) ([]models.RetentionPolicy, error)
+	GetPolicy(ctx context.Context, dataClass string) (*models.RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, policy *models.RetentionPolicy) error
+
+	FindFormsToRedact(ctx context.Context, dataClass string, beforeYear int) ([]RetentionCandidate, error)
+	RedactForms(ctx context.Context, formIDs []uint, updates map[string]interface{}) error
+	// PseudonymiseForms แทนชื่อ อีเมล และรหัสนิสิตของฟอร์มและรายการในประกาศผลด้วยนามแฝงแบบเดียวกับ EraseUser
+	PseudonymiseForms(ctx context.Context, formIDs []uint) error
+	FindFilesToPurge(ctx context.Context, beforeYear int) ([]RetentionFileCandidate, error)
+	DeleteFileRecord(ctx context.Context, fileDirID uint) error
+	MarkFormsPurged(ctx context.Context, formIDs []uint) error
+
+	CreateRun(ctx context.Context, run *models.RetentionPurgeRun) error
+	UpdateRun(ctx context.Context, run *models.RetentionPurgeRun) error
+	CreateItems(ctx context.Context, items []models.RetentionPurgeItem) error
+	GetRuns(ctx context.Context, limit, offset int) ([]models.RetentionPurgeRun, int64, error)
+	GetRunByID(ctx context.Context, runID uint) (*models.RetentionPurgeRun, error)
+}
+
+// RetentionCandidate ฟอร์มที่เกินระยะเวลาเก็บรักษาและยังมีข้อมูลประเภทนั้นอยู่
+type RetentionCandidate struct {
+	FormID       uint `gorm:"column:form_id"`
+	AcademicYear int  `gorm:"column:academic_year"`
+}
+
+// RetentionFileCandidate ไฟล์แนบของฟอร์มที่เกินระยะเวลาเก็บรักษา
+type RetentionFileCandidate struct {
+	FileDirID    uint   `gorm:"column:file_dir_id"`
+	FormID       uint   `gorm:"column:form_id"`
+	AcademicYear int    `gorm:"column:academic_year"`
+	FilePath     string `gorm:"column:file_path"`
+	FileSize     int64  `gorm:"column:file_size"`
+}
+
+// retentionClassConditions เงื่อนไขว่าฟอร์มยังมีข้อมูลประเภทนั้นเหลืออยู่ (ใช้ข้ามฟอร์มที่ลบไปแล้ว)
+var retentionClassConditions = map[string]string{
+	models.RetentionClassContact:     "(COALESCE(student_address, '') <> '' OR COALESCE(student_phone_number, '') <> '')",
+	models.RetentionClassDateOfBirth: "student_date_of_birth IS NOT NULL",
+	models.RetentionClassGPA:         "gpa IS NOT NULL", // serializer เก็บ GPA 0 (ไม่มีข้อมูล) เป็น NULL
+	models.RetentionClassIdentity:    "COALESCE(student_number, '') NOT LIKE 'ERASED-%'",
+}
+
+// retentionPseudonym รหัสนิสิตนามแฝง ERASED-<user_id> เหมือน EraseUser (ฟอร์มที่ไม่ผูกผู้ใช้ใช้ ERASED-F<form_id>)
+const retentionPseudonym = "'ERASED-' || COALESCE(nominee_user_id::text, 'F' || form_id::text)"
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+func (r *retentionRepository) GetPolicies(ctx context.Context) ([]models.RetentionPolicy, error) {
+	var policies []models.RetentionPolicy
+	err := r.db.WithContext(ctx).Order("data_class ASC").Find(&policies).Error
+	return policies, err
+}
+
+func (r *retentionRepository) GetPolicy(ctx context.Context, dataClass string) (*models.RetentionPolicy, error) {
+	var policy models.RetentionPolicy
+	err := r.db.WithContext(ctx).Where("data_class = ?", dataClass).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *retentionRepository) UpdatePolicy(ctx context.Context, policy *models.RetentionPolicy) error {
+	return r.db.WithContext(ctx).Save(policy).Error
+}
+
+// FindFormsToRedact ฟอร์มที่ academic_year < beforeYear และยังมีข้อมูลประเภท dataClass อยู่
+func (r *retentionRepository) FindFormsToRedact(ctx context.Context, dataClass string, beforeYear int) ([]RetentionCandidate, error) {
+	condition, ok := retentionClassConditions[dataClass]
+	if !ok {
+		return nil, nil
+	}
+
+	var candidates []RetentionCandidate
+	err := r.db.WithContext(ctx).
+		Model(&models.AwardForm{}).
+		Select("form_id, academic_year").
+		Where("academic_year < ?", beforeYear).
+		Where(condition).
+		Order("form_id ASC").
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+func (r *retentionRepository) RedactForms(ctx context.Context, formIDs []uint, updates map[string]interface{}) error {
+	if len(formIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.AwardForm{}).
+		Where("form_id IN ?", formIDs).
+		Updates(updates).Error
+}
+
+func (r *retentionRepository) PseudonymiseForms(ctx context.Context, formIDs []uint) error {
+	if len(formIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.AwardForm{}).Where("form_id IN ?", formIDs).Updates(map[string]interface{}{
+			"student_firstname": erasedName,
+			"student_lastname":  "",
+			"student_email":     "",
+			"student_number":    gorm.Expr(retentionPseudonym),
+		}).Error; err != nil {
+			return err
+		}
+		// ประกาศผลเก็บสำเนาชื่อไว้ ใช้รหัสนามแฝงเดียวกับฟอร์ม
+		return tx.Model(&models.AnnouncementItem{}).Where("form_id IN ?", formIDs).Updates(map[string]interface{}{
+			"prefix":            "",
+			"student_firstname": erasedName,
+			"student_lastname":  "",
+			"student_number":    gorm.Expr(`(SELECT af.student_number FROM "Award_Form" af WHERE af.form_id = "Announcement_Item".form_id)`),
+		}).Error
+	})
+}
+
+func (r *retentionRepository) FindFilesToPurge(ctx context.Context, beforeYear int) ([]RetentionFileCandidate, error) {
+	var files []RetentionFileCandidate
+	err := r.db.WithContext(ctx).
+		Table(`"Award_File_Directory" afd`).
+		Joins(`JOIN "Award_Form" af ON af.form_id = afd.form_id`).
+		Select("afd.file_dir_id, afd.form_id, af.academic_year, afd.file_path, afd.file_size").
+		Where("af.academic_year < ?", beforeYear).
+		Order("afd.file_dir_id ASC").
+		Scan(&files).Error
+	return files, err
+}
+
+func (r *retentionRepository) DeleteFileRecord(ctx context.Context, fileDirID uint) error {
+	return r.db.WithContext(ctx).Delete(&models.AwardFileDirectory{}, fileDirID).Error
+}
+
+// MarkFormsPurged บันทึกเวลาที่ลบข้อมูลส่วนบุคคลครั้งแรกของฟอร์ม (ไม่ทับค่าเดิม)
+func (r *retentionRepository) MarkFormsPurged(ctx context.Context, formIDs []uint) error {
+	if len(formIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.AwardForm{}).
+		Where("form_id IN ? AND personal_data_purged_at IS NULL", formIDs).
+		Update("personal_data_purged_at", time.Now()).Error
+}
+
+func (r *retentionRepository) CreateRun(ctx context.Context, run *models.RetentionPurgeRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *retentionRepository) UpdateRun(ctx context.Context, run *models.RetentionPurgeRun) error {
+	return r.db.WithContext(ctx).Omit("Items").Save(run).Error
+}
+
+func (r *retentionRepository) CreateItems(ctx context.Context, items []models.RetentionPurgeItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(items, 500).Error
+}
+
+func (r *retentionRepository) GetRuns(ctx context.Context, limit, offset int) ([]models.RetentionPurgeRun, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.RetentionPurgeRun{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []models.RetentionPurgeRun
+	err := r.db.WithContext(ctx).
+		Order("started_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&runs).Error
+	return runs, total, err
+}
+
+func (r *retentionRepository) GetRunByID(ctx context.Context, runID uint) (*models.RetentionPurgeRun, error) {
+	var run models.RetentionPurgeRun
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_id ASC")
+		}).
+		Where("run_id = ?", runID).
+		First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SignerKeyRepository interface {
+	Create(ctx context.Context, key *models.SignerKey) error
+	GetByID(ctx context.Context, keyID uint) (*models.SignerKey, error)
+	GetActiveByUserID(ctx context.Context, userID uint) (*models.SignerKey, error)
+	GetAllByUserID(ctx context.Context, userID uint) ([]models.SignerKey, error)
+	Rotate(ctx context.Context, userID uint, newKey *models.SignerKey) error
+}
+
+type signerKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSignerKeyRepository(db *gorm.DB) SignerKeyRepository {
+	return &signerKeyRepository{db: db}
+}
+
+func (r *signerKeyRepository) Create(ctx context.Context, key *models.SignerKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *signerKeyRepository) GetByID(ctx context.Context, keyID uint) (*models.SignerKey, error) {
+	var key models.SignerKey
+	err := r.db.WithContext(ctx).Where("key_id = ?", keyID).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *signerKeyRepository) GetActiveByUserID(ctx context.Context, userID uint) (*models.SignerKey, error) {
+	var key models.SignerKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Order("created_at DESC").
+		First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *signerKeyRepository) GetAllByUserID(ctx context.Context, userID uint) ([]models.SignerKey, error) {
+	keys := make([]models.SignerKey, 0)
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rotate ปลดกุญแจเดิมของผู้ใช้ทั้งหมด แล้วบันทึกกุญแจใหม่ใน transaction เดียวกัน
+// กุญแจเดิมยังเก็บไว้เพื่อใช้ตรวจสอบลายมือชื่อที่ลงไว้ก่อนหน้า
+func (r *signerKeyRepository) Rotate(ctx context.Context, userID uint, newKey *models.SignerKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&models.SignerKey{}).
+			Where("user_id = ? AND is_active = ?", userID, true).
+			Updates(map[string]interface{}{
+				"is_active":  false,
+				"retired_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Create(newKey).Error
+	})
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SLARepository interface {
+	GetPolicies(ctx context.Context) ([]models.ApprovalSLA, error)
+	GetPolicy(ctx context.Context, formStatusID int) (*models.ApprovalSLA, error)
+	UpdatePolicy(ctx context.Context, policy *models.ApprovalSLA) error
+
+	FindFormsInStepSince(ctx context.Context, formStatusID int, campusID int, updatedBefore time.Time) ([]models.AwardForm, error)
+	CreateAlert(ctx context.Context, alert *models.ApprovalSLAAlert) (bool, error)
+	GetEscalatedFormIDs(ctx context.Context, formIDs []uint) (map[uint]bool, error)
+}
+
+type slaRepository struct {
+	db *gorm.DB
+}
+
+func NewSLARepository(db *gorm.DB) SLARepository {
+	return &slaRepository{db: db}
+}
+
+func (r *slaRepository) GetPolicies(ctx context.Context) ([]models.ApprovalSLA, error) {
+	var policies []models.ApprovalSLA
+	err := r.db.WithContext(ctx).Order("form_status_id ASC").Find(&policies).Error
+	return policies, err
+}
+
+func (r *slaRepository) GetPolicy(ctx context.Context, formStatusID int) (*models.ApprovalSLA, error) {
+	var policy models.ApprovalSLA
+	err := r.db.WithContext(ctx).Where("form_status_id = ?", formStatusID).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *slaRepository) UpdatePolicy(ctx context.Context, policy *models.ApprovalSLA) error {
+	return r.db.WithContext(ctx).Save(policy).Error
+}
+
+// FindFormsInStepSince ฟอร์มที่อยู่ในสถานะ formStatusID มาตั้งแต่ก่อน startedBefore (นับจาก step_started_at)
+// campusID เป็น 0 = ทุกวิทยาเขต (เรียงจากค้างนานที่สุด)
+func (r *slaRepository) FindFormsInStepSince(ctx context.Context, formStatusID int, campusID int, startedBefore time.Time) ([]models.AwardForm, error) {
+	query := r.db.WithContext(ctx).
+		Where("form_status_id = ? AND step_started_at <= ?", formStatusID, startedBefore)
+	if campusID > 0 {
+		query = query.Where("campus_id = ?", campusID)
+	}
+
+	var forms []models.AwardForm
+	err := query.Order("step_started_at ASC").Find(&forms).Error
+	return forms, err
+}
+
+// CreateAlert คืน false ถ้าเคยส่งการแจ้งเตือนชนิดนี้ของขั้นนี้ไปแล้ว
+func (r *slaRepository) CreateAlert(ctx context.Context, alert *models.ApprovalSLAAlert) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetEscalatedFormIDs ฟอร์มที่ถูก escalate แล้วในขั้นปัจจุบัน (เทียบกับ step_started_at ของฟอร์ม)
+func (r *slaRepository) GetEscalatedFormIDs(ctx context.Context, formIDs []uint) (map[uint]bool, error) {
+	escalated := make(map[uint]bool)
+	if len(formIDs) == 0 {
+		return escalated, nil
+	}
+
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Table(`"Approval_SLA_Alert" a`).
+		Joins(`JOIN "Award_Form" af ON af.form_id = a.form_id AND af.form_status_id = a.form_status_id AND af.step_started_at = a.step_started_at`).
+		Where("a.form_id IN ? AND a.kind = ?", formIDs, models.SLAAlertEscalation).
+		Pluck("a.form_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		escalated[id] = true
+	}
+	return escalated, nil
+}
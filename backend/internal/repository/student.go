@@ -3,6 +3,9 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"errors"
+	"strings"
+
 	"gorm.io/gorm"
 )
 
@@ -26,8 +29,14 @@ func NewStudentRepository(db *gorm.DB) StudentRepository {
 	return &studentRepository{db: db}
 }
 
+// Create บันทึกนิสิต และผูกฟอร์มที่องค์กรเคยเสนอชื่อด้วยรหัสนิสิตนี้ไว้ก่อนมีบัญชี
 func (r *studentRepository) Create(ctx context.Context, student *models.Student) error {
-	return r.db.WithContext(ctx).Create(student).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(student).Error; err != nil {
+			return err
+		}
+		return linkNominations(tx, student)
+	})
 }
 
 func (r *studentRepository) GetByID(ctx context.Context, id uint) (*models.Student, error) {
@@ -72,7 +81,37 @@ func (r *studentRepository) GetAll(ctx context.Context) ([]models.Student, error
 }
 
 func (r *studentRepository) Update(ctx context.Context, student *models.Student) error {
-	return r.db.WithContext(ctx).Save(student).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(student).Error; err != nil {
+			return err
+		}
+		return linkNominations(tx, student)
+	})
+}
+
+// linkNominations ผูกฟอร์มที่ยังไม่มี nominee_student_id เข้ากับนิสิตคนนี้
+// ต้องตรงทั้งรหัสนิสิตและอีเมลที่องค์กรกรอก (student_email) กับอีเมลบัญชีที่ยืนยันแล้ว เพื่อไม่ให้ใครอ้างรหัสนิสิตของคนอื่น
+func linkNominations(tx *gorm.DB, student *models.Student) error {
+	if student.StudentNumber == "" {
+		return nil
+	}
+	var user models.User
+	if err := tx.Select("user_id", "email").Where("user_id = ?", student.UserID).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if strings.TrimSpace(user.Email) == "" {
+		return nil
+	}
+	return tx.Model(&models.AwardForm{}).
+		Where("nominee_student_id IS NULL AND student_number = ? AND LOWER(TRIM(student_email)) = LOWER(?)",
+			student.StudentNumber, strings.TrimSpace(user.Email)).
+		Updates(map[string]interface{}{
+			"nominee_student_id": student.StudentID,
+			"nominee_user_id":    student.UserID,
+		}).Error
 }
 
 func (r *studentRepository) Delete(ctx context.Context, id uint) error {
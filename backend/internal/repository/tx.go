@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey key ของ transaction ที่ผูกไว้กับ context
+type txContextKey struct{}
+
+// WithTx ผูก transaction กับ ctx ให้ repository ที่เขียนผ่าน dbFor ทำงานใน transaction เดียวกัน
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// dbFor transaction ที่ผูกกับ ctx ถ้ามี ไม่เช่นนั้นใช้ db ของ repository
+// Transaction ซ้อนบน transaction ที่ผูกไว้จะกลายเป็น savepoint
+func dbFor(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, endpointID uint) error
+	GetEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error)
+	GetEndpointByID(ctx context.Context, endpointID uint) (*models.WebhookEndpoint, error)
+	GetActiveEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error)
+
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, deliveryID uint) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, endpointID uint, status string, limit, offset int) ([]models.WebhookDelivery, int64, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Save(endpoint).Error
+}
+
+// DeleteEndpoint ลบ endpoint พร้อมประวัติการส่งของ endpoint นั้น
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, endpointID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("endpoint_id = ?", endpointID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.WebhookEndpoint{}, endpointID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *webhookRepository) GetEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	err := r.db.WithContext(ctx).Order("endpoint_id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) GetEndpointByID(ctx context.Context, endpointID uint) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("endpoint_id = ?", endpointID).First(&endpoint).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepository) GetActiveEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	err := dbFor(ctx, r.db).Where("is_active = ?", true).Order("endpoint_id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return dbFor(ctx, r.db).Create(delivery).Error
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return dbFor(ctx, r.db).Save(delivery).Error
+}
+
+func (r *webhookRepository) GetDeliveryByID(ctx context.Context, deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.WithContext(ctx).Where("delivery_id = ?", deliveryID).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, endpointID uint, status string, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpointID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Order("delivery_id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	return deliveries, total, err
+}
@@ -1,16 +1,37 @@
 package server
 
 import (
+	"context"
+	"log"
+	"path/filepath"
+	"time"
+
 	"backend/config"
 	academicyear "backend/internal/handler/academic_year"
+	"backend/internal/handler/analytics"
+	"backend/internal/handler/announcement"
 	"backend/internal/handler/auth"
+	awardtype "backend/internal/handler/award_type"
 	"backend/internal/handler/campus"
 	"backend/internal/handler/department"
+	"backend/internal/handler/dossier"
+	"backend/internal/handler/export"
 	"backend/internal/handler/faculty"
 	formstatus "backend/internal/handler/form_status"
+	"backend/internal/handler/job"
+	"backend/internal/handler/nomination"
+	"backend/internal/handler/notification"
+	"backend/internal/handler/privacy"
+	publicannouncement "backend/internal/handler/public_announcement"
+	"backend/internal/handler/realtime"
+	"backend/internal/handler/retention"
 	"backend/internal/handler/role"
+	"backend/internal/handler/signature"
+	"backend/internal/handler/sla"
 	"backend/internal/handler/student"
 	"backend/internal/handler/user"
+	"backend/internal/handler/verification"
+	"backend/internal/handler/webhook"
 
 	awardform "backend/internal/handler/award_form"
 	"backend/internal/middleware"
@@ -19,6 +40,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"gorm.io/gorm"
 )
@@ -54,25 +76,66 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 	campusRepo := repository.NewCampusRepository(db)
 	roleRepo := repository.NewRoleRepository(db)
 	formStatusRepo := repository.NewFormStatusRepository(db)
+	verificationRepo := repository.NewAwardVerificationRepository(db)
+	signerKeyRepo := repository.NewSignerKeyRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	privacyRepo := repository.NewPrivacyRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	slaRepo := repository.NewSLARepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	announcementRepo := repository.NewAnnouncementRepository(db)
+	awardTypeRepo := repository.NewAwardTypeRepository(db)
 
 	// --- 3. Usecase Layer (Business Logic) ---
 	// ส่ง Repository และ Config เข้าไปใน Usecase
 	authService := usecase.NewAuthUsecaseWithRepos(userRepo, studentRepo, organizationRepo, roleProfileRepo, googleConfig)
-	academicYearService := usecase.NewAcademicYearService(academicYearRepo)
+	academicYearService := usecase.NewAcademicYearService(academicYearRepo, awardTypeRepo)
 	studentService := usecase.NewStudentService(studentRepo)
 	organizationService := usecase.NewOrganizationService(organizationRepo)
-	awardService := usecase.NewAwardUseCase(awardRepo, studentService, organizationService, academicYearService)
+	verificationService := usecase.NewVerificationService(verificationRepo, awardRepo)
+	dossierService := usecase.NewDossierService(awardRepo, config.LoadPDFFontPath())
+	signingMasterKey, err := config.LoadSigningMasterKey()
+	if err != nil {
+		log.Fatal("Failed to load signing master key: ", err)
+	}
+	signatureService := usecase.NewSignatureService(signerKeyRepo, awardRepo, signingMasterKey)
+	retentionService := usecase.NewRetentionService(retentionRepo, academicYearRepo, "uploads")
+	profileImageService := usecase.NewProfileImageService(filepath.Join("uploads", "user-profile"))
+	privacyService := usecase.NewPrivacyService(privacyRepo, profileImageService, "uploads")
+	realtimeService := usecase.NewRealtimeService(db, config.LoadDSN())
+	go realtimeService.Run(context.Background())
+	jobService := usecase.NewJobService(jobRepo)
+	webhookService := usecase.NewWebhookService(webhookRepo, jobService)
+	notificationChannels, deliveryChannels := buildNotificationChannels(notificationRepo, realtimeService, jobService)
+	notificationService := usecase.NewNotificationService(notificationRepo, notificationChannels...)
+	slaService := usecase.NewSLAService(slaRepo, notificationRepo, notificationService)
+	announcementService := usecase.NewAnnouncementService(announcementRepo, academicYearRepo, jobService)
+	publicAnnouncementConfig := config.LoadPublicAnnouncementConfig()
+	publicAnnouncementService := usecase.NewPublicAnnouncementService(announcementRepo, campusRepo, publicAnnouncementConfig, config.LoadFrontendBaseURL())
+	// worker ในตัว API server (ปิดด้วย JOB_WORKER_EMBEDDED=false เมื่อรัน "./main worker" แยก)
+	if jobWorkerConfig := config.LoadJobWorkerConfig(); jobWorkerConfig.Embedded {
+		jobWorker := usecase.NewJobWorker(jobRepo, jobWorkerConfig)
+		registerJobHandlers(jobWorker, retentionService, slaService, webhookService, announcementService, notificationRepo, deliveryChannels)
+		go jobWorker.Run(context.Background())
+	}
+	awardTypeService := usecase.NewAwardTypeService(awardTypeRepo)
+	awardService := usecase.NewAwardUseCase(awardRepo, studentService, organizationService, academicYearService, verificationService, signatureService, notificationService, realtimeService, webhookService, awardTypeService)
 	userService := usecase.NewUserUsecase(userRepo)
 	facultyService := usecase.NewFacultyService(facultyRepo)
 	departmentService := usecase.NewDepartmentService(departmentRepo)
 	campusService := usecase.NewCampusService(campusRepo)
 	roleService := usecase.NewRoleService(roleRepo)
 	formStatusService := usecase.NewFormStatusService(formStatusRepo)
+	exportService := usecase.NewExportService(awardService, facultyRepo, departmentRepo, formStatusRepo)
+	analyticsService := usecase.NewAnalyticsService(analyticsRepo, config.LoadAnalyticsCacheTTL())
 
 	// --- 4. Handler Layer (Controller) ---
 	// สร้าง Handler ที่จะรับ HTTP Request
-	authHandler := auth.NewAuthHandlerWithServices(authService, studentService, organizationService)
+	authHandler := auth.NewAuthHandlerWithServices(authService, studentService, organizationService, profileImageService)
 	awardHandler := awardform.NewAwardHandler(awardService, studentService, academicYearService)
+	nominationHandler := nomination.NewNominationHandler(awardService, academicYearService)
 	userHandler := user.NewUserHandlerWithAuth(userService, authService)
 	academicYearHandler := academicyear.NewAcademicYearHandler(academicYearService)
 	facultyHandler := faculty.NewFacultyHandler(facultyService)
@@ -81,9 +144,26 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 	campusHandler := campus.NewCampusHandler(campusService)
 	roleHandler := role.NewRoleHandler(roleService)
 	formStatusHandler := formstatus.NewFormStatusHandler(formStatusService)
+	verificationHandler := verification.NewVerificationHandler(verificationService)
+	dossierHandler := dossier.NewDossierHandler(dossierService)
+	signatureHandler := signature.NewSignatureHandler(signatureService)
+	retentionHandler := retention.NewRetentionHandler(retentionService)
+	privacyHandler := privacy.NewPrivacyHandler(privacyService)
+	notificationHandler := notification.NewNotificationHandler(notificationService)
+	realtimeHandler := realtime.NewRealtimeHandler(realtimeService)
+	slaHandler := sla.NewSLAHandler(slaService)
+	jobHandler := job.NewJobHandler(jobService)
+	webhookHandler := webhook.NewWebhookHandler(webhookService)
+	analyticsHandler := analytics.NewAnalyticsHandler(analyticsService)
+	exportHandler := export.NewExportHandler(exportService)
+	announcementHandler := announcement.NewAnnouncementHandler(announcementService)
+	awardTypeHandler := awardtype.NewAwardTypeHandler(awardTypeService)
+	publicAnnouncementHandler := publicannouncement.NewPublicAnnouncementHandler(publicAnnouncementService, publicAnnouncementConfig.CacheMaxAge)
 
 	// --- 5. Routing Definition ---
 	apiGroup := app.Group("/api")
+	// requireAdmin ใช้ต่อจาก RequireAuth กับ route ที่เฉพาะกองพัฒนานิสิต (role 5)
+	requireAdmin := middleware.RequireRole(5)
 
 	// --- Auth Routes ---
 	authGroup := apiGroup.Group("/auth")
@@ -100,9 +180,21 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 
 	// --- Academic Year Routes ---
 	academicYearGroup := apiGroup.Group("/academic-years")
-	academicYearGroup.Get("/all", academicYearHandler.GetAllAcademicYears)    // ส่ง List เฉพาะปี (ไม่ซ้ำ) เอาไป sort
-	academicYearGroup.Post("/create", academicYearHandler.CreateAcademicYear) // สร้างปีการศึกษา ()
-	academicYearGroup.Get("/current", academicYearHandler.GetCurrentSemester)
+	academicYearGroup.Get("/all", academicYearHandler.GetAllAcademicYears)                                                           // ส่ง List เฉพาะปี (ไม่ซ้ำ) เอาไป sort
+	academicYearGroup.Post("/create", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.CreateAcademicYear)        // สร้างปีการศึกษา (กองพัฒนานิสิต)
+	academicYearGroup.Get("/current", academicYearHandler.GetCurrentSemester)                                                        // query: campus_id (ไม่ส่ง = ปฏิทินกลาง)
+	academicYearGroup.Get("/calendar", academicYearHandler.GetCampusCalendar)                                                        // ภาคเรียนและช่วงเวลาเปิดรับ/พิจารณา query: campus_id
+	academicYearGroup.Get("/term-types", academicYearHandler.GetTermTypes)                                                           // ประเภทภาคการศึกษาและลำดับในปี query: campus_id
+	academicYearGroup.Put("/term-types", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.UpdateTermTypes)        // query: campus_id, body: term_types [{semester, name, is_optional}] ตามลำดับในปี
+	academicYearGroup.Post("/:id/windows", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.CreateWindow)         // body: award_type_id (optional), stage (0 = ส่งฟอร์ม, 2-7 = ขั้นพิจารณา), start_date, end_date
+	academicYearGroup.Put("/windows/:windowId", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.UpdateWindow)    // กองพัฒนานิสิต
+	academicYearGroup.Delete("/windows/:windowId", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.DeleteWindow) // กองพัฒนานิสิต
+	academicYearGroup.Put("/:id", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.UpdateAcademicYear)            // กองพัฒนานิสิต (ปี/เทอมแก้ได้เฉพาะ upcoming)
+	academicYearGroup.Delete("/:id", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.DeleteAcademicYear)         // ลบได้เฉพาะ upcoming
+	academicYearGroup.Post("/:id/status", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.ChangeStatus)          // body: status (open|review|closed|archived), expire_pending, note
+	academicYearGroup.Post("/:id/rollover", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.Rollover)            // body: semester (optional), start_date, end_date, note
+	academicYearGroup.Get("/:id/summary", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.GetTermSummary)        // สรุปจำนวนฟอร์มและคณะกรรมการของภาคเรียน
+	academicYearGroup.Get("/:id/transitions", middleware.RequireAuth(userRepo), requireAdmin, academicYearHandler.GetTransitions)    // รายงานการเปลี่ยนสถานะแต่ละครั้ง
 
 	// --- Faculty Routes ---
 	facultyGroup := apiGroup.Group("/faculty")
@@ -129,14 +221,29 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 
 	awardGroup := apiGroup.Group("/awards", middleware.RequireAuth(userRepo))
 	awardGroup.Post("/submit", awardHandler.Submit)                                         // POST /awards/submit
-	awardGroup.Get("/search", awardHandler.GetByKeyword)                                    // ค้นหาและกรองพร้อม pagination (query: keyword, date, student_year, page, limit)
+	awardGroup.Get("/nominations", nominationHandler.GetNominations)                        // รายการเสนอชื่อขององค์กร (role 8)
+	awardGroup.Get("/nominations/template", nominationHandler.GetTemplate)                  // หัวตาราง CSV สำหรับเสนอชื่อแบบกลุ่ม
+	awardGroup.Post("/nominations/bulk", nominationHandler.BulkNominate)                    // multipart: file (CSV)
+	awardGroup.Get("/my/consents", nominationHandler.GetMyPendingConsents)                  // การเสนอชื่อขององค์กรที่รอนิสิตยินยอม (role 1)
+	awardGroup.Post("/consent/:formId/accept", nominationHandler.AcceptNomination)          // นิสิตยินยอมพร้อมกรอก GPA, ที่อยู่, วันเกิด
+	awardGroup.Post("/consent/:formId/decline", nominationHandler.DeclineNomination)        // นิสิตปฏิเสธการเสนอชื่อ
+	awardGroup.Get("/search", awardHandler.GetByKeyword)                                    // ค้นหาและกรองพร้อม pagination (query: keyword, date, student_year, page, limit, answer.<key>)
 	awardGroup.Get("/announcement", awardHandler.GetAnnouncementAwards)                     // ประกาศผลตาม campus พร้อม filter ปี/เทอม/รางวัล/คณะ
 	awardGroup.Get("/my/submissions", awardHandler.GetMySubmissions)                        // ดูการส่งฟอร์มของตัวเอง (Student/Organization) - sorted by created_at desc (ทั้งหมดที่เคยส่ง)
 	awardGroup.Get("/my/submissions/current", awardHandler.GetMyCurrentSemesterSubmissions) // ดูการส่งฟอร์มของตัวเองในภาคเรียนปัจจุบัน (isActive)
 	awardGroup.Get("/types", awardHandler.GetAllAwardTypes)
-	awardGroup.Get("/details/:formId", awardHandler.GetByFormID) // GET ดูรายละเอียดฟอร์ม
+	awardGroup.Get("/my/publication-preference", publicAnnouncementHandler.GetMyPublicationPreference)    // ยินยอมให้แสดงชื่อในประกาศผลสาธารณะหรือไม่
+	awardGroup.Put("/my/publication-preference", publicAnnouncementHandler.UpdateMyPublicationPreference) // body: {"opt_out": true}
+	awardGroup.Get("/details/:formId", awardHandler.GetByFormID)                                          // GET ดูรายละเอียดฟอร์ม
+	awardGroup.Get("/details/:formId/dossier", dossierHandler.GetFormDossier)                             // GET แฟ้มเสนอชื่อ (PDF)
+	awardGroup.Get("/dossiers/committee", dossierHandler.GetCommitteeDossiers)                            // GET zip แฟ้มของทุกฟอร์มในขั้นคณะกรรมการ
 	awardGroup.Get("/my/approval-logs", awardHandler.GetMyApprovalLogs)
 	awardGroup.Get("/my/vote-logs", awardHandler.GetMyVoteLogs)
+	// ส่งออกเป็นไฟล์ (query เดิม + format=csv|xlsx, columns=key1,key2) ดูคอลัมน์ได้ที่ /export/columns?dataset=
+	awardGroup.Get("/export/columns", exportHandler.GetColumns)
+	awardGroup.Get("/search/export", exportHandler.ExportSearch)
+	awardGroup.Get("/announcement/export", exportHandler.ExportAnnouncement)
+	awardGroup.Get("/my/approval-logs/export", exportHandler.ExportApprovalLogs)
 	awardGroup.Get("/approval-logs/:formId", awardHandler.GetApprovalLogDetail) // GET /awards/approval-logs/:id
 
 	awardGroup.Get("/my/award-type-logs", awardHandler.GetAwardTypeLogs)
@@ -147,12 +254,115 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 
 	awardGroup.Put("/award-type/change/:formId", awardHandler.UpdateAwardType)
 
+	awardGroup.Get("/verification/:formId", verificationHandler.GetByFormID)                 // รหัสตรวจสอบเกียรติบัตร (เจ้าของฟอร์ม / กองพัฒนานิสิต)
+	awardGroup.Put("/verification/revoke/:formId", requireAdmin, verificationHandler.Revoke) // เพิกถอนรางวัล (กองพัฒนานิสิต)
+
+	awardGroup.Get("/signatures/verify/:formId", signatureHandler.VerifyForm) // ตรวจลายมือชื่อดิจิทัลว่าข้อมูลไม่ถูกแก้ไขหลังลงนาม
+	awardGroup.Get("/signatures/keys/me", signatureHandler.GetMyKeys)         // กุญแจลงนามของตัวเอง (role 6, 7)
+	awardGroup.Post("/signatures/keys/rotate", signatureHandler.RotateMyKey)  // สร้างกุญแจใหม่ (role 6, 7)
+
 	userGroup := apiGroup.Group("/users", middleware.RequireAuth(userRepo))
 	userGroup.Get("/", userHandler.GetAllUsersByCampus) // GET /users (ดึง user ตามวิทยาเขตของคนที่ login)
 	userGroup.Get("/info/:id", userHandler.GetUserByID) // GET /users/:id
 	userGroup.Put("/promote-chairman/:id", userHandler.ChangeCommitteeRole)
 	userGroup.Put("/update/:id", userHandler.UpdateUserByID) // PUT /users/:id
 
+	// --- Notification Routes ---
+	notificationGroup := apiGroup.Group("/notifications", middleware.RequireAuth(userRepo))
+	notificationGroup.Get("/", notificationHandler.GetInbox)                       // inbox ของตัวเอง (query: page, limit, unread_only)
+	notificationGroup.Get("/bell", notificationHandler.GetBell)                    // จำนวนที่ยังไม่อ่าน + 5 รายการล่าสุด
+	notificationGroup.Get("/unread-count", notificationHandler.GetUnreadCount)     // จำนวนที่ยังไม่อ่าน
+	notificationGroup.Put("/read-all", notificationHandler.MarkAllAsRead)          // อ่านทั้งหมด
+	notificationGroup.Put("/:notificationId/read", notificationHandler.MarkAsRead) // อ่านรายการเดียว
+	notificationGroup.Get("/preferences", notificationHandler.GetPreferences)      // ช่องทางแจ้งเตือนที่เปิดรับ (in_app, email)
+	notificationGroup.Put("/preferences", notificationHandler.UpdatePreferences)   // เปิด/ปิดช่องทางแจ้งเตือนของตัวเอง
+
+	// --- Realtime Routes ---
+	// SSE: ผลโหวตกรรมการ, การเปลี่ยนสถานะฟอร์ม และการแจ้งเตือนใหม่ (กระจายข้าม replica ผ่าน Postgres LISTEN/NOTIFY)
+	realtimeGroup := apiGroup.Group("/realtime", middleware.RequireAuth(userRepo))
+	realtimeGroup.Get("/stream", realtimeHandler.Stream)
+
+	// --- SLA Routes (กองพัฒนานิสิต) ---
+	slaGroup := apiGroup.Group("/sla", middleware.RequireAuth(userRepo), requireAdmin)
+	slaGroup.Get("/policies", slaHandler.GetPolicies)                // ระยะเวลาที่กำหนดของแต่ละขั้น
+	slaGroup.Put("/policies/:formStatusId", slaHandler.UpdatePolicy) // แก้ไข SLA ของขั้น (duration_hours, reminder_before_hours, escalate_to)
+	slaGroup.Get("/overdue", slaHandler.GetOverdue)                  // dashboard ฟอร์มเกินกำหนด แยกตามขั้นและผู้พิจารณา
+	slaGroup.Post("/check", slaHandler.RunCheck)                     // ตรวจและส่งการเตือนทันที
+
+	// --- Background Job Routes (กองพัฒนานิสิต) ---
+	jobGroup := apiGroup.Group("/jobs", middleware.RequireAuth(userRepo), requireAdmin)
+	jobGroup.Get("/", jobHandler.GetJobs)                       // รายการงาน (query: status, type, page, limit) status=dead คือ dead letter
+	jobGroup.Post("/", jobHandler.EnqueueJob)                   // สร้างงานเอง (retention.purge, sla.check) ตั้ง run_at ล่วงหน้าได้
+	jobGroup.Get("/stats", jobHandler.GetStats)                 // จำนวนงานแยกตามสถานะ
+	jobGroup.Get("/schedules", jobHandler.GetSchedules)         // งานตามรอบเวลา (cron)
+	jobGroup.Put("/schedules/:name", jobHandler.UpdateSchedule) // แก้ไข cron_expr / enabled / payload
+	jobGroup.Get("/:jobId", jobHandler.GetJob)                  // รายละเอียดงาน
+	jobGroup.Post("/:jobId/retry", jobHandler.RetryJob)         // นำงาน dead/cancelled กลับเข้าคิว
+	jobGroup.Post("/:jobId/cancel", jobHandler.CancelJob)       // ยกเลิกงานที่ยังไม่เริ่ม
+
+	// --- Webhook Routes (กองพัฒนานิสิต) ---
+	// เหตุการณ์: award.submitted, award.status_changed, award.completed, committee.vote_cast
+	webhookGroup := apiGroup.Group("/webhooks", middleware.RequireAuth(userRepo), requireAdmin)
+	webhookGroup.Get("/", webhookHandler.GetEndpoints)
+	webhookGroup.Post("/", webhookHandler.CreateEndpoint)                            // ลงทะเบียน endpoint (คืน secret สำหรับตรวจลายเซ็นครั้งเดียว)
+	webhookGroup.Post("/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver) // ส่ง event เดิมซ้ำผ่านคิว
+	webhookGroup.Get("/:endpointId", webhookHandler.GetEndpoint)
+	webhookGroup.Put("/:endpointId", webhookHandler.UpdateEndpoint)
+	webhookGroup.Delete("/:endpointId", webhookHandler.DeleteEndpoint)
+	webhookGroup.Post("/:endpointId/rotate-secret", webhookHandler.RotateSecret) // ออก secret ใหม่
+	webhookGroup.Post("/:endpointId/test", webhookHandler.SendTest)              // ส่ง webhook.test ทันทีและคืนผล
+	webhookGroup.Get("/:endpointId/deliveries", webhookHandler.GetDeliveries)    // ประวัติการส่ง (query: status, page, limit)
+
+	// --- Analytics Routes (กองพัฒนานิสิต) ---
+	// กรองได้ด้วย campus_id, faculty_id, department_id, award_type, academic_year, semester (ผลถูก cache ตาม ANALYTICS_CACHE_TTL, refresh=true เพื่อโหลดใหม่)
+	analyticsGroup := apiGroup.Group("/analytics", middleware.RequireAuth(userRepo), requireAdmin)
+	analyticsGroup.Get("/summary", analyticsHandler.GetSummary)                    // จำนวนรวม อนุมัติ/ไม่อนุมัติ/ระหว่างพิจารณา และอัตราอนุมัติ
+	analyticsGroup.Get("/submissions", analyticsHandler.GetSubmissions)            // จำนวนฟอร์มแยกตาม group_by (default: month)
+	analyticsGroup.Get("/approval-rates", analyticsHandler.GetApprovalRates)       // อัตราอนุมัติแยกตาม group_by (default: faculty)
+	analyticsGroup.Get("/rejection-reasons", analyticsHandler.GetRejectionReasons) // เหตุผลการตีกลับที่พบบ่อย (query: limit)
+	analyticsGroup.Get("/step-durations", analyticsHandler.GetStepDurations)       // เวลาเฉลี่ย/มัธยฐานที่ใช้ในแต่ละขั้น (ชั่วโมง)
+
+	// --- Award Type Catalogue Routes ---
+	// รายการใช้ได้ทุก role ส่วนการจัดการแคตตาล็อกและเงื่อนไขคุณสมบัติเฉพาะกองพัฒนานิสิต
+	awardTypeGroup := apiGroup.Group("/award-types", middleware.RequireAuth(userRepo))
+	awardTypeGroup.Get("/", awardTypeHandler.GetAwardTypes) // query: academic_year, include_inactive
+	awardTypeGroup.Post("/", requireAdmin, awardTypeHandler.CreateAwardType)
+	awardTypeGroup.Get("/:id", requireAdmin, awardTypeHandler.GetAwardType)
+	awardTypeGroup.Put("/:id", requireAdmin, awardTypeHandler.UpdateAwardType)
+	awardTypeGroup.Put("/:id/years/:year", requireAdmin, awardTypeHandler.SetYearActive)    // เปิด/ปิดรับเสนอชื่อในปีการศึกษา
+	awardTypeGroup.Put("/:id/form-schema", requireAdmin, awardTypeHandler.UpdateFormSchema) // นิยามฟิลด์ของฟอร์มเฉพาะประเภทรางวัล
+
+	// --- Announcement Routes (กองพัฒนานิสิต) ---
+	// ประกาศผลต่อวิทยาเขต/ภาคเรียน: ร่าง -> (ตั้งเวลา) -> เผยแพร่ -> ฉบับแก้ไข/ถอนประกาศ หน้าประกาศผลแสดงเฉพาะฟอร์มในฉบับที่เผยแพร่
+	announcementGroup := apiGroup.Group("/announcements", middleware.RequireAuth(userRepo), requireAdmin)
+	announcementGroup.Get("/", announcementHandler.GetAnnouncements) // query: status, page, limit
+	announcementGroup.Post("/", announcementHandler.CreateAnnouncement)
+	announcementGroup.Get("/:id", announcementHandler.GetAnnouncement)
+	announcementGroup.Put("/:id", announcementHandler.UpdateDraft) // แก้ไขฉบับร่าง (title, form_ids, include_all)
+	announcementGroup.Delete("/:id", announcementHandler.DeleteAnnouncement)
+	announcementGroup.Get("/:id/preview", announcementHandler.Preview)        // query: version (ว่าง = ฉบับร่าง/ฉบับที่เผยแพร่)
+	announcementGroup.Post("/:id/publish", announcementHandler.Publish)       // body: {"publish_at": "..."} ว่าง = เผยแพร่ทันที
+	announcementGroup.Post("/:id/unschedule", announcementHandler.Unschedule) // ยกเลิกการตั้งเวลา
+	announcementGroup.Post("/:id/amend", announcementHandler.Amend)           // body: {"note": "..."} สร้างฉบับแก้ไขจากฉบับที่เผยแพร่
+	announcementGroup.Post("/:id/retract", announcementHandler.Retract)       // body: {"reason": "..."}
+
+	// --- Data Retention Routes (PDPA, กองพัฒนานิสิต) ---
+	retentionGroup := apiGroup.Group("/retention", middleware.RequireAuth(userRepo), requireAdmin)
+	retentionGroup.Get("/policies", retentionHandler.GetPolicies)
+	retentionGroup.Put("/policies/:dataClass", retentionHandler.UpdatePolicy)
+	retentionGroup.Post("/purge", retentionHandler.RunPurge) // body: {"dry_run": true} ดูรายงานก่อนลบจริง
+	retentionGroup.Get("/runs", retentionHandler.GetRuns)
+	retentionGroup.Get("/runs/:runId", retentionHandler.GetRunByID)
+
+	// --- Data Subject Rights Routes (PDPA: ขอสำเนาข้อมูล / ขอลบข้อมูล) ---
+	privacyGroup := apiGroup.Group("/privacy", middleware.RequireAuth(userRepo))
+	privacyGroup.Get("/export", privacyHandler.ExportMyData)                                               // zip ข้อมูลส่วนบุคคลทั้งหมดของผู้ใช้ (data.json + ไฟล์แนบ)
+	privacyGroup.Post("/erasure-requests", privacyHandler.RequestErasure)                                  // นิสิตยื่นคำขอลบข้อมูล
+	privacyGroup.Get("/erasure-requests/my", privacyHandler.GetMyErasureRequests)                          // คำขอของตัวเอง
+	privacyGroup.Get("/erasure-requests", requireAdmin, privacyHandler.GetErasureRequests)                 // กองพัฒนานิสิต: รายการคำขอ (query: status, page, limit)
+	privacyGroup.Post("/erasure-requests/:requestId/approve", requireAdmin, privacyHandler.ApproveErasure) // กองพัฒนานิสิต: อนุมัติและดำเนินการลบ
+	privacyGroup.Post("/erasure-requests/:requestId/reject", requireAdmin, privacyHandler.RejectErasure)   // กองพัฒนานิสิต: ปฏิเสธ (ต้องระบุ note)
+
 	// --- Campus Routes ---
 	campusGroup := apiGroup.Group("/campus")
 	campusGroup.Get("/", campusHandler.GetAllCampuses)
@@ -164,4 +374,35 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 	// --- Form Status Routes ---
 	formStatusGroup := apiGroup.Group("/form-statuses")
 	formStatusGroup.Get("/", formStatusHandler.GetAllFormStatuses)
-}
\ No newline at end of file
+
+	// --- Public Verification Routes (ไม่ต้อง login, จำกัดจำนวน request ต่อ IP) ---
+	verifyGroup := apiGroup.Group("/verify", limiter.New(limiter.Config{
+		Max:        30,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"status":  "error",
+				"message": "too many requests",
+			})
+		},
+	}))
+	verifyGroup.Get("/:code", verificationHandler.Verify)
+
+	// --- Public Announcement Routes (ไม่ต้อง login) ---
+	// แสดงเฉพาะฉบับที่เผยแพร่ ฟิลด์ตาม PUBLIC_ANNOUNCEMENT_FIELDS และตัดนิสิตที่ opt-out ออก
+	// ใส่ Cache-Control/ETag/Last-Modified ตาม PUBLIC_ANNOUNCEMENT_CACHE_MAX_AGE
+	publicGroup := apiGroup.Group("/public", limiter.New(limiter.Config{
+		Max:        120,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"status":  "error",
+				"message": "too many requests",
+			})
+		},
+	}))
+	publicGroup.Get("/announcements", publicAnnouncementHandler.GetAnnouncements) // query: campus_id, academic_year, semester, page, limit
+	publicGroup.Get("/announcements/:id", publicAnnouncementHandler.GetAnnouncement)
+	publicGroup.Get("/campuses/:campusId/announcements.rss", publicAnnouncementHandler.GetRSSFeed)
+	publicGroup.Get("/campuses/:campusId/announcements.atom", publicAnnouncementHandler.GetAtomFeed)
+}
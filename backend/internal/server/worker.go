@@ -0,0 +1,60 @@
+package server
+
+import (
+	"backend/config"
+	"backend/internal/repository"
+	"backend/internal/usecase"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gorm.io/gorm"
+)
+
+// RunWorker โหมด worker ของ binary เดียวกัน ("./main worker") ทำงานในคิวจนได้รับ SIGINT/SIGTERM
+func RunWorker(db *gorm.DB) {
+	academicYearRepo := repository.NewAcademicYearRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	slaRepo := repository.NewSLARepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	announcementRepo := repository.NewAnnouncementRepository(db)
+
+	jobService := usecase.NewJobService(jobRepo)
+	webhookService := usecase.NewWebhookService(webhookRepo, jobService)
+	realtimeService := usecase.NewRealtimeService(db, config.LoadDSN())
+	notificationChannels, deliveryChannels := buildNotificationChannels(notificationRepo, realtimeService, jobService)
+	notificationService := usecase.NewNotificationService(notificationRepo, notificationChannels...)
+	retentionService := usecase.NewRetentionService(retentionRepo, academicYearRepo, "uploads")
+	slaService := usecase.NewSLAService(slaRepo, notificationRepo, notificationService)
+	announcementService := usecase.NewAnnouncementService(announcementRepo, academicYearRepo, jobService)
+
+	worker := usecase.NewJobWorker(jobRepo, config.LoadJobWorkerConfig())
+	registerJobHandlers(worker, retentionService, slaService, webhookService, announcementService, notificationRepo, deliveryChannels)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	worker.Run(ctx)
+}
+
+// buildNotificationChannels คืนช่องทางที่ NotificationService ใช้ (อีเมลเข้าคิว) และช่องทางจริงที่ worker ใช้ส่งงานในคิว
+func buildNotificationChannels(repo repository.NotificationRepository, realtime usecase.RealtimeService, jobs usecase.JobService) ([]usecase.NotificationChannel, []usecase.NotificationChannel) {
+	channels := []usecase.NotificationChannel{usecase.NewInAppChannel(repo, realtime)}
+	var delivery []usecase.NotificationChannel
+	if smtpConfig := config.LoadSMTPConfig(); smtpConfig.Host != "" {
+		delivery = append(delivery, usecase.NewEmailChannel(smtpConfig, config.LoadFrontendBaseURL()))
+		channels = append(channels, usecase.NewQueuedChannel(usecase.NotificationChannelEmail, jobs))
+	}
+	return channels, delivery
+}
+
+// registerJobHandlers ผูก handler ของงานทุกชนิดเข้ากับ worker
+func registerJobHandlers(worker usecase.JobWorker, retentionService usecase.RetentionService, slaService usecase.SLAService, webhookService usecase.WebhookService, announcementService usecase.AnnouncementService, notificationRepo repository.NotificationRepository, deliveryChannels []usecase.NotificationChannel) {
+	worker.Register(usecase.JobTypeRetentionPurge, usecase.RetentionPurgeJobHandler(retentionService))
+	worker.Register(usecase.JobTypeSLACheck, usecase.SLACheckJobHandler(slaService))
+	worker.Register(usecase.JobTypeNotificationSend, usecase.NotificationSendJobHandler(notificationRepo, deliveryChannels...))
+	worker.Register(usecase.JobTypeWebhookDeliver, usecase.WebhookDeliverJobHandler(webhookService))
+	worker.Register(usecase.JobTypeAnnouncementPublish, usecase.AnnouncementPublishJobHandler(announcementService))
+}
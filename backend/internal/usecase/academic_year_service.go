@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -18,16 +20,74 @@ type AcademicYearService interface {
 	GetAllAcademicYears(ctx context.Context) ([]models.AcademicYear, error)
 	UpdateAcademicYear(ctx context.Context, id uint, req *academicYearDTO.UpdateAcademicYear) (*models.AcademicYear, error)
 	DeleteAcademicYear(ctx context.Context, id uint) error
-	GetCurrentSemester(ctx context.Context) (*models.AcademicYear, error)
-	GetLatestAbleRegister(ctx context.Context) (*models.AcademicYear, error)
+	// GetCurrentSemester / GetLatestAbleRegister ใช้ปฏิทินของวิทยาเขต ถ้าไม่มีใช้ปฏิทินกลาง (campusID = 0)
+	GetCurrentSemester(ctx context.Context, campusID int) (*models.AcademicYear, error)
+	GetLatestAbleRegister(ctx context.Context, campusID int) (*models.AcademicYear, error)
+	GetCampusCalendar(ctx context.Context, campusID int) (*academicYearDTO.CampusCalendarResponse, error)
+	// GetTermTypes ประเภทภาคการศึกษาของวิทยาเขตเรียงตามลำดับในปี ถ้าไม่ได้กำหนดเองใช้ของปฏิทินกลาง
+	GetTermTypes(ctx context.Context, campusID int) (*academicYearDTO.TermTypeListResponse, error)
+	// UpdateTermTypes แทนที่ประเภทภาคการศึกษาของวิทยาเขต ต้องคงเลขภาคเรียนที่ภาคเรียนเดิมใช้อยู่
+	UpdateTermTypes(ctx context.Context, campusID int, req academicYearDTO.UpdateTermTypesRequest) (*academicYearDTO.TermTypeListResponse, error)
+
+	// ChangeStatus เปลี่ยนสถานะภาคเรียนตามลำดับ upcoming -> open <-> review -> closed -> archived พร้อมรายงานสรุป
+	// ปิดภาคเรียนที่ยังมีฟอร์มค้างได้เมื่อ expire_pending = true (ฟอร์มที่ค้างถูกปิดเป็นสถานะหมดเวลา)
+	ChangeStatus(ctx context.Context, id uint, req academicYearDTO.ChangeStatusRequest, performedBy uint) (*models.AcademicYearTransition, error)
+	// Rollover สร้างภาคเรียนถัดไป (upcoming) จากภาคเรียนล่าสุดของวิทยาเขต พร้อมคัดลอกการตั้งค่า
+	Rollover(ctx context.Context, id uint, req academicYearDTO.RolloverRequest, performedBy uint) (*models.AcademicYear, *models.AcademicYearTransition, error)
+	GetTermSummary(ctx context.Context, id uint) (*academicYearDTO.TermSummaryResponse, error)
+	GetTransitions(ctx context.Context, id uint) ([]models.AcademicYearTransition, error)
+
+	CreateWindow(ctx context.Context, academicYearID uint, req academicYearDTO.SubmissionWindowRequest, updatedBy uint) (*models.SubmissionWindow, error)
+	UpdateWindow(ctx context.Context, windowID uint, req academicYearDTO.SubmissionWindowRequest, updatedBy uint) (*models.SubmissionWindow, error)
+	DeleteWindow(ctx context.Context, windowID uint) error
+	// CheckSubmissionWindow ตรวจว่าส่งฟอร์มได้ในขณะนี้ตามปฏิทินของวิทยาเขต คืนภาคเรียนที่รับฟอร์ม
+	// awardTypeID = nil ผ่านเมื่อมีช่วงเวลาส่งฟอร์มใดเปิดอยู่ (ใช้ตรวจก่อนรับไฟล์เสนอชื่อแบบกลุ่ม)
+	CheckSubmissionWindow(ctx context.Context, campusID int, awardTypeID *uint, now time.Time) (*models.AcademicYear, error)
+	// CheckStageWindow ตรวจช่วงเวลาพิจารณาของขั้น stage ในภาคเรียนของฟอร์ม ไม่ได้กำหนดช่วงเวลา = ไม่จำกัด
+	CheckStageWindow(ctx context.Context, campusID int, year int, semester int, awardTypeID *uint, stage int, now time.Time) error
+}
+
+// WindowClosedError ดำเนินการนอกช่วงเวลาที่เปิดตามปฏิทินของวิทยาเขต
+type WindowClosedError struct {
+	Stage     int
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (e *WindowClosedError) Error() string {
+	period := e.StartDate.Format("2006-01-02") + " ถึง " + e.EndDate.Format("2006-01-02")
+	if e.Stage == models.SubmissionStageSubmit {
+		return "ขณะนี้ไม่อยู่ในช่วงเวลาที่อนุญาตให้ส่งฟอร์ม (" + period + ")"
+	}
+	return "ขณะนี้ไม่อยู่ในช่วงเวลาพิจารณาของขั้นนี้ (" + period + ")"
+}
+
+// ErrAcademicYearClosed ภาคเรียนปิดแล้ว ไม่รับการพิจารณาหรือแก้ไขการตั้งค่าอีก
+var ErrAcademicYearClosed = errors.New("academic year is closed")
+
+// defaultTermTypes ใช้เมื่อยังไม่มี Term_Type ทั้งของวิทยาเขตและปฏิทินกลาง (ภาคเรียนที่ 1 และ 2)
+var defaultTermTypes = []models.TermType{
+	{Semester: 1, Sequence: 1, Name: "ภาคเรียนที่ 1"},
+	{Semester: 2, Sequence: 2, Name: "ภาคเรียนที่ 2"},
+}
+
+const maxTermTypeNameLength = 100
+
+// academicYearTransitions สถานะถัดไปที่เปลี่ยนได้จากแต่ละสถานะ
+var academicYearTransitions = map[string][]string{
+	models.AcademicYearStatusUpcoming: {models.AcademicYearStatusOpen},
+	models.AcademicYearStatusOpen:     {models.AcademicYearStatusReview, models.AcademicYearStatusClosed},
+	models.AcademicYearStatusReview:   {models.AcademicYearStatusOpen, models.AcademicYearStatusClosed},
+	models.AcademicYearStatusClosed:   {models.AcademicYearStatusArchived},
 }
 
 type academicYearService struct {
-	repo repository.AcademicYearRepository
+	repo          repository.AcademicYearRepository
+	awardTypeRepo repository.AwardTypeRepository
 }
 
-func NewAcademicYearService(repo repository.AcademicYearRepository) AcademicYearService {
-	return &academicYearService{repo: repo}
+func NewAcademicYearService(repo repository.AcademicYearRepository, awardTypeRepo repository.AwardTypeRepository) AcademicYearService {
+	return &academicYearService{repo: repo, awardTypeRepo: awardTypeRepo}
 }
 
 func (s *academicYearService) CreateAcademicYear(ctx context.Context, req *academicYearDTO.CreateAcademicYear) (*models.AcademicYear, error) {
@@ -37,38 +97,29 @@ func (s *academicYearService) CreateAcademicYear(ctx context.Context, req *acade
 	if req.EndDate.Before(req.StartDate) {
 		return nil, fmt.Errorf("end_date must be after or equal to start_date")
 	}
+	if req.CampusID < 0 {
+		return nil, fmt.Errorf("invalid campus_id")
+	}
 
-	var nextYear int
-	var nextSemester int
-
-	latestAcademicYear, err := s.repo.GetCurrentSemester(ctx)
+	// ลำดับปี/ภาคการศึกษานับแยกตามปฏิทินของแต่ละวิทยาเขต
+	campusTerms, err := s.repo.GetByCampus(ctx, req.CampusID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			nextYear = time.Now().Year()
-			nextSemester = 1
-		} else {
-			return nil, err
-		}
-	} else {
-		nextYear = latestAcademicYear.Year
-		switch latestAcademicYear.Semester {
-		case 1:
-			nextSemester = 2
-		case 2:
-			nextYear = latestAcademicYear.Year + 1
-			nextSemester = 1
-		default:
-			return nil, fmt.Errorf("invalid latest semester value: %d", latestAcademicYear.Semester)
-		}
+		return nil, err
+	}
+	nextYear, nextSemester, err := s.nextTerm(ctx, req.CampusID, campusTerms, req.Semester)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.validateAcademicYearRules(ctx, nextYear, nextSemester, nil); err != nil {
+	if err := s.validateAcademicYearRules(ctx, req.CampusID, nextYear, nextSemester, nil); err != nil {
 		return nil, err
 	}
 
 	academicYear := &models.AcademicYear{
+		CampusID:  req.CampusID,
 		Year:      nextYear,
 		Semester:  nextSemester,
+		Status:    models.AcademicYearStatusUpcoming,
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
 	}
@@ -88,14 +139,29 @@ func (s *academicYearService) GetAllAcademicYears(ctx context.Context) ([]models
 	return s.repo.GetAll(ctx)
 }
 
+// UpdateAcademicYear ปี/เทอมเปลี่ยนได้เฉพาะภาคเรียนที่ยังไม่เริ่ม (ฟอร์มอ้างอิงปี/เทอม) ภาคเรียนที่ปิดแล้วแก้ไขไม่ได้
 func (s *academicYearService) UpdateAcademicYear(ctx context.Context, id uint, req *academicYearDTO.UpdateAcademicYear) (*models.AcademicYear, error) {
-	academicYear, err := s.repo.GetByID(ctx, id)
+	academicYear, err := s.getAcademicYear(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if isClosedStatus(academicYear.Status) {
+		return nil, ErrAcademicYearClosed
+	}
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, fmt.Errorf("start_date and end_date are required")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, fmt.Errorf("end_date must be after or equal to start_date")
+	}
 
-	if err := s.validateAcademicYearRules(ctx, req.Year, req.Semester, &id); err != nil {
-		return nil, err
+	if req.Year != academicYear.Year || req.Semester != academicYear.Semester {
+		if academicYear.Status != models.AcademicYearStatusUpcoming {
+			return nil, fmt.Errorf("year and semester can only be changed while the term is upcoming")
+		}
+		if err := s.validateAcademicYearRules(ctx, academicYear.CampusID, req.Year, req.Semester, &id); err != nil {
+			return nil, err
+		}
 	}
 
 	academicYear.Year = req.Year
@@ -110,86 +176,741 @@ func (s *academicYearService) UpdateAcademicYear(ctx context.Context, id uint, r
 	return academicYear, nil
 }
 
+// DeleteAcademicYear ลบได้เฉพาะภาคเรียนที่ยังไม่เริ่ม (ยังไม่มีฟอร์ม)
 func (s *academicYearService) DeleteAcademicYear(ctx context.Context, id uint) error {
+	academicYear, err := s.getAcademicYear(ctx, id)
+	if err != nil {
+		return err
+	}
+	if academicYear.Status != models.AcademicYearStatusUpcoming {
+		return fmt.Errorf("only upcoming terms can be deleted")
+	}
 	return s.repo.Delete(ctx, id)
 }
 
-func (s *academicYearService) GetCurrentSemester(ctx context.Context) (*models.AcademicYear, error) {
-	return s.repo.GetCurrentSemester(ctx)
+func (s *academicYearService) ChangeStatus(ctx context.Context, id uint, req academicYearDTO.ChangeStatusRequest, performedBy uint) (*models.AcademicYearTransition, error) {
+	academicYear, err := s.getAcademicYear(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if academicYear.Status == req.Status {
+		return nil, fmt.Errorf("academic year is already %s", req.Status)
+	}
+	allowed := false
+	for _, next := range academicYearTransitions[academicYear.Status] {
+		if next == req.Status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("cannot change academic year status from %s to %s", academicYear.Status, req.Status)
+	}
+
+	// เปิดรับฟอร์มได้ครั้งละ 1 ภาคเรียนต่อปฏิทิน
+	if req.Status == models.AcademicYearStatusOpen {
+		terms, err := s.repo.GetByCampus(ctx, academicYear.CampusID)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range terms {
+			if term.AcademicYearID != academicYear.AcademicYearID && term.Status == models.AcademicYearStatusOpen {
+				return nil, fmt.Errorf("term %d/%d is already open; move it to review first", term.Semester, term.Year)
+			}
+		}
+	}
+
+	expireReason := ""
+	if req.Status == models.AcademicYearStatusClosed {
+		unfinished, err := s.repo.CountUnfinishedForms(ctx, academicYear)
+		if err != nil {
+			return nil, err
+		}
+		if unfinished > 0 {
+			if !req.ExpirePending {
+				return nil, fmt.Errorf("term has %d unfinished forms; resolve them first or set expire_pending to expire them", unfinished)
+			}
+			termTypes, _, err := loadTermTypes(ctx, s.repo, academicYear.CampusID)
+			if err != nil {
+				return nil, err
+			}
+			expireReason = "ปิด" + termLabel(termTypes, academicYear.Year, academicYear.Semester) + " ก่อนพิจารณาเสร็จ"
+		}
+	}
+
+	record := &models.AcademicYearTransition{
+		FromStatus:  academicYear.Status,
+		Note:        strings.TrimSpace(req.Note),
+		PerformedBy: performedBy,
+		PerformedAt: time.Now(),
+	}
+	if err := s.repo.Transition(ctx, academicYear, req.Status, expireReason, record); err != nil {
+		return nil, err
+	}
+	return record, nil
 }
 
-func (s *academicYearService) GetLatestAbleRegister(ctx context.Context) (*models.AcademicYear, error) {
-	return s.repo.GetLatestAbleRegister(ctx)
+func (s *academicYearService) Rollover(ctx context.Context, id uint, req academicYearDTO.RolloverRequest, performedBy uint) (*models.AcademicYear, *models.AcademicYearTransition, error) {
+	source, err := s.getAcademicYear(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source.Status == models.AcademicYearStatusUpcoming {
+		return nil, nil, fmt.Errorf("cannot roll over from a term that has not started")
+	}
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, nil, fmt.Errorf("start_date and end_date are required")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, nil, fmt.Errorf("end_date must be after or equal to start_date")
+	}
+
+	terms, err := s.repo.GetByCampus(ctx, source.CampusID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest := terms[len(terms)-1]; latest.AcademicYearID != source.AcademicYearID {
+		return nil, nil, fmt.Errorf("only the latest term can be rolled over (latest is %d/%d)", latest.Semester, latest.Year)
+	}
+
+	nextYear, nextSemester, err := s.nextTerm(ctx, source.CampusID, terms, req.Semester)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.validateAcademicYearRules(ctx, source.CampusID, nextYear, nextSemester, nil); err != nil {
+		return nil, nil, err
+	}
+
+	next := &models.AcademicYear{
+		CampusID:  source.CampusID,
+		Year:      nextYear,
+		Semester:  nextSemester,
+		Status:    models.AcademicYearStatusUpcoming,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	record := &models.AcademicYearTransition{
+		Note:        strings.TrimSpace(req.Note),
+		PerformedBy: performedBy,
+		PerformedAt: time.Now(),
+	}
+	if err := s.repo.Rollover(ctx, source, next, record); err != nil {
+		return nil, nil, err
+	}
+	return next, record, nil
 }
 
-func (s *academicYearService) validateAcademicYearRules(ctx context.Context, year int, semester int, excludeID *uint) error {
-	if semester != 1 && semester != 2 {
-		return fmt.Errorf("semester must be 1 or 2")
+func (s *academicYearService) GetTermSummary(ctx context.Context, id uint) (*academicYearDTO.TermSummaryResponse, error) {
+	academicYear, err := s.getAcademicYear(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.repo.GetTermSummary(ctx, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := s.repo.GetCommittee(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &academicYearDTO.TermSummaryResponse{
+		AcademicYear: toAcademicYearResponse(academicYear),
+		Summary:      *summary,
+		Committee:    committee,
+	}, nil
+}
+
+func (s *academicYearService) GetTransitions(ctx context.Context, id uint) ([]models.AcademicYearTransition, error) {
+	if _, err := s.getAcademicYear(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTransitions(ctx, id)
+}
+
+func (s *academicYearService) getAcademicYear(ctx context.Context, id uint) (*models.AcademicYear, error) {
+	academicYear, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("academic year not found")
+		}
+		return nil, err
 	}
+	return academicYear, nil
+}
 
-	academicYears, err := s.repo.GetAll(ctx)
+func (s *academicYearService) GetCurrentSemester(ctx context.Context, campusID int) (*models.AcademicYear, error) {
+	return s.repo.GetCurrentSemester(ctx, campusID)
+}
+
+func (s *academicYearService) GetLatestAbleRegister(ctx context.Context, campusID int) (*models.AcademicYear, error) {
+	return s.repo.GetLatestAbleRegister(ctx, campusID)
+}
+
+func (s *academicYearService) GetCampusCalendar(ctx context.Context, campusID int) (*academicYearDTO.CampusCalendarResponse, error) {
+	if campusID < 0 {
+		return nil, errors.New("invalid campus_id")
+	}
+	terms, err := s.repo.GetByCampus(ctx, campusID)
 	if err != nil {
+		return nil, err
+	}
+	calendar := &academicYearDTO.CampusCalendarResponse{CampusID: campusID, Terms: []academicYearDTO.CampusTerm{}}
+	calendarCampusID := campusID
+	if len(terms) == 0 && campusID != 0 {
+		calendar.UsesDefault = true
+		calendarCampusID = 0
+		if terms, err = s.repo.GetByCampus(ctx, 0); err != nil {
+			return nil, err
+		}
+	}
+	if calendar.TermTypes, _, err = loadTermTypes(ctx, s.repo, calendarCampusID); err != nil {
+		return nil, err
+	}
+
+	for _, term := range terms {
+		windows, err := s.repo.GetWindowsByAcademicYear(ctx, term.AcademicYearID)
+		if err != nil {
+			return nil, err
+		}
+		item := academicYearDTO.CampusTerm{
+			AcademicYearResponse: toAcademicYearResponse(&term),
+			TermName:             termName(calendar.TermTypes, term.Semester),
+			Windows:              make([]academicYearDTO.SubmissionWindowResponse, 0, len(windows)),
+		}
+		for i := range windows {
+			item.Windows = append(item.Windows, toSubmissionWindowResponse(&windows[i]))
+		}
+		calendar.Terms = append(calendar.Terms, item)
+	}
+	return calendar, nil
+}
+
+func (s *academicYearService) GetTermTypes(ctx context.Context, campusID int) (*academicYearDTO.TermTypeListResponse, error) {
+	if campusID < 0 {
+		return nil, errors.New("invalid campus_id")
+	}
+	termTypes, usesDefault, err := loadTermTypes(ctx, s.repo, campusID)
+	if err != nil {
+		return nil, err
+	}
+	return &academicYearDTO.TermTypeListResponse{CampusID: campusID, UsesDefault: usesDefault, TermTypes: termTypes}, nil
+}
+
+func (s *academicYearService) UpdateTermTypes(ctx context.Context, campusID int, req academicYearDTO.UpdateTermTypesRequest) (*academicYearDTO.TermTypeListResponse, error) {
+	if campusID < 0 {
+		return nil, errors.New("invalid campus_id")
+	}
+	if len(req.TermTypes) == 0 && campusID == 0 {
+		return nil, errors.New("term_types is required for the default calendar")
+	}
+
+	termTypes := make([]models.TermType, 0, len(req.TermTypes))
+	seen := make(map[int]bool, len(req.TermTypes))
+	hasRequired := false
+	for i, item := range req.TermTypes {
+		name := strings.TrimSpace(item.Name)
+		if item.Semester < 1 {
+			return nil, errors.New("semester must be a positive number")
+		}
+		if name == "" {
+			return nil, fmt.Errorf("name is required for semester %d", item.Semester)
+		}
+		if len([]rune(name)) > maxTermTypeNameLength {
+			return nil, fmt.Errorf("name must not exceed %d characters", maxTermTypeNameLength)
+		}
+		if seen[item.Semester] {
+			return nil, fmt.Errorf("semester %d must not be repeated", item.Semester)
+		}
+		seen[item.Semester] = true
+		hasRequired = hasRequired || !item.IsOptional
+		termTypes = append(termTypes, models.TermType{
+			CampusID:   campusID,
+			Semester:   item.Semester,
+			Sequence:   i + 1,
+			Name:       name,
+			IsOptional: item.IsOptional,
+		})
+	}
+	if len(termTypes) > 0 && !hasRequired {
+		return nil, errors.New("term_types must include at least one required term (is_optional = false)")
+	}
+
+	// ภาคเรียนและฟอร์มเดิมอ้างอิงเลขภาคเรียน จึงลบประเภทที่ยังถูกใช้อยู่ไม่ได้
+	effective := termTypes
+	if len(effective) == 0 {
+		var err error
+		if effective, _, err = loadTermTypes(ctx, s.repo, 0); err != nil {
+			return nil, err
+		}
+	}
+	used, err := s.usedSemesters(ctx, campusID)
+	if err != nil {
+		return nil, err
+	}
+	for _, semester := range used {
+		if findTermType(effective, semester) == nil {
+			return nil, fmt.Errorf("cannot remove semester %d because existing terms use it", semester)
+		}
+	}
+
+	if err := s.repo.ReplaceTermTypes(ctx, campusID, termTypes); err != nil {
+		return nil, err
+	}
+	return s.GetTermTypes(ctx, campusID)
+}
+
+// usedSemesters เลขภาคเรียนของภาคเรียนที่ใช้ประเภทภาคการศึกษาของ campusID
+// ปฏิทินกลางรวมภาคเรียนของวิทยาเขตที่ยังไม่ได้กำหนดประเภทเอง
+func (s *academicYearService) usedSemesters(ctx context.Context, campusID int) ([]int, error) {
+	terms, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ownTypes := map[int]bool{}
+	seen := map[int]bool{}
+	var used []int
+	for _, term := range terms {
+		if term.CampusID != campusID {
+			if campusID != 0 {
+				continue
+			}
+			has, ok := ownTypes[term.CampusID]
+			if !ok {
+				campusTypes, err := s.repo.GetTermTypes(ctx, term.CampusID)
+				if err != nil {
+					return nil, err
+				}
+				has = len(campusTypes) > 0
+				ownTypes[term.CampusID] = has
+			}
+			if has {
+				continue
+			}
+		}
+		if !seen[term.Semester] {
+			seen[term.Semester] = true
+			used = append(used, term.Semester)
+		}
+	}
+	return used, nil
+}
+
+func (s *academicYearService) CreateWindow(ctx context.Context, academicYearID uint, req academicYearDTO.SubmissionWindowRequest, updatedBy uint) (*models.SubmissionWindow, error) {
+	if err := s.ensureTermEditable(ctx, academicYearID); err != nil {
+		return nil, err
+	}
+
+	window := &models.SubmissionWindow{AcademicYearID: academicYearID}
+	if err := s.applyWindow(ctx, window, req, updatedBy); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateWindow(ctx, window); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+func (s *academicYearService) UpdateWindow(ctx context.Context, windowID uint, req academicYearDTO.SubmissionWindowRequest, updatedBy uint) (*models.SubmissionWindow, error) {
+	window, err := s.getWindow(ctx, windowID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureTermEditable(ctx, window.AcademicYearID); err != nil {
+		return nil, err
+	}
+	if err := s.applyWindow(ctx, window, req, updatedBy); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateWindow(ctx, window); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+func (s *academicYearService) DeleteWindow(ctx context.Context, windowID uint) error {
+	window, err := s.getWindow(ctx, windowID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureTermEditable(ctx, window.AcademicYearID); err != nil {
 		return err
 	}
+	return s.repo.DeleteWindow(ctx, windowID)
+}
 
-	var hasSem1 bool
-	var hasSem2 bool
-	var maxYear int
-	var maxSemester int
-	for _, ay := range academicYears {
-		if excludeID != nil && ay.AcademicYearID == *excludeID {
-			continue
+func (s *academicYearService) CheckSubmissionWindow(ctx context.Context, campusID int, awardTypeID *uint, now time.Time) (*models.AcademicYear, error) {
+	term, err := s.repo.GetLatestAbleRegister(ctx, campusID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no open registration period found")
 		}
+		return nil, err
+	}
+	windows, err := s.repo.GetWindowsByAcademicYear(ctx, term.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
 
-		if ay.Year == year {
-			if ay.Semester == 1 {
-				hasSem1 = true
+	// ไม่ได้กำหนดช่วงเวลาส่งฟอร์มไว้ ใช้วันเริ่ม/สิ้นสุดของภาคเรียน
+	closed := &WindowClosedError{Stage: models.SubmissionStageSubmit, StartDate: term.StartDate, EndDate: term.EndDate}
+	if awardTypeID == nil {
+		hasWindow := false
+		for _, window := range windows {
+			if window.Stage != models.SubmissionStageSubmit {
+				continue
+			}
+			hasWindow = true
+			if withinDateRange(window.StartDate, window.EndDate, now) {
+				return term, nil
 			}
-			if ay.Semester == 2 {
-				hasSem2 = true
+			if window.AwardTypeID == nil {
+				closed.StartDate, closed.EndDate = window.StartDate, window.EndDate
 			}
 		}
+		if !hasWindow && withinDateRange(term.StartDate, term.EndDate, now) {
+			return term, nil
+		}
+		return nil, closed
+	}
+
+	if window := pickWindow(windows, models.SubmissionStageSubmit, awardTypeID); window != nil {
+		closed.StartDate, closed.EndDate = window.StartDate, window.EndDate
+	}
+	if !withinDateRange(closed.StartDate, closed.EndDate, now) {
+		return nil, closed
+	}
+	return term, nil
+}
+
+func (s *academicYearService) CheckStageWindow(ctx context.Context, campusID int, year int, semester int, awardTypeID *uint, stage int, now time.Time) error {
+	term, err := s.repo.GetTerm(ctx, campusID, year, semester)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if isClosedStatus(term.Status) {
+		return ErrAcademicYearClosed
+	}
+	windows, err := s.repo.GetWindowsByAcademicYear(ctx, term.AcademicYearID)
+	if err != nil {
+		return err
+	}
+	window := pickWindow(windows, stage, awardTypeID)
+	if window == nil || withinDateRange(window.StartDate, window.EndDate, now) {
+		return nil
+	}
+	return &WindowClosedError{Stage: stage, StartDate: window.StartDate, EndDate: window.EndDate}
+}
+
+func (s *academicYearService) ensureTermEditable(ctx context.Context, academicYearID uint) error {
+	academicYear, err := s.getAcademicYear(ctx, academicYearID)
+	if err != nil {
+		return err
+	}
+	if isClosedStatus(academicYear.Status) {
+		return ErrAcademicYearClosed
+	}
+	return nil
+}
+
+func (s *academicYearService) getWindow(ctx context.Context, windowID uint) (*models.SubmissionWindow, error) {
+	window, err := s.repo.GetWindowByID(ctx, windowID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("submission window not found")
+		}
+		return nil, err
+	}
+	return window, nil
+}
+
+// applyWindow ตรวจและกำหนดค่าจาก request ช่วงเวลาของขั้นและประเภทรางวัลเดียวกันมีได้ 1 ช่วงต่อภาคเรียน
+func (s *academicYearService) applyWindow(ctx context.Context, window *models.SubmissionWindow, req academicYearDTO.SubmissionWindowRequest, updatedBy uint) error {
+	if req.Stage != models.SubmissionStageSubmit && (req.Stage < 2 || req.Stage > 7) {
+		return errors.New("stage must be 0 (submission) or an approver role between 2 and 7")
+	}
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return errors.New("start_date and end_date are required")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return errors.New("end_date must be after or equal to start_date")
+	}
+	if req.AwardTypeID != nil {
+		if _, err := s.awardTypeRepo.GetByID(ctx, *req.AwardTypeID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("award type not found")
+			}
+			return err
+		}
+	}
+
+	existing, err := s.repo.GetWindowsByAcademicYear(ctx, window.AcademicYearID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.WindowID != window.WindowID && other.Stage == req.Stage && sameAwardType(other.AwardTypeID, req.AwardTypeID) {
+			return errors.New("a window for this stage and award type already exists in this term")
+		}
+	}
+
+	window.AwardTypeID = req.AwardTypeID
+	window.Stage = req.Stage
+	window.StartDate = req.StartDate
+	window.EndDate = req.EndDate
+	window.UpdatedBy = &updatedBy
+	window.UpdatedAt = time.Now()
+	return nil
+}
+
+// nextTerm ปี/ภาคการศึกษาถัดจากภาคเรียนล่าสุดของ terms (เรียงตามลำดับแล้ว) ตามประเภทภาคการศึกษาของวิทยาเขต
+// semester = 0 เลือกภาคถัดไปที่ข้ามไม่ได้ ไม่มีภาคเรียนเลยเริ่มที่ปีปัจจุบัน
+func (s *academicYearService) nextTerm(ctx context.Context, campusID int, terms []models.AcademicYear, semester int) (int, int, error) {
+	termTypes, _, err := loadTermTypes(ctx, s.repo, campusID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var candidates []termKey
+	if len(terms) == 0 {
+		candidates = yearStartCandidates(termTypes, time.Now().Year())
+	} else if candidates, err = nextTermCandidates(termTypes, &terms[len(terms)-1]); err != nil {
+		return 0, 0, err
+	}
+
+	if semester == 0 {
+		next := candidates[len(candidates)-1]
+		return next.Year, next.Semester, nil
+	}
+	for _, candidate := range candidates {
+		if candidate.Semester == semester {
+			return candidate.Year, candidate.Semester, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("semester must be the next term: %s", describeTerms(termTypes, candidates))
+}
 
-		if ay.Year > maxYear || (ay.Year == maxYear && ay.Semester > maxSemester) {
-			maxYear = ay.Year
-			maxSemester = ay.Semester
+func isClosedStatus(status string) bool {
+	return status == models.AcademicYearStatusClosed || status == models.AcademicYearStatusArchived
+}
+
+// pickWindow ช่วงเวลาของประเภทรางวัลมีผลก่อนช่วงเวลาที่ใช้กับทุกประเภท
+func pickWindow(windows []models.SubmissionWindow, stage int, awardTypeID *uint) *models.SubmissionWindow {
+	var generic *models.SubmissionWindow
+	for i := range windows {
+		if windows[i].Stage != stage {
+			continue
 		}
+		if windows[i].AwardTypeID == nil {
+			generic = &windows[i]
+			continue
+		}
+		if awardTypeID != nil && *windows[i].AwardTypeID == *awardTypeID {
+			return &windows[i]
+		}
+	}
+	return generic
+}
+
+func sameAwardType(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
+	return *a == *b
+}
+
+// withinDateRange นับทั้งวันเริ่มและวันสิ้นสุด (00:00:00 - 23:59:59 ตามเวลาท้องถิ่น)
+func withinDateRange(start, end, now time.Time) bool {
+	startOfDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, now.Location())
+	endOfDay := time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), now.Location())
+	return !now.Before(startOfDay) && !now.After(endOfDay)
+}
 
-	if semester == 1 && hasSem1 {
-		return fmt.Errorf("semester 1 already exists for year %d", year)
+func toAcademicYearResponse(academicYear *models.AcademicYear) academicYearDTO.AcademicYearResponse {
+	return academicYearDTO.AcademicYearResponse{
+		AcademicYearID: academicYear.AcademicYearID,
+		CampusID:       academicYear.CampusID,
+		Year:           academicYear.Year,
+		Semester:       academicYear.Semester,
+		Status:         academicYear.Status,
+		StartDate:      academicYear.StartDate,
+		EndDate:        academicYear.EndDate,
 	}
-	if semester == 2 && hasSem2 {
-		return fmt.Errorf("semester 2 already exists for year %d", year)
+}
+
+func toSubmissionWindowResponse(window *models.SubmissionWindow) academicYearDTO.SubmissionWindowResponse {
+	return academicYearDTO.SubmissionWindowResponse{
+		WindowID:       window.WindowID,
+		AcademicYearID: window.AcademicYearID,
+		AwardTypeID:    window.AwardTypeID,
+		Stage:          window.Stage,
+		StartDate:      window.StartDate,
+		EndDate:        window.EndDate,
 	}
-	if semester == 1 && hasSem2 {
-		return fmt.Errorf("cannot create semester 1 because semester 2 already exists for year %d", year)
+}
+
+// validateAcademicYearRules ภาคเรียนต้องเป็นประเภทที่วิทยาเขตกำหนด ไม่ซ้ำ และต่อจากภาคเรียนล่าสุดตามลำดับ
+// (ข้ามได้เฉพาะภาคการศึกษาที่ is_optional) ภาคเรียนแรกของปฏิทินต้องเริ่มต้นปี
+func (s *academicYearService) validateAcademicYearRules(ctx context.Context, campusID int, year int, semester int, excludeID *uint) error {
+	termTypes, _, err := loadTermTypes(ctx, s.repo, campusID)
+	if err != nil {
+		return err
 	}
-	if semester == 2 && !hasSem1 {
-		return fmt.Errorf("semester 2 requires semester 1 for year %d", year)
+	if findTermType(termTypes, semester) == nil {
+		semesters := make([]string, 0, len(termTypes))
+		for _, termType := range termTypes {
+			semesters = append(semesters, fmt.Sprintf("%d (%s)", termType.Semester, termType.Name))
+		}
+		return fmt.Errorf("semester must be one of %s", strings.Join(semesters, ", "))
+	}
+
+	academicYears, err := s.repo.GetByCampus(ctx, campusID)
+	if err != nil {
+		return err
+	}
+
+	var latest *models.AcademicYear
+	for i, ay := range academicYears {
+		if excludeID != nil && ay.AcademicYearID == *excludeID {
+			continue
+		}
+		if ay.Year == year && ay.Semester == semester {
+			return fmt.Errorf("%s already exists", termLabel(termTypes, year, semester))
+		}
+		latest = &academicYears[i]
 	}
 
-	if len(academicYears) == 0 {
+	if latest == nil {
+		candidates := yearStartCandidates(termTypes, year)
+		if !containsTerm(candidates, year, semester) {
+			return fmt.Errorf("new year must start with %s", describeTerms(termTypes, candidates))
+		}
 		return nil
 	}
 
-	if year < maxYear {
-		return fmt.Errorf("year must be %d or newer", maxYear)
+	candidates, err := nextTermCandidates(termTypes, latest)
+	if err != nil {
+		return err
+	}
+	if !containsTerm(candidates, year, semester) {
+		return fmt.Errorf("next term after %s must be %s", termLabel(termTypes, latest.Year, latest.Semester), describeTerms(termTypes, candidates))
+	}
+	return nil
+}
+
+// termKey ปี/ภาคการศึกษาที่ยังไม่มีในปฏิทิน
+type termKey struct {
+	Year     int
+	Semester int
+}
+
+// loadTermTypes ประเภทภาคการศึกษาของวิทยาเขต -> ของปฏิทินกลาง -> ภาคเรียนที่ 1, 2 ตามเดิม
+// usesDefault = true เมื่อวิทยาเขตยังไม่ได้กำหนดเอง
+func loadTermTypes(ctx context.Context, repo repository.AcademicYearRepository, campusID int) ([]models.TermType, bool, error) {
+	termTypes, err := repo.GetTermTypes(ctx, campusID)
+	if err != nil {
+		return nil, false, err
 	}
-	if year == maxYear {
-		if maxSemester == 1 && semester != 2 {
-			return fmt.Errorf("next semester must be 2 for year %d", year)
+	if len(termTypes) > 0 {
+		return termTypes, false, nil
+	}
+	if campusID != 0 {
+		if termTypes, err = repo.GetTermTypes(ctx, 0); err != nil {
+			return nil, false, err
+		}
+		if len(termTypes) > 0 {
+			return termTypes, true, nil
 		}
-		if maxSemester == 2 {
-			return fmt.Errorf("year %d already has semester 1 and 2; next semester must be year %d semester 1", year, year+1)
+	}
+	return append([]models.TermType(nil), defaultTermTypes...), campusID != 0, nil
+}
+
+// yearStartCandidates ภาคการศึกษาที่เริ่มปี year ได้: ตั้งแต่ภาคแรกจนถึงภาคแรกที่ข้ามไม่ได้
+func yearStartCandidates(termTypes []models.TermType, year int) []termKey {
+	var candidates []termKey
+	for _, termType := range termTypes {
+		candidates = append(candidates, termKey{Year: year, Semester: termType.Semester})
+		if !termType.IsOptional {
+			break
 		}
 	}
-	if year == maxYear+1 && semester != 1 {
-		return fmt.Errorf("new year must start with semester 1")
+	return candidates
+}
+
+// nextTermCandidates ภาคการศึกษาที่ต่อจาก latest ได้ เรียงตามลำดับ ภาคสุดท้ายในรายการคือภาคที่ข้ามไม่ได้
+// ถ้าปีของ latest ไม่มีภาคที่ข้ามไม่ได้เหลือ ต่อด้วยภาคที่เริ่มปีถัดไป
+func nextTermCandidates(termTypes []models.TermType, latest *models.AcademicYear) ([]termKey, error) {
+	position := -1
+	for i, termType := range termTypes {
+		if termType.Semester == latest.Semester {
+			position = i
+			break
+		}
 	}
-	if year > maxYear+1 {
-		return fmt.Errorf("year must be %d or %d", maxYear, maxYear+1)
+	if position < 0 {
+		return nil, fmt.Errorf("invalid latest semester value: %d", latest.Semester)
 	}
 
+	var candidates []termKey
+	for _, termType := range termTypes[position+1:] {
+		candidates = append(candidates, termKey{Year: latest.Year, Semester: termType.Semester})
+		if !termType.IsOptional {
+			return candidates, nil
+		}
+	}
+	return append(candidates, yearStartCandidates(termTypes, latest.Year+1)...), nil
+}
+
+func containsTerm(candidates []termKey, year int, semester int) bool {
+	for _, candidate := range candidates {
+		if candidate.Year == year && candidate.Semester == semester {
+			return true
+		}
+	}
+	return false
+}
+
+func describeTerms(termTypes []models.TermType, terms []termKey) string {
+	labels := make([]string, 0, len(terms))
+	for _, term := range terms {
+		labels = append(labels, fmt.Sprintf("%s (semester %d)", termLabel(termTypes, term.Year, term.Semester), term.Semester))
+	}
+	return strings.Join(labels, " or ")
+}
+
+func findTermType(termTypes []models.TermType, semester int) *models.TermType {
+	for i := range termTypes {
+		if termTypes[i].Semester == semester {
+			return &termTypes[i]
+		}
+	}
 	return nil
 }
+
+// sortSemestersByTerm เรียงเลขภาคเรียนตามลำดับในปีจากมากไปน้อย เลขที่ไม่อยู่ในรายการไว้ท้ายสุด
+func sortSemestersByTerm(termTypes []models.TermType, semesters []int) {
+	sequence := func(semester int) int {
+		if termType := findTermType(termTypes, semester); termType != nil {
+			return termType.Sequence
+		}
+		return 0
+	}
+	sort.SliceStable(semesters, func(i, j int) bool {
+		return sequence(semesters[i]) > sequence(semesters[j])
+	})
+}
+
+// termName ชื่อประเภทภาคการศึกษา ถ้าไม่อยู่ในรายการใช้ "ภาคเรียนที่ n"
+func termName(termTypes []models.TermType, semester int) string {
+	if termType := findTermType(termTypes, semester); termType != nil {
+		return termType.Name
+	}
+	return fmt.Sprintf("ภาคเรียนที่ %d", semester)
+}
+
+// termLabel เช่น "ภาคฤดูร้อน ปีการศึกษา 2567"
+func termLabel(termTypes []models.TermType, year int, semester int) string {
+	return fmt.Sprintf("%s ปีการศึกษา %d", termName(termTypes, semester), year)
+}
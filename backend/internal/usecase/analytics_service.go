@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	analyticsdto "backend/internal/dto/analytics_dto"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSubmissionsGroupBy   = "month"
+	defaultApprovalRatesGroupBy = "faculty"
+	defaultRejectionReasonLimit = 10
+	maxRejectionReasonLimit     = 50
+)
+
+type AnalyticsService interface {
+	GetSummary(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.SummaryResponse, error)
+	GetSubmissions(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.ChartResponse, error)
+	GetApprovalRates(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.ChartResponse, error)
+	GetRejectionReasons(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.RejectionReasonsResponse, error)
+	GetStepDurations(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.StepDurationsResponse, error)
+}
+
+type analyticsCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type analyticsService struct {
+	repo repository.AnalyticsRepository
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]analyticsCacheEntry
+}
+
+// NewAnalyticsService ttl คือระยะเวลาที่เก็บผลไว้ใน cache (0 = ไม่ cache)
+func NewAnalyticsService(repo repository.AnalyticsRepository, ttl time.Duration) AnalyticsService {
+	return &analyticsService{repo: repo, ttl: ttl, cache: make(map[string]analyticsCacheEntry)}
+}
+
+func (s *analyticsService) GetSummary(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.SummaryResponse, error) {
+	value, err := s.cached("summary", query, func() (interface{}, error) {
+		totals, err := s.repo.GetTotals(ctx, toAnalyticsFilter(query))
+		if err != nil {
+			return nil, err
+		}
+		approvalRate, rejectionRate := decisionRates(totals.Approved, totals.Rejected)
+		return &analyticsdto.SummaryResponse{
+			GeneratedAt:   time.Now(),
+			Total:         totals.Total,
+			Approved:      totals.Approved,
+			Rejected:      totals.Rejected,
+			Pending:       totals.Pending,
+			ApprovalRate:  approvalRate,
+			RejectionRate: rejectionRate,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*analyticsdto.SummaryResponse), nil
+}
+
+// GetSubmissions จำนวนฟอร์มที่ส่งแยกตามมิติ group_by (ค่าเริ่มต้นรายเดือน) พร้อมผลการพิจารณา
+func (s *analyticsService) GetSubmissions(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.ChartResponse, error) {
+	groupBy, err := normalizeAnalyticsGroupBy(query.GroupBy, defaultSubmissionsGroupBy)
+	if err != nil {
+		return nil, err
+	}
+	query.GroupBy = groupBy
+
+	value, err := s.cached("submissions", query, func() (interface{}, error) {
+		rows, err := s.repo.GetGroupedCounts(ctx, toAnalyticsFilter(query), groupBy)
+		if err != nil {
+			return nil, err
+		}
+
+		chart := newChartResponse(groupBy, len(rows), "total", "approved", "rejected", "pending")
+		for _, row := range rows {
+			chart.Keys = append(chart.Keys, row.Key)
+			chart.Labels = append(chart.Labels, row.Label)
+			chart.Series[0].Data = append(chart.Series[0].Data, float64(row.Total))
+			chart.Series[1].Data = append(chart.Series[1].Data, float64(row.Approved))
+			chart.Series[2].Data = append(chart.Series[2].Data, float64(row.Rejected))
+			chart.Series[3].Data = append(chart.Series[3].Data, float64(row.Pending))
+		}
+		return chart, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*analyticsdto.ChartResponse), nil
+}
+
+// GetApprovalRates อัตราอนุมัติ/ไม่อนุมัติ (ร้อยละ) แยกตามมิติ group_by (ค่าเริ่มต้นรายคณะ)
+func (s *analyticsService) GetApprovalRates(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.ChartResponse, error) {
+	groupBy, err := normalizeAnalyticsGroupBy(query.GroupBy, defaultApprovalRatesGroupBy)
+	if err != nil {
+		return nil, err
+	}
+	query.GroupBy = groupBy
+
+	value, err := s.cached("approval-rates", query, func() (interface{}, error) {
+		rows, err := s.repo.GetGroupedCounts(ctx, toAnalyticsFilter(query), groupBy)
+		if err != nil {
+			return nil, err
+		}
+
+		chart := newChartResponse(groupBy, len(rows), "approval_rate", "rejection_rate", "decided")
+		for _, row := range rows {
+			approvalRate, rejectionRate := decisionRates(row.Approved, row.Rejected)
+			chart.Keys = append(chart.Keys, row.Key)
+			chart.Labels = append(chart.Labels, row.Label)
+			chart.Series[0].Data = append(chart.Series[0].Data, approvalRate)
+			chart.Series[1].Data = append(chart.Series[1].Data, rejectionRate)
+			chart.Series[2].Data = append(chart.Series[2].Data, float64(row.Approved+row.Rejected))
+		}
+		return chart, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*analyticsdto.ChartResponse), nil
+}
+
+func (s *analyticsService) GetRejectionReasons(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.RejectionReasonsResponse, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultRejectionReasonLimit
+	}
+	if query.Limit > maxRejectionReasonLimit {
+		query.Limit = maxRejectionReasonLimit
+	}
+	query.GroupBy = ""
+
+	value, err := s.cached("rejection-reasons", query, func() (interface{}, error) {
+		rows, err := s.repo.GetRejectionReasons(ctx, toAnalyticsFilter(query), query.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		var total int64
+		for _, row := range rows {
+			total += row.Count
+		}
+
+		response := &analyticsdto.RejectionReasonsResponse{
+			ChartResponse: *newChartResponse("", len(rows), "count"),
+			Items:         make([]analyticsdto.RejectionReason, 0, len(rows)),
+		}
+		for _, row := range rows {
+			response.Keys = append(response.Keys, fmt.Sprintf("%d:%s", row.FormStatusID, row.Reason))
+			response.Labels = append(response.Labels, row.Reason)
+			response.Series[0].Data = append(response.Series[0].Data, float64(row.Count))
+			response.Items = append(response.Items, analyticsdto.RejectionReason{
+				Reason:         row.Reason,
+				FormStatusID:   row.FormStatusID,
+				FormStatusName: row.FormStatusName,
+				Count:          row.Count,
+				Percent:        percent(row.Count, total),
+			})
+		}
+		return response, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*analyticsdto.RejectionReasonsResponse), nil
+}
+
+// GetStepDurations ระยะเวลาที่ฟอร์มอยู่ในแต่ละขั้น (ชั่วโมง) นับเฉพาะการเปลี่ยนสถานะที่บันทึกใน Award_Status_Log
+func (s *analyticsService) GetStepDurations(ctx context.Context, query analyticsdto.AnalyticsQuery) (*analyticsdto.StepDurationsResponse, error) {
+	query.GroupBy = ""
+	query.Limit = 0
+
+	value, err := s.cached("step-durations", query, func() (interface{}, error) {
+		rows, err := s.repo.GetStepDurations(ctx, toAnalyticsFilter(query))
+		if err != nil {
+			return nil, err
+		}
+
+		response := &analyticsdto.StepDurationsResponse{
+			ChartResponse: *newChartResponse("status", len(rows), "avg_hours", "median_hours"),
+			Items:         make([]analyticsdto.StepDuration, 0, len(rows)),
+		}
+		for _, row := range rows {
+			item := analyticsdto.StepDuration{
+				FormStatusID: row.FormStatusID,
+				StepName:     row.FormStatusName,
+				Transitions:  row.Transitions,
+				AvgHours:     round2(row.AvgHours),
+				MedianHours:  round2(row.MedianHours),
+				MaxHours:     round2(row.MaxHours),
+			}
+			response.Keys = append(response.Keys, fmt.Sprint(row.FormStatusID))
+			response.Labels = append(response.Labels, row.FormStatusName)
+			response.Series[0].Data = append(response.Series[0].Data, item.AvgHours)
+			response.Series[1].Data = append(response.Series[1].Data, item.MedianHours)
+			response.Items = append(response.Items, item)
+		}
+		return response, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*analyticsdto.StepDurationsResponse), nil
+}
+
+// cached คืนค่าจาก cache ถ้ายังไม่หมดอายุ ไม่เช่นนั้นเรียก load แล้วเก็บผลไว้ (query.Refresh = true บังคับโหลดใหม่)
+func (s *analyticsService) cached(name string, query analyticsdto.AnalyticsQuery, load func() (interface{}, error)) (interface{}, error) {
+	refresh := query.Refresh
+	query.Refresh = false
+	key := fmt.Sprintf("%s|%+v", name, query)
+	now := time.Now()
+
+	if s.ttl > 0 && !refresh {
+		s.mu.Lock()
+		entry, ok := s.cache[key]
+		s.mu.Unlock()
+		if ok && now.Before(entry.expiresAt) {
+			return entry.value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if s.ttl <= 0 {
+		return value, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = analyticsCacheEntry{value: value, expiresAt: now.Add(s.ttl)}
+	return value, nil
+}
+
+func toAnalyticsFilter(query analyticsdto.AnalyticsQuery) repository.AnalyticsFilter {
+	return repository.AnalyticsFilter{
+		CampusID:     query.CampusID,
+		FacultyID:    query.FacultyID,
+		DepartmentID: query.DepartmentID,
+		AwardType:    strings.TrimSpace(query.AwardType),
+		AcademicYear: query.AcademicYear,
+		Semester:     query.Semester,
+	}
+}
+
+func normalizeAnalyticsGroupBy(groupBy string, fallback string) (string, error) {
+	groupBy = strings.ToLower(strings.TrimSpace(groupBy))
+	if groupBy == "" {
+		return fallback, nil
+	}
+	if !repository.IsAnalyticsGroupBy(groupBy) {
+		return "", errors.New("group_by must be one of status, award_type, campus, faculty, department, academic_year, semester, month, student_year")
+	}
+	return groupBy, nil
+}
+
+func newChartResponse(groupBy string, size int, seriesNames ...string) *analyticsdto.ChartResponse {
+	chart := &analyticsdto.ChartResponse{
+		GeneratedAt: time.Now(),
+		GroupBy:     groupBy,
+		Keys:        make([]string, 0, size),
+		Labels:      make([]string, 0, size),
+		Series:      make([]analyticsdto.ChartSeries, 0, len(seriesNames)),
+	}
+	for _, name := range seriesNames {
+		chart.Series = append(chart.Series, analyticsdto.ChartSeries{Name: name, Data: make([]float64, 0, size)})
+	}
+	return chart
+}
+
+// decisionRates ร้อยละอนุมัติ/ไม่อนุมัติ เทียบกับฟอร์มที่พิจารณาเสร็จแล้ว
+func decisionRates(approved, rejected int64) (float64, float64) {
+	decided := approved + rejected
+	return percent(approved, decided), percent(rejected, decided)
+}
+
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(float64(part) * 100 / float64(total))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
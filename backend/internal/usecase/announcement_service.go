@@ -0,0 +1,586 @@
+package usecase
+
+import (
+	announcementdto "backend/internal/dto/announcement_dto"
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnnouncementService interface {
+	Create(ctx context.Context, user *models.User, req announcementdto.CreateAnnouncementRequest) (*announcementdto.AnnouncementResponse, error)
+	GetList(ctx context.Context, campusID int, status string, page, limit int) (*announcementdto.PaginatedAnnouncementResponse, error)
+	GetByID(ctx context.Context, user *models.User, announcementID uint) (*announcementdto.AnnouncementResponse, error)
+	UpdateDraft(ctx context.Context, user *models.User, announcementID uint, req announcementdto.UpdateDraftRequest) (*announcementdto.AnnouncementResponse, error)
+	Delete(ctx context.Context, user *models.User, announcementID uint) error
+	// Preview แสดงเนื้อหาของฉบับ version (0 = ฉบับร่างถ้ามี ไม่เช่นนั้นฉบับที่เผยแพร่)
+	Preview(ctx context.Context, user *models.User, announcementID uint, version int) (*announcementdto.AnnouncementPreviewResponse, error)
+	// Publish เผยแพร่ฉบับร่างทันที หรือตั้งเวลาเผยแพร่ผ่านคิวงาน
+	Publish(ctx context.Context, user *models.User, announcementID uint, req announcementdto.PublishAnnouncementRequest) (*announcementdto.AnnouncementResponse, error)
+	Unschedule(ctx context.Context, user *models.User, announcementID uint) (*announcementdto.AnnouncementResponse, error)
+	// PublishScheduled เรียกจากงาน announcement.publish เมื่อถึงเวลาที่ตั้งไว้
+	PublishScheduled(ctx context.Context, versionID uint) error
+	// Amend สร้างฉบับแก้ไข (คัดลอกรายการจากฉบับที่เผยแพร่) ฉบับเดิมยังแสดงอยู่จนกว่าฉบับแก้ไขจะเผยแพร่
+	Amend(ctx context.Context, user *models.User, announcementID uint, req announcementdto.AmendAnnouncementRequest) (*announcementdto.AnnouncementResponse, error)
+	Retract(ctx context.Context, user *models.User, announcementID uint, req announcementdto.RetractAnnouncementRequest) (*announcementdto.AnnouncementResponse, error)
+}
+
+type announcementService struct {
+	repo             repository.AnnouncementRepository
+	academicYearRepo repository.AcademicYearRepository
+	jobs             JobService
+}
+
+func NewAnnouncementService(repo repository.AnnouncementRepository, academicYearRepo repository.AcademicYearRepository, jobs JobService) AnnouncementService {
+	return &announcementService{repo: repo, academicYearRepo: academicYearRepo, jobs: jobs}
+}
+
+func (s *announcementService) Create(ctx context.Context, user *models.User, req announcementdto.CreateAnnouncementRequest) (*announcementdto.AnnouncementResponse, error) {
+	if user.CampusID == 0 {
+		return nil, errors.New("invalid campus id")
+	}
+	// ไม่ระบุปี/เทอม ใช้ภาคเรียนปัจจุบันตามปฏิทินของวิทยาเขตผู้สร้าง
+	if req.AcademicYear == 0 && req.Semester == 0 {
+		current, err := s.academicYearRepo.GetCurrentSemester(ctx, user.CampusID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+		} else {
+			req.AcademicYear, req.Semester = current.Year, current.Semester
+		}
+	}
+	if req.AcademicYear <= 0 || req.Semester <= 0 {
+		return nil, errors.New("academic_year and semester are required")
+	}
+	termTypes, _, err := loadTermTypes(ctx, s.academicYearRepo, user.CampusID)
+	if err != nil {
+		return nil, err
+	}
+	if findTermType(termTypes, req.Semester) == nil {
+		return nil, fmt.Errorf("invalid semester: semester %d is not a term of this campus calendar", req.Semester)
+	}
+
+	if _, err := s.repo.GetByTerm(ctx, user.CampusID, req.AcademicYear, req.Semester); err == nil {
+		return nil, errors.New("an announcement for this campus and term already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	items, err := s.buildItems(ctx, user.CampusID, req.AcademicYear, req.Semester, req.FormIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = "ประกาศผลรางวัลนิสิตดีเด่น " + termLabel(termTypes, req.AcademicYear, req.Semester)
+	}
+
+	now := time.Now()
+	announcement := &models.Announcement{
+		CampusID:     user.CampusID,
+		AcademicYear: req.AcademicYear,
+		Semester:     req.Semester,
+		Title:        title,
+		Status:       models.AnnouncementStatusDraft,
+		CreatedBy:    user.UserID,
+		UpdatedBy:    user.UserID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	version := &models.AnnouncementVersion{
+		Version:   1,
+		Status:    models.AnnouncementVersionDraft,
+		CreatedBy: user.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, announcement, version, items); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) GetList(ctx context.Context, campusID int, status string, page, limit int) (*announcementdto.PaginatedAnnouncementResponse, error) {
+	announcements, total, err := s.repo.GetList(ctx, campusID, strings.TrimSpace(status), limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]announcementdto.AnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		data = append(data, mapToAnnouncementResponse(&announcements[i], nil))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+	return &announcementdto.PaginatedAnnouncementResponse{
+		Data: data,
+		Pagination: awardformdto.PaginationMeta{
+			CurrentPage: page,
+			TotalPages:  totalPages,
+			TotalItems:  total,
+			Limit:       limit,
+		},
+	}, nil
+}
+
+func (s *announcementService) GetByID(ctx context.Context, user *models.User, announcementID uint) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) UpdateDraft(ctx context.Context, user *models.User, announcementID uint, req announcementdto.UpdateDraftRequest) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.draftVersion(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	if version.Status == models.AnnouncementVersionScheduled {
+		return nil, errors.New("draft is scheduled for publishing, unschedule it before editing")
+	}
+
+	if req.FormIDs != nil || req.IncludeAll {
+		var formIDs []uint
+		if !req.IncludeAll {
+			formIDs = *req.FormIDs
+			if len(formIDs) == 0 {
+				return nil, errors.New("form_ids must not be empty (use include_all to add every completed form)")
+			}
+		}
+		items, err := s.buildItems(ctx, announcement.CampusID, announcement.AcademicYear, announcement.Semester, formIDs)
+		if err != nil {
+			return nil, err
+		}
+		version.UpdatedAt = time.Now()
+		if err := s.repo.ReplaceItems(ctx, version, items); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, errors.New("title must not be empty")
+		}
+		announcement.Title = title
+	}
+	announcement.UpdatedBy = user.UserID
+	announcement.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+// Delete ลบได้เฉพาะประกาศที่ยังไม่เคยเผยแพร่ ประกาศที่เผยแพร่แล้วต้องใช้การถอนประกาศแทน
+func (s *announcementService) Delete(ctx context.Context, user *models.User, announcementID uint) error {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return err
+	}
+	if announcement.PublishedVersionID != nil {
+		return errors.New("announcement has been published, retract it instead")
+	}
+	return s.repo.Delete(ctx, announcement.AnnouncementID)
+}
+
+func (s *announcementService) Preview(ctx context.Context, user *models.User, announcementID uint, version int) (*announcementdto.AnnouncementPreviewResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *models.AnnouncementVersion
+	if version > 0 {
+		versions, err := s.repo.GetVersions(ctx, announcement.AnnouncementID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range versions {
+			if versions[i].Version == version {
+				selected = &versions[i]
+				break
+			}
+		}
+		if selected == nil {
+			return nil, errors.New("announcement version not found")
+		}
+	} else {
+		versionID := announcement.DraftVersionID
+		if versionID == nil {
+			versionID = announcement.PublishedVersionID
+		}
+		if versionID == nil {
+			return nil, errors.New("announcement version not found")
+		}
+		if selected, err = s.repo.GetVersion(ctx, *versionID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.repo.GetItems(ctx, selected.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &announcementdto.AnnouncementPreviewResponse{
+		AnnouncementID: announcement.AnnouncementID,
+		Title:          announcement.Title,
+		CampusID:       announcement.CampusID,
+		AcademicYear:   announcement.AcademicYear,
+		Semester:       announcement.Semester,
+		Version:        selected.Version,
+		VersionStatus:  selected.Status,
+		TotalItems:     len(items),
+		Sections:       buildAnnouncementSections(announcement, items),
+	}, nil
+}
+
+func (s *announcementService) Publish(ctx context.Context, user *models.User, announcementID uint, req announcementdto.PublishAnnouncementRequest) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.draftVersion(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	if version.ItemCount == 0 {
+		return nil, errors.New("announcement has no forms to publish")
+	}
+
+	now := time.Now()
+	if req.PublishAt == nil || !req.PublishAt.After(now) {
+		publishedBy := user.UserID
+		if err := s.repo.Publish(ctx, announcement.AnnouncementID, version.VersionID, &publishedBy, now); err != nil {
+			return nil, err
+		}
+		return s.reload(ctx, announcement.AnnouncementID)
+	}
+
+	publishAt := *req.PublishAt
+	version.Status = models.AnnouncementVersionScheduled
+	version.ScheduledAt = &publishAt
+	version.UpdatedAt = now
+	if err := s.repo.UpdateVersion(ctx, version); err != nil {
+		return nil, err
+	}
+	if announcement.PublishedVersionID == nil {
+		announcement.Status = models.AnnouncementStatusScheduled
+	}
+	announcement.UpdatedBy = user.UserID
+	announcement.UpdatedAt = now
+	if err := s.repo.Update(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	// unique key ผูกกับเวลาที่ตั้งไว้ ถ้าตั้งเวลาใหม่งานเดิมจะพบว่าเวลาไม่ตรงและไม่ทำอะไร
+	_, err = s.jobs.Enqueue(ctx, JobTypeAnnouncementPublish, map[string]uint{"version_id": version.VersionID}, EnqueueOptions{
+		RunAt:     publishAt,
+		UniqueKey: fmt.Sprintf("%s:%d:%d", JobTypeAnnouncementPublish, version.VersionID, publishAt.Unix()),
+		CreatedBy: &user.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) Unschedule(ctx context.Context, user *models.User, announcementID uint) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.draftVersion(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	if version.Status != models.AnnouncementVersionScheduled {
+		return nil, errors.New("draft is not scheduled")
+	}
+
+	now := time.Now()
+	version.Status = models.AnnouncementVersionDraft
+	version.ScheduledAt = nil
+	version.UpdatedAt = now
+	if err := s.repo.UpdateVersion(ctx, version); err != nil {
+		return nil, err
+	}
+	if announcement.Status == models.AnnouncementStatusScheduled {
+		announcement.Status = models.AnnouncementStatusDraft
+	}
+	announcement.UpdatedBy = user.UserID
+	announcement.UpdatedAt = now
+	if err := s.repo.Update(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) PublishScheduled(ctx context.Context, versionID uint) error {
+	version, err := s.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	// ถูกยกเลิก/ตั้งเวลาใหม่/เผยแพร่ไปแล้ว
+	now := time.Now()
+	if version.Status != models.AnnouncementVersionScheduled || version.ScheduledAt == nil || version.ScheduledAt.After(now) {
+		return nil
+	}
+
+	announcement, err := s.repo.GetByID(ctx, version.AnnouncementID)
+	if err != nil {
+		return err
+	}
+	if announcement.DraftVersionID == nil || *announcement.DraftVersionID != version.VersionID {
+		return nil
+	}
+	return s.repo.Publish(ctx, announcement.AnnouncementID, version.VersionID, nil, now)
+}
+
+func (s *announcementService) Amend(ctx context.Context, user *models.User, announcementID uint, req announcementdto.AmendAnnouncementRequest) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if announcement.PublishedVersionID == nil {
+		return nil, errors.New("announcement has not been published, edit the draft instead")
+	}
+	if announcement.DraftVersionID != nil {
+		return nil, errors.New("an amendment draft already exists")
+	}
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, errors.New("note is required for an amendment")
+	}
+
+	versions, err := s.repo.GetVersions(ctx, announcement.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetItems(ctx, *announcement.PublishedVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	version := &models.AnnouncementVersion{
+		Version:   versions[0].Version + 1,
+		Status:    models.AnnouncementVersionDraft,
+		Note:      note,
+		CreatedBy: user.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	announcement.UpdatedBy = user.UserID
+	announcement.UpdatedAt = now
+	if err := s.repo.CreateVersion(ctx, announcement, version, items); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) Retract(ctx context.Context, user *models.User, announcementID uint, req announcementdto.RetractAnnouncementRequest) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.get(ctx, user, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if announcement.Status != models.AnnouncementStatusPublished {
+		return nil, errors.New("only a published announcement can be retracted")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	now := time.Now()
+	announcement.Status = models.AnnouncementStatusRetracted
+	announcement.RetractedAt = &now
+	announcement.RetractReason = reason
+	announcement.UpdatedBy = user.UserID
+	announcement.UpdatedAt = now
+	if err := s.repo.Retract(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+// get ดึงประกาศของวิทยาเขตผู้ใช้ (ประกาศของวิทยาเขตอื่นถือว่าไม่พบ)
+func (s *announcementService) get(ctx context.Context, user *models.User, announcementID uint) (*models.Announcement, error) {
+	announcement, err := s.repo.GetByID(ctx, announcementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("announcement not found")
+		}
+		return nil, err
+	}
+	if announcement.CampusID != user.CampusID {
+		return nil, errors.New("announcement not found")
+	}
+	return announcement, nil
+}
+
+func (s *announcementService) draftVersion(ctx context.Context, announcement *models.Announcement) (*models.AnnouncementVersion, error) {
+	if announcement.DraftVersionID == nil {
+		return nil, errors.New("announcement has no draft, create an amendment first")
+	}
+	return s.repo.GetVersion(ctx, *announcement.DraftVersionID)
+}
+
+// buildItems ตรวจว่าทุก form_id เป็นฟอร์มที่อนุมัติเสร็จสิ้นของวิทยาเขตและภาคเรียนนี้
+func (s *announcementService) buildItems(ctx context.Context, campusID, academicYear, semester int, formIDs []uint) ([]models.AnnouncementItem, error) {
+	unique := make([]uint, 0, len(formIDs))
+	seen := make(map[uint]bool, len(formIDs))
+	for _, id := range formIDs {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	items, err := s.repo.BuildItems(ctx, campusID, academicYear, semester, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) > 0 && len(items) != len(unique) {
+		found := make(map[uint]bool, len(items))
+		for _, item := range items {
+			found[item.FormID] = true
+		}
+		missing := make([]string, 0)
+		for _, id := range unique {
+			if !found[id] {
+				missing = append(missing, fmt.Sprint(id))
+			}
+		}
+		return nil, fmt.Errorf("form(s) %s are not completed forms of this campus and term", strings.Join(missing, ", "))
+	}
+	return items, nil
+}
+
+func (s *announcementService) reload(ctx context.Context, announcementID uint) (*announcementdto.AnnouncementResponse, error) {
+	announcement, err := s.repo.GetByID(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, announcement)
+}
+
+func (s *announcementService) detail(ctx context.Context, announcement *models.Announcement) (*announcementdto.AnnouncementResponse, error) {
+	versions, err := s.repo.GetVersions(ctx, announcement.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+	response := mapToAnnouncementResponse(announcement, versions)
+	return &response, nil
+}
+
+func mapToAnnouncementResponse(announcement *models.Announcement, versions []models.AnnouncementVersion) announcementdto.AnnouncementResponse {
+	response := announcementdto.AnnouncementResponse{
+		AnnouncementID: announcement.AnnouncementID,
+		CampusID:       announcement.CampusID,
+		AcademicYear:   announcement.AcademicYear,
+		Semester:       announcement.Semester,
+		Title:          announcement.Title,
+		Status:         announcement.Status,
+		PublishedAt:    announcement.PublishedAt,
+		RetractedAt:    announcement.RetractedAt,
+		RetractReason:  announcement.RetractReason,
+		CreatedBy:      announcement.CreatedBy,
+		CreatedAt:      announcement.CreatedAt,
+		UpdatedAt:      announcement.UpdatedAt,
+	}
+
+	for _, v := range versions {
+		number := v.Version
+		if announcement.PublishedVersionID != nil && *announcement.PublishedVersionID == v.VersionID {
+			response.PublishedVersion = &number
+		}
+		if announcement.DraftVersionID != nil && *announcement.DraftVersionID == v.VersionID {
+			response.DraftVersion = &number
+		}
+		response.Versions = append(response.Versions, announcementdto.AnnouncementVersionResponse{
+			VersionID:   v.VersionID,
+			Version:     v.Version,
+			Status:      v.Status,
+			Note:        v.Note,
+			ItemCount:   v.ItemCount,
+			ScheduledAt: v.ScheduledAt,
+			PublishedAt: v.PublishedAt,
+			PublishedBy: v.PublishedBy,
+			CreatedBy:   v.CreatedBy,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+	return response
+}
+
+// buildAnnouncementSections จัดรายการเป็นหมวดและเรียงตามชื่อเหมือนหน้าประกาศผล
+func buildAnnouncementSections(announcement *models.Announcement, items []models.AnnouncementItem) []awardformdto.AnnouncementAwardSection {
+	byKey := make(map[string][]awardformdto.AnnouncementAwardItem, len(announcementSections))
+	for _, item := range items {
+		section := announcementSectionOf(item.AwardCategory)
+		byKey[section.key] = append(byKey[section.key], awardformdto.AnnouncementAwardItem{
+			FormID:           item.FormID,
+			CampusID:         announcement.CampusID,
+			AcademicYear:     announcement.AcademicYear,
+			Semester:         announcement.Semester,
+			AwardType:        item.AwardType,
+			AwardTypeGroup:   section.label,
+			FacultyID:        item.FacultyID,
+			FacultyName:      item.FacultyName,
+			StudentNumber:    item.StudentNumber,
+			Prefix:           item.Prefix,
+			StudentFirstname: item.StudentFirstname,
+			StudentLastname:  item.StudentLastname,
+			DisplayName:      strings.TrimSpace(strings.TrimSpace(item.Prefix) + " " + strings.TrimSpace(item.StudentFirstname) + " " + strings.TrimSpace(item.StudentLastname)),
+		})
+	}
+
+	sections := make([]awardformdto.AnnouncementAwardSection, 0, len(announcementSections))
+	for _, section := range announcementSections {
+		data := byKey[section.key]
+		if data == nil {
+			data = make([]awardformdto.AnnouncementAwardItem, 0)
+		}
+		sort.SliceStable(data, func(i, j int) bool {
+			if data[i].StudentFirstname != data[j].StudentFirstname {
+				return data[i].StudentFirstname < data[j].StudentFirstname
+			}
+			return data[i].StudentLastname < data[j].StudentLastname
+		})
+		sections = append(sections, awardformdto.AnnouncementAwardSection{
+			Key:   section.key,
+			Label: section.label,
+			Data:  data,
+			Pagination: awardformdto.PaginationMeta{
+				CurrentPage: 1,
+				TotalPages:  1,
+				TotalItems:  int64(len(data)),
+				Limit:       len(data),
+			},
+		})
+	}
+	return sections
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ฟิลด์ข้อมูลส่วนบุคคลที่ถูกซ่อนตามบทบาทของผู้ดู
+const (
+	personalFieldAddress     = "student_address"
+	personalFieldPhoneNumber = "student_phone_number"
+	personalFieldGPA         = "gpa"
+	personalFieldDateOfBirth = "student_date_of_birth"
+)
+
+// approverStepRank ลำดับขั้นของผู้พิจารณาแต่ละ role ในสายอนุมัติ
+var approverStepRank = map[int]int{2: 1, 3: 2, 4: 3, 5: 4, 6: 5, 7: 6}
+
+// formStepRoleByStatus role ของขั้นล่าสุดที่ฟอร์มไปถึงแล้ว (รอพิจารณาอยู่ หรือเป็นผู้อนุมัติ/ปฏิเสธ)
+// สถานะ 13/14 (ยังไม่เข้าสายอนุมัติ) ไม่มีในตารางนี้
+var formStepRoleByStatus = map[int]int{
+	1: 2, 2: 3, 3: 2,
+	4: 4, 5: 3,
+	6: 5, 7: 4,
+	8: 6, 9: 6, 10: 6,
+	11: 7, 12: 7,
+}
+
+// hiddenPersonalFieldsByRole ฟิลด์ที่ผู้พิจารณาแต่ละ role ไม่จำเป็นต้องเห็น
+// หัวหน้าภาควิชา/รองคณบดี/คณบดีเห็น GPA และเบอร์โทรเพื่อตรวจคุณสมบัติและติดต่อ คณะกรรมการ/อธิการบดีเห็นเฉพาะ GPA
+var hiddenPersonalFieldsByRole = map[int][]string{
+	2: {personalFieldAddress, personalFieldDateOfBirth},
+	3: {personalFieldAddress, personalFieldDateOfBirth},
+	4: {personalFieldAddress, personalFieldDateOfBirth},
+	6: {personalFieldAddress, personalFieldPhoneNumber, personalFieldDateOfBirth},
+	7: {personalFieldAddress, personalFieldPhoneNumber, personalFieldDateOfBirth},
+}
+
+// errFormForbidden ผู้ดูไม่มีสิทธิ์อ่านฟอร์มนี้
+var errFormForbidden = errors.New("forbidden: you do not have access to this form")
+
+// formReadAccess ตรวจสิทธิ์อ่านและซ่อนข้อมูลส่วนบุคคลของฟอร์มสำหรับผู้ดูคนหนึ่ง
+// ใช้กับทุกเส้นทางที่คืนข้อมูลส่วนบุคคลของฟอร์ม (รายละเอียด, ผลค้นหา/ส่งออก, แฟ้มเสนอชื่อ, รายการของตนเอง)
+// ขอบเขตของผู้พิจารณาและนิยามฟิลด์ของประเภทรางวัลโหลดครั้งเดียวต่อ request
+type formReadAccess struct {
+	repo   *repository.AwardRepository
+	viewer *models.User
+
+	scopeLoaded  bool
+	departmentID int
+	facultyID    int
+	formFields   map[uint][]models.AwardFormField
+}
+
+func newFormReadAccess(repo *repository.AwardRepository, viewer *models.User) *formReadAccess {
+	return &formReadAccess{repo: repo, viewer: viewer, formFields: make(map[uint][]models.AwardFormField)}
+}
+
+// authorize คืน errFormForbidden ถ้าไม่มีสิทธิ์ ถ้ามีสิทธิ์จะล้างค่าที่ต้องซ่อนใน form และคืนชื่อฟิลด์ที่ถูกซ่อน
+func (a *formReadAccess) authorize(ctx context.Context, form *models.AwardForm) ([]string, error) {
+	allowed, err := a.canView(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errFormForbidden
+	}
+
+	hidden := hiddenPersonalFields(a.viewer, form)
+	maskFormPersonalFields(form, hidden)
+
+	// คำตอบชนิด email/phone (เช่น ผู้รับรอง สมาชิกทีม) เป็นข้อมูลติดต่อ ซ่อนเมื่อผู้ดูไม่เห็นเบอร์โทรของนิสิต
+	if containsString(hidden, personalFieldPhoneNumber) && len(form.FormAnswers) > 0 && form.AwardTypeID != nil {
+		fields, err := a.awardTypeFields(ctx, *form.AwardTypeID)
+		if err != nil {
+			return nil, err
+		}
+		hidden = append(hidden, maskContactAnswers(form.FormAnswers, fields)...)
+	}
+	return hidden, nil
+}
+
+// mapForms แปลงรายการฟอร์มเป็น response ข้ามฟอร์มที่ไม่มีสิทธิ์อ่าน และซ่อนฟิลด์ส่วนบุคคลของแต่ละฟอร์ม
+func (a *formReadAccess) mapForms(ctx context.Context, forms []models.AwardForm) ([]awardformdto.AwardFormResponse, error) {
+	response := make([]awardformdto.AwardFormResponse, 0, len(forms))
+	for i := range forms {
+		hidden, err := a.authorize(ctx, &forms[i])
+		if errors.Is(err, errFormForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		item := mapToAwardResponse(forms[i])
+		item.MaskedFields = hidden
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// canView เจ้าของ/ผู้ถูกเสนอชื่อ, องค์กรผู้เสนอชื่อ, กองพัฒนานิสิต,
+// ผู้พิจารณาในขอบเขตของตนสำหรับขั้นปัจจุบันหรือขั้นที่ผ่านมา และคณะกรรมการระหว่างขั้นคณะกรรมการ
+func (a *formReadAccess) canView(ctx context.Context, form *models.AwardForm) (bool, error) {
+	viewer := a.viewer
+	if viewer.RoleID == 5 || isFormParty(form, viewer.UserID) {
+		return true, nil
+	}
+
+	rank, isApprover := approverStepRank[viewer.RoleID]
+	stepRole, inChain := formStepRoleByStatus[form.FormStatusID]
+	if !isApprover || !inChain || rank > approverStepRank[stepRole] {
+		return false, nil
+	}
+
+	switch viewer.RoleID {
+	case 2, 3, 4:
+		if err := a.loadScope(ctx); err != nil {
+			return false, err
+		}
+		if viewer.RoleID == 2 {
+			return a.departmentID != 0 && a.departmentID == form.DepartmentID, nil
+		}
+		return a.facultyID != 0 && a.facultyID == form.FacultyID, nil
+	case 6:
+		// คณะกรรมการเห็นฟอร์มเฉพาะช่วงโหวต/ลงนามของวิทยาเขตตนเอง
+		return stepRole == 6 && viewer.CampusID == form.CampusID, nil
+	case 7:
+		return viewer.CampusID == form.CampusID, nil
+	}
+	return false, nil
+}
+
+func (a *formReadAccess) loadScope(ctx context.Context) error {
+	if a.scopeLoaded {
+		return nil
+	}
+	var err error
+	if a.viewer.RoleID == 2 {
+		_, a.departmentID, err = a.repo.GetHeadOfDepartmentScopeByUserID(ctx, a.viewer.UserID)
+	} else {
+		a.facultyID, err = a.repo.GetFacultyScopeByRoleAndUserID(ctx, a.viewer.RoleID, a.viewer.UserID)
+	}
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	a.scopeLoaded = true
+	return nil
+}
+
+func (a *formReadAccess) awardTypeFields(ctx context.Context, awardTypeID uint) ([]models.AwardFormField, error) {
+	if fields, ok := a.formFields[awardTypeID]; ok {
+		return fields, nil
+	}
+	fields, err := a.repo.GetAwardTypeFormFields(ctx, awardTypeID)
+	if err != nil {
+		return nil, err
+	}
+	a.formFields[awardTypeID] = fields
+	return fields, nil
+}
+
+// ignoreNotFound ผู้ใช้ที่ยังไม่มีขอบเขต (ไม่พบแถวในตาราง role) ถือว่าไม่มีสิทธิ์ ไม่ใช่ข้อผิดพลาด
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// hiddenPersonalFields ฟิลด์ส่วนบุคคลที่ต้องซ่อนจากผู้ดูคนนี้
+func hiddenPersonalFields(viewer *models.User, form *models.AwardForm) []string {
+	if viewer.RoleID == 5 {
+		return nil
+	}
+	if isFormParty(form, viewer.UserID) {
+		// องค์กรผู้เสนอชื่อไม่เห็นข้อมูลส่วนตัวที่นิสิตกรอกตอนยินยอม
+		if form.SubmitterRoleID == 8 && (form.NomineeUserID == nil || *form.NomineeUserID != viewer.UserID) {
+			return []string{personalFieldAddress, personalFieldPhoneNumber, personalFieldGPA, personalFieldDateOfBirth}
+		}
+		return nil
+	}
+	return append([]string(nil), hiddenPersonalFieldsByRole[viewer.RoleID]...)
+}
+
+// maskFormPersonalFields ล้างค่าฟิลด์ที่ซ่อนใน form
+func maskFormPersonalFields(form *models.AwardForm, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case personalFieldAddress:
+			form.StudentAddress = ""
+		case personalFieldPhoneNumber:
+			form.StudentPhoneNumber = ""
+		case personalFieldGPA:
+			form.GPA = 0
+		case personalFieldDateOfBirth:
+			form.StudentDateOfBirth = time.Time{}
+		}
+	}
+}
+
+// maskContactAnswers ล้างคำตอบชนิด email/phone (รวมฟิลด์ย่อยของ list) คืนชื่อฟิลด์ในรูป form_answers.<key>
+func maskContactAnswers(answers map[string]interface{}, fields []models.AwardFormField) []string {
+	var masked []string
+	for _, field := range fields {
+		value, ok := answers[field.Key]
+		if !ok || value == nil {
+			continue
+		}
+		switch field.Type {
+		case models.FormFieldEmail, models.FormFieldPhone:
+			answers[field.Key] = ""
+			masked = append(masked, "form_answers."+field.Key)
+		case models.FormFieldList:
+			items, ok := value.([]interface{})
+			if !ok {
+				continue
+			}
+			copied := make([]interface{}, len(items))
+			for i, item := range items {
+				entry, ok := item.(map[string]interface{})
+				if !ok {
+					copied[i] = item
+					continue
+				}
+				clone := make(map[string]interface{}, len(entry))
+				for key, v := range entry {
+					clone[key] = v
+				}
+				for _, sub := range maskContactAnswers(clone, field.Fields) {
+					masked = appendUnique(masked, "form_answers."+field.Key+"."+strings.TrimPrefix(sub, "form_answers."))
+				}
+				copied[i] = clone
+			}
+			answers[field.Key] = copied
+		}
+	}
+	return masked
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"backend/internal/models"
+	"backend/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	formFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+	formPhonePattern    = regexp.MustCompile(`^\+?[0-9][0-9 -]{6,19}$`)
+)
+
+// maxFormFields จำกัดจำนวนฟิลด์ต่อระดับ กันฟอร์มใหญ่เกินจนค้นหาไม่ไหว
+const maxFormFields = 50
+
+var formFieldTypes = map[string]bool{
+	models.FormFieldText:        true,
+	models.FormFieldTextarea:    true,
+	models.FormFieldNumber:      true,
+	models.FormFieldInteger:     true,
+	models.FormFieldBoolean:     true,
+	models.FormFieldDate:        true,
+	models.FormFieldEmail:       true,
+	models.FormFieldPhone:       true,
+	models.FormFieldSelect:      true,
+	models.FormFieldMultiSelect: true,
+	models.FormFieldList:        true,
+}
+
+// normalizeFormFields ตรวจนิยามฟิลด์ที่ admin ส่งมา nested = true คือฟิลด์ย่อยของ list (ห้ามมี list ซ้อน)
+func normalizeFormFields(fields []models.AwardFormField, nested bool) ([]models.AwardFormField, error) {
+	if len(fields) > maxFormFields {
+		return nil, fmt.Errorf("form_fields must not exceed %d fields", maxFormFields)
+	}
+
+	seen := make(map[string]bool, len(fields))
+	result := make([]models.AwardFormField, 0, len(fields))
+	for _, field := range fields {
+		field.Key = strings.TrimSpace(field.Key)
+		field.Label = strings.TrimSpace(field.Label)
+		field.Type = strings.ToLower(strings.TrimSpace(field.Type))
+		field.Description = strings.TrimSpace(field.Description)
+
+		if !formFieldKeyPattern.MatchString(field.Key) {
+			return nil, fmt.Errorf("invalid field key %q: must start with a-z and contain only a-z, 0-9 or _", field.Key)
+		}
+		if seen[field.Key] {
+			return nil, fmt.Errorf("duplicate field key %q", field.Key)
+		}
+		seen[field.Key] = true
+		if field.Label == "" {
+			return nil, fmt.Errorf("field %s: label is required", field.Key)
+		}
+		if !formFieldTypes[field.Type] {
+			return nil, fmt.Errorf("field %s: invalid type %q", field.Key, field.Type)
+		}
+
+		if err := checkFormFieldLimits(field); err != nil {
+			return nil, err
+		}
+
+		switch field.Type {
+		case models.FormFieldSelect, models.FormFieldMultiSelect:
+			options := make([]string, 0, len(field.Options))
+			optionSet := make(map[string]bool, len(field.Options))
+			for _, option := range field.Options {
+				option = strings.TrimSpace(option)
+				if option == "" || optionSet[option] {
+					continue
+				}
+				optionSet[option] = true
+				options = append(options, option)
+			}
+			if len(options) == 0 {
+				return nil, fmt.Errorf("field %s: options are required for %s", field.Key, field.Type)
+			}
+			field.Options = options
+		case models.FormFieldList:
+			if nested {
+				return nil, fmt.Errorf("field %s: list fields cannot be nested", field.Key)
+			}
+			if len(field.Fields) == 0 {
+				return nil, fmt.Errorf("field %s: fields are required for list", field.Key)
+			}
+			subFields, err := normalizeFormFields(field.Fields, true)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Key, err)
+			}
+			field.Fields = subFields
+		}
+		if field.Type != models.FormFieldSelect && field.Type != models.FormFieldMultiSelect {
+			field.Options = nil
+		}
+		if field.Type != models.FormFieldList {
+			field.Fields = nil
+		}
+		result = append(result, field)
+	}
+	return result, nil
+}
+
+// checkFormFieldLimits ค่าจำกัดต้องเป็นของชนิดฟิลด์นั้น และค่าต่ำสุดต้องไม่เกินค่าสูงสุด
+func checkFormFieldLimits(field models.AwardFormField) error {
+	isText := field.Type == models.FormFieldText || field.Type == models.FormFieldTextarea
+	isNumber := field.Type == models.FormFieldNumber || field.Type == models.FormFieldInteger
+	hasItems := field.Type == models.FormFieldMultiSelect || field.Type == models.FormFieldList
+
+	if (field.MinLength != nil || field.MaxLength != nil) && !isText {
+		return fmt.Errorf("field %s: min_length/max_length must only be set on text fields", field.Key)
+	}
+	if (field.Min != nil || field.Max != nil) && !isNumber {
+		return fmt.Errorf("field %s: min/max must only be set on number fields", field.Key)
+	}
+	if (field.MinItems != nil || field.MaxItems != nil) && !hasItems {
+		return fmt.Errorf("field %s: min_items/max_items must only be set on multiselect and list fields", field.Key)
+	}
+
+	for _, limit := range []*int{field.MinLength, field.MaxLength, field.MinItems, field.MaxItems} {
+		if limit != nil && *limit < 0 {
+			return fmt.Errorf("field %s: limits must not be negative", field.Key)
+		}
+	}
+	if field.MinLength != nil && field.MaxLength != nil && *field.MinLength > *field.MaxLength {
+		return fmt.Errorf("field %s: min_length must not exceed max_length", field.Key)
+	}
+	if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+		return fmt.Errorf("field %s: min must not exceed max", field.Key)
+	}
+	if field.MinItems != nil && field.MaxItems != nil && *field.MinItems > *field.MaxItems {
+		return fmt.Errorf("field %s: min_items must not exceed max_items", field.Key)
+	}
+	return nil
+}
+
+// validateFormAnswers ตรวจคำตอบกับนิยามฟิลด์และคืนค่าที่แปลงเป็นชนิดมาตรฐานแล้ว
+// (ตัวเลขเป็น float64, วันที่เป็น YYYY-MM-DD) ฟิลด์ที่ไม่อยู่ในนิยามถือว่าผิด
+func validateFormAnswers(fields []models.AwardFormField, answers map[string]interface{}) (map[string]interface{}, error) {
+	problems := make([]string, 0)
+	result := checkFormAnswers(fields, answers, "", &problems)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid form answers: %s", strings.Join(problems, "; "))
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+func checkFormAnswers(fields []models.AwardFormField, answers map[string]interface{}, prefix string, problems *[]string) map[string]interface{} {
+	known := make(map[string]bool, len(fields))
+	result := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+		path := prefix + field.Key
+
+		raw, ok := answers[field.Key]
+		if !ok || isEmptyAnswer(raw) {
+			if field.Required {
+				*problems = append(*problems, fmt.Sprintf("%s is required", path))
+			}
+			continue
+		}
+		value, err := normalizeFormAnswer(field, raw, path, problems)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("%s %s", path, err.Error()))
+			continue
+		}
+		result[field.Key] = value
+	}
+
+	unknown := make([]string, 0)
+	for key := range answers {
+		if !known[key] {
+			unknown = append(unknown, prefix+key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		*problems = append(*problems, fmt.Sprintf("%s is not a field of this award type", key))
+	}
+	return result
+}
+
+func isEmptyAnswer(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// normalizeFormAnswer error ที่คืนเป็นปัญหาของฟิลด์นี้ ปัญหาของฟิลด์ย่อยใน list จะถูกเพิ่มลง problems โดยตรง
+func normalizeFormAnswer(field models.AwardFormField, raw interface{}, path string, problems *[]string) (interface{}, error) {
+	switch field.Type {
+	case models.FormFieldText, models.FormFieldTextarea:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be text")
+		}
+		text = strings.TrimSpace(text)
+		length := utf8.RuneCountInString(text)
+		if field.MinLength != nil && length < *field.MinLength {
+			return nil, fmt.Errorf("must be at least %d characters", *field.MinLength)
+		}
+		if field.MaxLength != nil && length > *field.MaxLength {
+			return nil, fmt.Errorf("must be at most %d characters", *field.MaxLength)
+		}
+		return text, nil
+
+	case models.FormFieldNumber, models.FormFieldInteger:
+		number, ok := answerNumber(raw)
+		if !ok {
+			return nil, errors.New("must be a number")
+		}
+		if field.Type == models.FormFieldInteger && number != math.Trunc(number) {
+			return nil, errors.New("must be an integer")
+		}
+		if field.Min != nil && number < *field.Min {
+			return nil, fmt.Errorf("must be at least %s", formatAnswerNumber(*field.Min))
+		}
+		if field.Max != nil && number > *field.Max {
+			return nil, fmt.Errorf("must be at most %s", formatAnswerNumber(*field.Max))
+		}
+		return number, nil
+
+	case models.FormFieldBoolean:
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if parsed, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, errors.New("must be true or false")
+
+	case models.FormFieldDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(text))
+		if err != nil {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		return date.Format("2006-01-02"), nil
+
+	case models.FormFieldEmail:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be an email address")
+		}
+		text = strings.TrimSpace(text)
+		if address, err := mail.ParseAddress(text); err != nil || address.Address != text {
+			return nil, errors.New("must be an email address")
+		}
+		return text, nil
+
+	case models.FormFieldPhone:
+		text, ok := raw.(string)
+		if !ok || !formPhonePattern.MatchString(strings.TrimSpace(text)) {
+			return nil, errors.New("must be a phone number")
+		}
+		return strings.TrimSpace(text), nil
+
+	case models.FormFieldSelect:
+		text, ok := raw.(string)
+		if !ok || !containsString(field.Options, strings.TrimSpace(text)) {
+			return nil, fmt.Errorf("must be one of: %s", strings.Join(field.Options, ", "))
+		}
+		return strings.TrimSpace(text), nil
+
+	case models.FormFieldMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, errors.New("must be a list of options")
+		}
+		selected := make([]interface{}, 0, len(items))
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			text, ok := item.(string)
+			if !ok || !containsString(field.Options, strings.TrimSpace(text)) {
+				return nil, fmt.Errorf("must only contain: %s", strings.Join(field.Options, ", "))
+			}
+			text = strings.TrimSpace(text)
+			if !seen[text] {
+				seen[text] = true
+				selected = append(selected, text)
+			}
+		}
+		if err := checkAnswerItems(field, len(selected)); err != nil {
+			return nil, err
+		}
+		return selected, nil
+
+	case models.FormFieldList:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, errors.New("must be a list")
+		}
+		if err := checkAnswerItems(field, len(items)); err != nil {
+			return nil, err
+		}
+		rows := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item %d must be an object", i+1)
+			}
+			rows = append(rows, checkFormAnswers(field.Fields, row, fmt.Sprintf("%s[%d].", path, i+1), problems))
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("has unsupported type %q", field.Type)
+}
+
+func checkAnswerItems(field models.AwardFormField, count int) error {
+	if field.MinItems != nil && count < *field.MinItems {
+		return fmt.Errorf("must have at least %d items", *field.MinItems)
+	}
+	if field.MaxItems != nil && count > *field.MaxItems {
+		return fmt.Errorf("must have at most %d items", *field.MaxItems)
+	}
+	return nil
+}
+
+// answerNumber รับทั้งตัวเลขจาก JSON และข้อความตัวเลข (multipart form ส่งเป็น string ได้)
+func answerNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return 0, false
+		}
+		return number, true
+	}
+	return 0, false
+}
+
+func formatAnswerNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// buildAnswerFilters แปลงตัวกรองคำตอบของหน้าค้นหา (key -> ค่า) เป็นเงื่อนไขของ repository
+// key ต้องเป็นฟิลด์ระดับบนของประเภทรางวัลใดประเภทหนึ่ง วิธีเทียบขึ้นกับชนิดของฟิลด์
+func buildAnswerFilters(awardTypes []models.AwardType, answers map[string]string) ([]repository.AnswerFilter, error) {
+	if len(answers) == 0 {
+		return nil, nil
+	}
+
+	fieldTypes := make(map[string]string)
+	for _, awardType := range awardTypes {
+		for _, field := range awardType.FormFields {
+			if _, ok := fieldTypes[field.Key]; !ok {
+				fieldTypes[field.Key] = field.Type
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filters := make([]repository.AnswerFilter, 0, len(keys))
+	for _, key := range keys {
+		value := strings.TrimSpace(answers[key])
+		if value == "" {
+			continue
+		}
+		fieldType, ok := fieldTypes[key]
+		if !ok {
+			return nil, fmt.Errorf("invalid answer filter: unknown field %q", key)
+		}
+
+		filter := repository.AnswerFilter{Key: key, Value: value, Match: repository.AnswerMatchContains}
+		switch fieldType {
+		case models.FormFieldNumber, models.FormFieldInteger:
+			number, ok := answerNumber(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid answer filter: %s must be a number", key)
+			}
+			filter.Value = formatAnswerNumber(number)
+			filter.Match = repository.AnswerMatchExact
+		case models.FormFieldBoolean:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid answer filter: %s must be true or false", key)
+			}
+			filter.Value = strconv.FormatBool(parsed)
+			filter.Match = repository.AnswerMatchExact
+		case models.FormFieldSelect, models.FormFieldDate:
+			filter.Match = repository.AnswerMatchExact
+		case models.FormFieldMultiSelect:
+			filter.Match = repository.AnswerMatchIncludes
+		case models.FormFieldList:
+			return nil, fmt.Errorf("invalid answer filter: %s is a list field and cannot be filtered", key)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
@@ -2,10 +2,14 @@ package usecase
 
 import (
 	awardformdto "backend/internal/dto/award_form_dto"
+	realtimedto "backend/internal/dto/realtime_dto"
+	webhookdto "backend/internal/dto/webhook_dto"
 	"backend/internal/models"
 	"backend/internal/repository"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"time"
 )
@@ -13,29 +17,43 @@ import (
 type AwardUseCase interface {
 	// ปรับปรุง: รับ userID เพื่อดึงข้อมูล student และ files
 	SubmitAward(ctx context.Context, userID uint, input awardformdto.SubmitAwardRequest, files []models.AwardFileDirectory) error
-	GetByKeyword(ctx context.Context, userID uint, roleID int, campusID int, keyword string, date string, studentYear int, awardType string, sortBy string, sortOrder string, page int, limit int) (*awardformdto.PaginatedAwardResponse, error)
+	GetByKeyword(ctx context.Context, userID uint, roleID int, campusID int, keyword string, date string, studentYear int, academicYear int, semester int, awardType string, answers map[string]string, sortBy string, sortOrder string, page int, limit int) (*awardformdto.PaginatedAwardResponse, error)
 	GetAwardsByUserID(ctx context.Context, userID uint) ([]awardformdto.AwardFormResponse, error)
-	GetAwardsByStudentID(ctx context.Context, studentID int) ([]awardformdto.AwardFormResponse, error)
+	GetAwardsByStudentID(ctx context.Context, studentID int, viewer *models.User) ([]awardformdto.AwardFormResponse, error)
 	GetAwardsByUserIDAndYear(ctx context.Context, userID uint, year int) ([]awardformdto.AwardFormResponse, error)
 	GetAwardsByUserIDAndSemester(ctx context.Context, userID uint, year int, semester int) ([]awardformdto.AwardFormResponse, error)
 	GetAwardsByUserIDWithYearSort(ctx context.Context, userID uint, years []int) ([]awardformdto.AwardFormResponse, error)
 	GetAwardsByUserIDPaged(ctx context.Context, userID uint, years []int, page int, limit int) (*awardformdto.PaginatedAwardResponse, error)
-	GetByFormID(ctx context.Context, formID int) (*awardformdto.AwardFormResponse, error)
-	IsDuplicate(userID uint, year int, semester int) (bool, error)
+	GetByFormID(ctx context.Context, formID int, viewer *models.User) (*awardformdto.AwardFormResponse, error)
+	// IsDuplicate semester ต้องเป็นประเภทภาคการศึกษาของวิทยาเขต
+	IsDuplicate(ctx context.Context, campusID int, studentNumber string, awardTypeID uint, year int, semester int) (bool, error)
 	UpdateAwardType(ctx context.Context, formID uint, awardType string, changedBy uint) error
 	UpdateFormStatus(ctx context.Context, formID uint, formStatus int, rejectReason string, changedBy uint) error
 	UpdateFormStatusWithLog(ctx context.Context, formID uint, formStatus int, rejectReason string, changedBy uint) error
 	UpdateFormStatusWithSignedLog(ctx context.Context, formID uint, formStatus int, rejectReason string, changedBy uint) error
 	IsCommitteeChairman(ctx context.Context, userID uint) (bool, error)
+	IsFormCommitteeChairman(ctx context.Context, formID uint, userID uint) (bool, error)
 	CommitteeVote(ctx context.Context, formID uint, operation string, votedBy uint) (*awardformdto.CommitteeVoteResult, error)
 	GetApprovalLogsByUserID(ctx context.Context, userID uint) ([]models.AwardApprovalLog, error)
 	GetSignedLogsByUserID(ctx context.Context, userID uint) ([]models.AwardSignedLog, error)
 	GetCommitteeVoteLogsByUserID(ctx context.Context, userID uint, keyword string, date string, page int, limit int) ([]models.CommitteeVoteLog, int64, error)
 	GetApprovalHistory(ctx context.Context, userID uint, campusID int, keyword string, date string, operation string, sortBy string, sortOrder string, page int, limit int) (*awardformdto.PaginatedApprovalLogResponse, error)
-	GetAllAwardTypes(ctx context.Context) ([]string, error)
+	GetAllAwardTypes(ctx context.Context, campusID int) ([]string, error)
 	GetApprovalLogDetail(ctx context.Context, approvalLogID uint) (*models.AwardApprovalLog, error)
 	GetAnnouncementAwards(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (*awardformdto.PaginatedAnnouncementAwardResponse, error)
+	// ResolveAnnouncementRequest เติมปี/ภาคเรียนที่หน้าประกาศจะใช้ลงในคำขอ และตรวจตัวกรองภาคเรียน
+	ResolveAnnouncementRequest(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (awardformdto.AnnouncementAwardRequest, error)
+	// EachAnnouncementAward ส่งรายชื่อในประกาศของคำขอที่ resolve แล้วให้ emit ทีละหมวด ดึงทีละ batchSize แถว
+	EachAnnouncementAward(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest, batchSize int, emit func(awardformdto.AnnouncementAwardItem) error) error
 	GetAwardTypeLogs(ctx context.Context, req awardformdto.SearchAwardTypeLogRequest) ([]awardformdto.AwardTypeLogResponse, error)
+
+	// การเสนอชื่อโดยองค์กร (role 8)
+	GetNominations(ctx context.Context, userID uint, req awardformdto.NominationListRequest) (*awardformdto.PaginatedAwardResponse, error)
+	BulkNominate(ctx context.Context, userID uint, file io.Reader) (*awardformdto.BulkNominationResponse, error)
+	// การยินยอมของนิสิตผู้ถูกเสนอชื่อ (role 1)
+	GetPendingConsents(ctx context.Context, userID uint) ([]awardformdto.AwardFormResponse, error)
+	AcceptNomination(ctx context.Context, userID uint, formID uint, req awardformdto.NomineeConsentRequest) error
+	DeclineNomination(ctx context.Context, userID uint, formID uint, req awardformdto.NomineeDeclineRequest) error
 }
 
 type awardUseCase struct {
@@ -43,34 +61,47 @@ type awardUseCase struct {
 	studentService      StudentService
 	organizationService OrganizationService
 	academicYearService AcademicYearService
+	verificationService VerificationService
+	signatureService    SignatureService
+	notificationService NotificationService
+	realtimeService     RealtimeService
+	webhookService      WebhookService
+	awardTypeService    AwardTypeService
 }
 
-func NewAwardUseCase(r *repository.AwardRepository, ss StudentService, os OrganizationService, ays AcademicYearService) AwardUseCase {
+func NewAwardUseCase(r *repository.AwardRepository, ss StudentService, os OrganizationService, ays AcademicYearService, vs VerificationService, sigs SignatureService, ns NotificationService, rts RealtimeService, whs WebhookService, ats AwardTypeService) AwardUseCase {
 	return &awardUseCase{
 		repo:                r,
 		studentService:      ss,
 		organizationService: os,
 		academicYearService: ays,
+		verificationService: vs,
+		signatureService:    sigs,
+		notificationService: ns,
+		realtimeService:     rts,
+		webhookService:      whs,
+		awardTypeService:    ats,
 	}
 }
 
 func (u *awardUseCase) SubmitAward(ctx context.Context, userID uint, input awardformdto.SubmitAwardRequest, files []models.AwardFileDirectory) error {
-	// 1. ดึงข้อมูล Academic Year ที่เปิดรับสมัคร
-	academicYear, err := u.academicYearService.GetLatestAbleRegister(ctx)
-	if err != nil || academicYear == nil {
-		return errors.New("no open registration period found")
+	// 1. ประเภทรางวัลต้องอยู่ในแคตตาล็อก (รับได้ทั้ง code และชื่อ)
+	awardType, err := u.awardTypeService.Resolve(ctx, input.AwardType)
+	if err != nil {
+		return err
 	}
 
-	// 2. เตรียม Model ตารางหลัก (Award_Form) - พื้นฐาน
+	// 2. เตรียม Model ตารางหลัก (Award_Form) - พื้นฐาน (ปี/เทอมกำหนดหลังทราบวิทยาเขตของผู้ส่ง)
 	now := time.Now()
 	form := models.AwardForm{
 		UserID:             userID,
-		AcademicYear:       academicYear.Year,
-		Semester:           academicYear.Semester,
-		AwardType:          input.AwardType,
+		AwardType:          awardType.NameTH,
+		AwardTypeID:        &awardType.AwardTypeID,
 		FormStatusID:       1,
+		SubmitterRoleID:    1,
 		CreatedAt:          now,
 		LatestUpdate:       now,
+		StepStartedAt:      now,
 		StudentYear:        input.StudentYear,
 		AdvisorName:        input.AdvisorName,
 		StudentPhoneNumber: input.StudentPhoneNumber,
@@ -83,6 +114,7 @@ func (u *awardUseCase) SubmitAward(ctx context.Context, userID uint, input award
 	// 3. เช็คว่าข้อมูลถูกส่งมาจาก Role ไหน (ดูจาก Input ที่ Handler ปั้นมาให้)
 	// 🚨 ถ้ามี FacultyID ส่งมา แสดงว่าเป็น Organization (เพราะ Student Handler ไม่ได้ดึงค่านี้มา)
 	if input.FacultyID != 0 && input.StudentNumber != "" {
+		form.SubmitterRoleID = 8
 		// ===== ROLE: ORGANIZATION (RoleID = 8) =====
 		org, orgErr := u.organizationService.GetByUserID(ctx, userID)
 		if orgErr == nil && org != nil {
@@ -97,10 +129,25 @@ func (u *awardUseCase) SubmitAward(ctx context.Context, userID uint, input award
 		form.StudentFirstname = input.StudentFirstname
 		form.StudentLastname = input.StudentLastname
 		form.StudentEmail = input.StudentEmail
-		form.StudentNumber = input.StudentNumber
+		form.StudentNumber = strings.TrimSpace(input.StudentNumber)
 		form.FacultyID = input.FacultyID
 		form.DepartmentID = input.DepartmentID
 
+		// ข้อมูลส่วนตัวให้นิสิตกรอกเองตอนยินยอม ฟอร์มจึงยังไม่เข้าสู่การพิจารณา
+		form.FormStatusID = formStatusAwaitingConsent
+		form.GPA = 0
+		form.StudentAddress = ""
+		form.StudentDateOfBirth = time.Time{}
+
+		// ผูกผู้ถูกเสนอชื่อกับข้อมูลนิสิตในระบบ (ถ้ามี) ใช้คณะ/สาขาจากข้อมูลนิสิตเป็นหลัก
+		// ผูกเฉพาะเมื่ออีเมลที่กรอกตรงกับอีเมลบัญชีของนิสิต รหัสนิสิตอย่างเดียวไม่พอยืนยันตัวตน
+		if nominee := u.matchNominee(ctx, form.StudentNumber, form.StudentEmail); nominee != nil {
+			form.NomineeStudentID = &nominee.StudentID
+			form.NomineeUserID = &nominee.UserID
+			form.FacultyID = int(nominee.FacultyID)
+			form.DepartmentID = int(nominee.DepartmentID)
+		}
+
 	} else {
 		// ===== ROLE: STUDENT (RoleID = 1) =====
 		student, studentErr := u.studentService.GetStudentByUserID(ctx, userID)
@@ -116,6 +163,8 @@ func (u *awardUseCase) SubmitAward(ctx context.Context, userID uint, input award
 		form.FacultyID = int(student.FacultyID)
 		form.DepartmentID = int(student.DepartmentID)
 		form.CampusID = student.User.CampusID
+		form.NomineeStudentID = &student.StudentID
+		form.NomineeUserID = &student.UserID
 
 		// Organization info ล้างเป็นค่าว่าง
 		form.OrgName = ""
@@ -124,10 +173,150 @@ func (u *awardUseCase) SubmitAward(ctx context.Context, userID uint, input award
 		form.OrgPhoneNumber = ""
 	}
 
-	// เรียก Repository โดยส่งไฟล์ (Slice) เข้าไปด้วย
-	return u.repo.CreateWithTransaction(ctx, &form, files)
+	// 3.1 ภาคเรียนและช่วงเวลาเปิดรับตามปฏิทินของวิทยาเขตผู้ส่ง (ช่วงเวลาของประเภทรางวัลมีผลก่อน)
+	academicYear, err := u.academicYearService.CheckSubmissionWindow(ctx, form.CampusID, &awardType.AwardTypeID, now)
+	if err != nil {
+		return err
+	}
+	form.AcademicYear = academicYear.Year
+	form.Semester = academicYear.Semester
+
+	// 4. ตรวจเงื่อนไขของประเภทรางวัล (เปิดรับในปีนี้, role ผู้ส่ง, ชั้นปี, GPA ขั้นต่ำ)
+	// GPA ของการเสนอชื่อโดยองค์กรจะตรวจตอนนิสิตยินยอม
+	candidate := AwardEligibility{RoleID: form.SubmitterRoleID, StudentYear: form.StudentYear}
+	if form.FormStatusID != formStatusAwaitingConsent {
+		candidate.GPA = &form.GPA
+	}
+	if err := u.awardTypeService.CheckEligibility(ctx, awardType, form.AcademicYear, candidate); err != nil {
+		return err
+	}
+
+	// 5. ผู้ถูกเสนอชื่อมีได้ 1 ฟอร์มต่อประเภทรางวัลต่อภาคการศึกษา (รวมภาคฤดูร้อน/ไตรภาค ตามปฏิทินของวิทยาเขต)
+	duplicate, err := u.repo.CheckDuplicate(ctx, form.StudentNumber, awardType.AwardTypeID, form.AcademicYear, form.Semester)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		termTypes, err := u.termTypes(ctx, form.CampusID)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("student %s is already nominated for %s in %s", form.StudentNumber, awardType.NameTH, termLabel(termTypes, form.AcademicYear, form.Semester))
+	}
+
+	// 6. คำตอบของฟิลด์เฉพาะประเภทรางวัลต้องตรงกับ schema
+	if form.FormAnswers, err = validateFormAnswers(awardType.FormFields, input.FormAnswers); err != nil {
+		return err
+	}
+
+	eventType := NotificationEventSubmitted
+	if form.FormStatusID == formStatusAwaitingConsent {
+		eventType = NotificationEventConsentRequested
+	}
+
+	// เรียก Repository โดยส่งไฟล์ (Slice) เข้าไปด้วย ฟอร์มกับ webhook delivery commit พร้อมกัน
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateWithTransaction(ctx, &form, files); err != nil {
+			return err
+		}
+		return u.publishWebhook(ctx, eventType, &form, form.FormStatusID, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.notify(ctx, eventType, &form, form.FormStatusID, userID, "")
+	return nil
+}
+
+// formPartyUserIDs ผู้ส่งฟอร์ม และนิสิตผู้ถูกเสนอชื่อ (ถ้าเป็นคนละคน)
+func formPartyUserIDs(form *models.AwardForm) []uint {
+	userIDs := []uint{form.UserID}
+	if form.NomineeUserID != nil && *form.NomineeUserID != form.UserID {
+		userIDs = append(userIDs, *form.NomineeUserID)
+	}
+	return userIDs
+}
+
+// isFormParty ผู้ใช้เป็นผู้ส่งฟอร์ม หรือเป็นนิสิตผู้ถูกเสนอชื่อ
+func isFormParty(form *models.AwardForm, userID uint) bool {
+	return form.UserID == userID || (form.NomineeUserID != nil && *form.NomineeUserID == userID)
+}
+
+// notify แจ้งเตือนผู้เกี่ยวข้องเมื่อฟอร์มเปลี่ยนสถานะ (หลัง commit แล้ว webhook สร้างใน transaction ผ่าน publishWebhook)
+func (u *awardUseCase) notify(ctx context.Context, eventType string, form *models.AwardForm, formStatus int, actorID uint, reason string) {
+	u.publishNotification(ctx, NotificationEvent{
+		Type:         eventType,
+		Form:         *form,
+		FormStatusID: formStatus,
+		ActorID:      actorID,
+		Reason:       reason,
+	})
+}
+
+// publishNotification ส่ง realtime และการแจ้งเตือนของ event ที่ประกอบไว้แล้ว
+func (u *awardUseCase) publishNotification(ctx context.Context, event NotificationEvent) {
+	form := &event.Form
+	if u.realtimeService != nil {
+		u.realtimeService.Publish(ctx, RealtimeEventFormStatus, form.FormID, RealtimeTarget{
+			UserIDs:  formPartyUserIDs(form),
+			CampusID: form.CampusID,
+			Roles:    realtimeStaffRoles,
+		}, realtimedto.FormStatusChanged{
+			FormID:       form.FormID,
+			FormStatusID: event.FormStatusID,
+			EventType:    event.Type,
+			ChangedBy:    event.ActorID,
+		})
+	}
+
+	if u.notificationService == nil {
+		return
+	}
+	u.notificationService.Publish(ctx, event)
+}
+
+// publishWebhook สร้าง delivery ของเหตุการณ์ฟอร์มสำหรับ webhook ภายนอก (form คือข้อมูลก่อนเปลี่ยนสถานะ)
+// เรียกด้วย ctx จาก u.repo.Transaction เพื่อให้ delivery commit พร้อมการเปลี่ยนสถานะ
+func (u *awardUseCase) publishWebhook(ctx context.Context, eventType string, form *models.AwardForm, formStatus int, actorID uint) error {
+	if u.webhookService == nil || eventType == NotificationEventAwardType {
+		return nil
+	}
+
+	data := webhookdto.AwardEventData{
+		FormID:           form.FormID,
+		StudentNumber:    form.StudentNumber,
+		StudentFirstname: form.StudentFirstname,
+		StudentLastname:  form.StudentLastname,
+		AwardType:        form.AwardType,
+		AcademicYear:     form.AcademicYear,
+		Semester:         form.Semester,
+		CampusID:         form.CampusID,
+		FacultyID:        form.FacultyID,
+		DepartmentID:     form.DepartmentID,
+		FormStatusID:     formStatus,
+		ChangedBy:        actorID,
+	}
+	if eventType == NotificationEventSubmitted || eventType == NotificationEventConsentRequested {
+		return u.webhookService.Publish(ctx, models.WebhookEventAwardSubmitted, data)
+	}
+
+	data.PreviousFormStatusID = form.FormStatusID
+	if err := u.webhookService.Publish(ctx, models.WebhookEventAwardStatusChanged, data); err != nil {
+		return err
+	}
+	if formStatus == formStatusCompleted {
+		return u.webhookService.Publish(ctx, models.WebhookEventAwardCompleted, data)
+	}
+	return nil
 }
 
+// realtimeStaffRoles ผู้พิจารณาทุกระดับในวิทยาเขตเดียวกันที่ได้รับการเปลี่ยนสถานะแบบ real-time
+var realtimeStaffRoles = []int{2, 3, 4, 5, 6, 7}
+
+// realtimeVoteRoles ผู้ที่เห็นผลนับคะแนนกรรมการแบบ real-time
+var realtimeVoteRoles = []int{5, 6, 7}
+
 func mapToAwardResponse(item models.AwardForm) awardformdto.AwardFormResponse {
 	var fileResponses []awardformdto.FileResponse
 
@@ -169,13 +358,19 @@ func mapToAwardResponse(item models.AwardForm) awardformdto.AwardFormResponse {
 		OrgPhoneNumber:     item.OrgPhoneNumber,
 		FormDetail:         item.FormDetail,
 		RejectReason:       item.RejectReason,
+		AwardTypeID:        item.AwardTypeID,
+		FormAnswers:        item.FormAnswers,
+		SubmitterRoleID:    item.SubmitterRoleID,
+		NomineeRespondedAt: item.NomineeRespondedAt,
+		NomineeStudentID:   item.NomineeStudentID,
+		NomineeUserID:      item.NomineeUserID,
 		Files:              fileResponses,
 	}
 
 	return res
 }
 
-func (u *awardUseCase) GetByKeyword(ctx context.Context, userID uint, roleID int, campusID int, keyword string, date string, studentYear int, awardType string, sortBy string, sortOrder string, page int, limit int) (*awardformdto.PaginatedAwardResponse, error) {
+func (u *awardUseCase) GetByKeyword(ctx context.Context, userID uint, roleID int, campusID int, keyword string, date string, studentYear int, academicYear int, semester int, awardType string, answers map[string]string, sortBy string, sortOrder string, page int, limit int) (*awardformdto.PaginatedAwardResponse, error) {
 	// 1. 🚨 ลบ limit = 5 ที่ฮาร์ดโค้ดไว้ออก เพื่อให้ใช้ Limit จาก Frontend ได้
 	if limit == 0 {
 		limit = 3000 // กันเหนียวกรณี Frontend ไม่ได้ส่ง limit มา
@@ -185,20 +380,33 @@ func (u *awardUseCase) GetByKeyword(ctx context.Context, userID uint, roleID int
 	normalizedSortOrder := normalizeSortOrder(sortOrder)
 
 	filter := repository.AwardSearchFilter{
-		CampusID:    campusID,
-		Keyword:     strings.TrimSpace(keyword),
-		Date:        strings.TrimSpace(date),
-		StudentYear: studentYear,
-		AwardType:   strings.TrimSpace(awardType),
-		SortBy:      normalizedSortBy,
-		SortOrder:   normalizedSortOrder,
-		Page:        page,
-		Limit:       limit,
+		CampusID:     campusID,
+		Keyword:      strings.TrimSpace(keyword),
+		Date:         strings.TrimSpace(date),
+		StudentYear:  studentYear,
+		AcademicYear: academicYear,
+		Semester:     semester,
+		AwardType:    strings.TrimSpace(awardType),
+		SortBy:       normalizedSortBy,
+		SortOrder:    normalizedSortOrder,
+		Page:         page,
+		Limit:        limit,
+	}
+	if semester > 0 {
+		termTypes, err := u.termTypes(ctx, campusID)
+		if err != nil {
+			return nil, err
+		}
+		if findTermType(termTypes, semester) == nil {
+			return nil, fmt.Errorf("invalid semester filter: semester %d is not a term of this campus calendar", semester)
+		}
 	}
 
-	if groupedTypes, isOther := mapAwardTypeSearchFilter(filter.AwardType); len(groupedTypes) > 0 {
-		filter.AwardTypes = groupedTypes
-		filter.IsOtherAwardType = isOther
+	if err := u.applyAwardTypeFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	if err := u.applyAnswerFilters(ctx, &filter, answers); err != nil {
+		return nil, err
 	}
 
 	// 2. 🚨 จัดการเรื่อง Status ตาม Role
@@ -249,9 +457,12 @@ func (u *awardUseCase) GetByKeyword(ctx context.Context, userID uint, roleID int
 		return nil, err
 	}
 
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
+	// ผลค้นหา (และการส่งออก) ผ่านการตรวจสิทธิ์และซ่อนข้อมูลส่วนบุคคลชุดเดียวกับหน้ารายละเอียด
+	// ฟอร์มที่ไม่มีสิทธิ์ถูกข้ามโดยไม่ลด total เพื่อให้จำนวนหน้ายังตรงกับการแบ่งหน้าของ query
+	viewer := &models.User{UserID: userID, RoleID: roleID, CampusID: campusID}
+	response, err := newFormReadAccess(u.repo, viewer).mapForms(ctx, results)
+	if err != nil {
+		return nil, err
 	}
 
 	// คำนวณจำนวนหน้า
@@ -297,33 +508,43 @@ func normalizeSortOrder(sortOrder string) string {
 	}
 }
 
-func mapAwardTypeSearchFilter(awardType string) ([]string, bool) {
-	normalized := strings.TrimSpace(strings.ToLower(awardType))
-	if normalized == "" {
-		return nil, false
-	}
-
-	mainCategoryTypes := []string{
-		"กิจกรรมนอกหลักสูตร",
-		"ด้านกิจกรรมเสริมหลักสูตร",
-		"ความคิดสร้างสรรค์และนวัตกรรม",
-		"ด้านความคิดสร้างสรรค์และนวัตกรรม",
-		"ความประพฤติดี",
-		"ด้านประพฤติดี",
-	}
-
-	switch normalized {
-	case "extracurricular", "กิจกรรมนอกหลักสูตร":
-		return []string{"กิจกรรมนอกหลักสูตร", "ด้านกิจกรรมเสริมหลักสูตร"}, false
-	case "creativity", "ความคิดสร้างสรรค์และนวัตกรรม":
-		return []string{"ความคิดสร้างสรรค์และนวัตกรรม", "ด้านความคิดสร้างสรรค์และนวัตกรรม"}, false
-	case "behavior", "ความประพฤติดี":
-		return []string{"ความประพฤติดี", "ด้านประพฤติดี"}, false
-	case "other", "อื่นๆ", "ประเภทอื่นๆ":
-		return mainCategoryTypes, true
-	default:
-		return nil, false
+// applyAwardTypeFilter แปลงค่า award_type ของการค้นหา (หมวด, code หรือชื่อประเภทรางวัล) เป็นเงื่อนไขตาม award_type_id
+// ค่าที่ไม่อยู่ในแคตตาล็อกจะกรองด้วยชื่อตรงตัวเหมือนเดิม
+func (u *awardUseCase) applyAwardTypeFilter(ctx context.Context, filter *repository.AwardSearchFilter) error {
+	if filter.AwardType == "" {
+		return nil
+	}
+
+	if category, ok := awardCategoryFromFilter(filter.AwardType); ok {
+		ids, err := u.awardTypeService.CategoryTypeIDs(ctx, category)
+		if err != nil {
+			return err
+		}
+		filter.IsOtherAwardType = category == models.AwardCategoryOther
+		if len(ids) == 0 && !filter.IsOtherAwardType {
+			ids = []uint{0} // หมวดที่ยังไม่มีประเภทรางวัล
+		}
+		filter.AwardTypeIDs = ids
+		return nil
+	}
+
+	if awardType, err := u.awardTypeService.Resolve(ctx, filter.AwardType); err == nil {
+		filter.AwardTypeIDs = []uint{awardType.AwardTypeID}
+	}
+	return nil
+}
+
+// applyAnswerFilters ตรวจ key ของตัวกรองคำตอบกับ schema ของประเภทรางวัลในแคตตาล็อก
+func (u *awardUseCase) applyAnswerFilters(ctx context.Context, filter *repository.AwardSearchFilter, answers map[string]string) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	awardTypes, err := u.awardTypeService.FormSchemas(ctx)
+	if err != nil {
+		return err
 	}
+	filter.AnswerFilters, err = buildAnswerFilters(awardTypes, answers)
+	return err
 }
 
 func normalizeApprovalSortBy(sortBy string) string {
@@ -402,16 +623,12 @@ func needsFacultyScopeByRole(roleID int) bool {
 	return roleID == 3 || roleID == 4
 }
 
-func (u *awardUseCase) GetAwardsByStudentID(ctx context.Context, studentID int) ([]awardformdto.AwardFormResponse, error) {
+func (u *awardUseCase) GetAwardsByStudentID(ctx context.Context, studentID int, viewer *models.User) ([]awardformdto.AwardFormResponse, error) {
 	results, err := u.repo.GetByStudentID(ctx, studentID)
 	if err != nil {
 		return nil, err
 	}
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
-	}
-	return response, nil
+	return newFormReadAccess(u.repo, viewer).mapForms(ctx, results)
 }
 
 func (u *awardUseCase) GetAwardsByUserIDAndYear(ctx context.Context, userID uint, year int) ([]awardformdto.AwardFormResponse, error) {
@@ -419,11 +636,7 @@ func (u *awardUseCase) GetAwardsByUserIDAndYear(ctx context.Context, userID uint
 	if err != nil {
 		return nil, err
 	}
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
-	}
-	return response, nil
+	return newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, results)
 }
 
 func (u *awardUseCase) GetAwardsByUserIDAndSemester(ctx context.Context, userID uint, year int, semester int) ([]awardformdto.AwardFormResponse, error) {
@@ -431,11 +644,7 @@ func (u *awardUseCase) GetAwardsByUserIDAndSemester(ctx context.Context, userID
 	if err != nil {
 		return nil, err
 	}
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
-	}
-	return response, nil
+	return newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, results)
 }
 
 func (u *awardUseCase) GetAwardsByUserIDWithYearSort(ctx context.Context, userID uint, years []int) ([]awardformdto.AwardFormResponse, error) {
@@ -443,11 +652,7 @@ func (u *awardUseCase) GetAwardsByUserIDWithYearSort(ctx context.Context, userID
 	if err != nil {
 		return nil, err
 	}
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
-	}
-	return response, nil
+	return newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, results)
 }
 
 func (u *awardUseCase) GetAwardsByUserIDPaged(ctx context.Context, userID uint, years []int, page int, limit int) (*awardformdto.PaginatedAwardResponse, error) {
@@ -463,9 +668,9 @@ func (u *awardUseCase) GetAwardsByUserIDPaged(ctx context.Context, userID uint,
 		return nil, err
 	}
 
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
+	response, err := newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, results)
+	if err != nil {
+		return nil, err
 	}
 
 	totalPages := int(total) / limit
@@ -484,7 +689,7 @@ func (u *awardUseCase) GetAwardsByUserIDPaged(ctx context.Context, userID uint,
 	}, nil
 }
 
-func (u *awardUseCase) GetByFormID(ctx context.Context, formID int) (*awardformdto.AwardFormResponse, error) {
+func (u *awardUseCase) GetByFormID(ctx context.Context, formID int, viewer *models.User) (*awardformdto.AwardFormResponse, error) {
 	form, err := u.repo.GetByFormID(ctx, formID)
 	if err != nil {
 		return nil, err
@@ -494,7 +699,22 @@ func (u *awardUseCase) GetByFormID(ctx context.Context, formID int) (*awardformd
 		return nil, errors.New("form not found")
 	}
 
+	hidden, err := newFormReadAccess(u.repo, viewer).authorize(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
 	response := mapToAwardResponse(*form)
+	response.MaskedFields = hidden
+
+	// แนบนิยามฟิลด์ของประเภทรางวัลเพื่อให้ frontend แสดงคำตอบพร้อม label
+	if form.AwardTypeID != nil {
+		awardType, err := u.awardTypeService.GetByID(ctx, *form.AwardTypeID)
+		if err != nil {
+			return nil, err
+		}
+		response.FormFields = awardType.FormFields
+	}
 	return &response, nil
 }
 
@@ -503,15 +723,27 @@ func (u *awardUseCase) GetAwardsByUserID(ctx context.Context, userID uint) ([]aw
 	if err != nil {
 		return nil, err
 	}
-	var response []awardformdto.AwardFormResponse
-	for _, item := range results {
-		response = append(response, mapToAwardResponse(item))
+	return newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, results)
+}
+
+func (u *awardUseCase) IsDuplicate(ctx context.Context, campusID int, studentNumber string, awardTypeID uint, year int, semester int) (bool, error) {
+	termTypes, err := u.termTypes(ctx, campusID)
+	if err != nil {
+		return false, err
 	}
-	return response, nil
+	if findTermType(termTypes, semester) == nil {
+		return false, fmt.Errorf("invalid semester: semester %d is not a term of this campus calendar", semester)
+	}
+	return u.repo.CheckDuplicate(ctx, studentNumber, awardTypeID, year, semester)
 }
 
-func (u *awardUseCase) IsDuplicate(userID uint, year int, semester int) (bool, error) {
-	return u.repo.CheckDuplicate(userID, year, semester)
+// termTypes ประเภทภาคการศึกษาของวิทยาเขตเรียงตามลำดับในปี (ใช้ชื่อและลำดับของภาคการศึกษา)
+func (u *awardUseCase) termTypes(ctx context.Context, campusID int) ([]models.TermType, error) {
+	result, err := u.academicYearService.GetTermTypes(ctx, campusID)
+	if err != nil {
+		return nil, err
+	}
+	return result.TermTypes, nil
 }
 
 func (u *awardUseCase) UpdateAwardType(ctx context.Context, formID uint, awardType string, changedBy uint) error {
@@ -525,11 +757,17 @@ func (u *awardUseCase) UpdateAwardType(ctx context.Context, formID uint, awardTy
 	if awardType == "" {
 		return errors.New("award_type is required")
 	}
-	if form.AwardType == awardType {
+	// เปลี่ยนได้เฉพาะประเภทในแคตตาล็อก (เจ้าหน้าที่เปลี่ยนได้โดยไม่ตรวจเงื่อนไขคุณสมบัติ)
+	catalogueType, err := u.awardTypeService.Resolve(ctx, awardType)
+	if err != nil {
+		return err
+	}
+	awardType = catalogueType.NameTH
+	if form.AwardType == awardType && form.AwardTypeID != nil && *form.AwardTypeID == catalogueType.AwardTypeID {
 		return nil
 	}
 
-	if err := u.repo.UpdateAwardType(ctx, formID, awardType); err != nil {
+	if err := u.repo.UpdateAwardType(ctx, formID, catalogueType.AwardTypeID, awardType); err != nil {
 		return err
 	}
 
@@ -545,6 +783,16 @@ func (u *awardUseCase) UpdateAwardType(ctx context.Context, formID uint, awardTy
 		return err
 	}
 
+	// แจ้งนิสิตว่าประเภทรางวัลถูกเปลี่ยน พร้อมประเภทเดิมสำหรับข้อความ
+	oldAwardType := form.AwardType
+	form.AwardType = awardType
+	u.publishNotification(ctx, NotificationEvent{
+		Type:              NotificationEventAwardType,
+		Form:              *form,
+		FormStatusID:      form.FormStatusID,
+		ActorID:           changedBy,
+		PreviousAwardType: oldAwardType,
+	})
 	return nil
 }
 
@@ -556,12 +804,18 @@ func (u *awardUseCase) UpdateFormStatus(ctx context.Context, formID uint, formSt
 	if form == nil {
 		return errors.New("form not found")
 	}
+	if err := checkConsentResolved(form); err != nil {
+		return err
+	}
 	if formStatus == 0 {
 		return errors.New("form_status is required")
 	}
 	if form.FormStatusID == formStatus {
 		return nil
 	}
+	if err := u.checkStageWindow(ctx, form); err != nil {
+		return err
+	}
 
 	trimmedRejectReason := strings.TrimSpace(rejectReason)
 	if isRejectOrReturnStatus(formStatus) && trimmedRejectReason == "" {
@@ -571,23 +825,31 @@ func (u *awardUseCase) UpdateFormStatus(ctx context.Context, formID uint, formSt
 		trimmedRejectReason = ""
 	}
 
-	if err := u.repo.UpdateFormStatus(ctx, formID, formStatus, trimmedRejectReason); err != nil {
-		return err
-	}
-
-	if approvalStatus, shouldLogApproval := mapFormStatusToApprovalStatus(formStatus); shouldLogApproval {
-		log := &models.AwardApprovalLog{
-			FormID:         formID,
-			UserID:         changedBy,
-			ApprovalStatus: approvalStatus,
-			RejectReason:   trimmedRejectReason,
-			ApprovedAt:     time.Now(),
-		}
-		if err := u.repo.CreateAwardApprovalLog(ctx, log); err != nil {
+	eventType := notificationEventForStatus(formStatus)
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateFormStatus(ctx, formID, formStatus, trimmedRejectReason); err != nil {
 			return err
 		}
+
+		if approvalStatus, shouldLogApproval := mapFormStatusToApprovalStatus(formStatus); shouldLogApproval {
+			log := &models.AwardApprovalLog{
+				FormID:         formID,
+				UserID:         changedBy,
+				ApprovalStatus: approvalStatus,
+				RejectReason:   trimmedRejectReason,
+				ApprovedAt:     time.Now(),
+			}
+			if err := u.repo.CreateAwardApprovalLog(ctx, log); err != nil {
+				return err
+			}
+		}
+		return u.publishWebhook(ctx, eventType, form, formStatus, changedBy)
+	})
+	if err != nil {
+		return err
 	}
 
+	u.notify(ctx, notificationEventForStatus(formStatus), form, formStatus, changedBy, trimmedRejectReason)
 	return nil
 }
 
@@ -600,12 +862,18 @@ func (u *awardUseCase) UpdateFormStatusWithLog(ctx context.Context, formID uint,
 	if form == nil {
 		return errors.New("form not found")
 	}
+	if err := checkConsentResolved(form); err != nil {
+		return err
+	}
 	if formStatus == 0 {
 		return errors.New("form_status is required")
 	}
 	if form.FormStatusID == formStatus {
 		return nil
 	}
+	if err := u.checkStageWindow(ctx, form); err != nil {
+		return err
+	}
 
 	trimmedRejectReason := strings.TrimSpace(rejectReason)
 
@@ -615,45 +883,42 @@ func (u *awardUseCase) UpdateFormStatusWithLog(ctx context.Context, formID uint,
 		return errors.New("reject_reason is required for rejection")
 	}
 
-	// 1. อัปเดตสถานะฟอร์ม
-	if err := u.repo.UpdateFormStatus(ctx, formID, formStatus, trimmedRejectReason); err != nil {
-		return err
-	}
-
-	// 2. กำหนด logType
-	logType := "approval"
-	if formStatus == 3 {
-		logType = "rejection"
-	}
-
-	// 3. บันทึก award type log (เก็บ old/new เฉพาะกรณีมีการเปลี่ยนประเภท)
-	typeLog := &models.AwardTypeLog{
-		FormID:    formID,
-		UserID:    changedBy,
-		LogType:   logType,
-		ChangedAt: time.Now(),
-	}
+	eventType := notificationEventForStatus(formStatus)
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		// 1. อัปเดตสถานะฟอร์ม (พร้อมลายมือชื่อดิจิทัลถ้าเป็นขั้นที่ต้องลงนาม)
+		if err := u.updateStatusAndSign(ctx, formID, formStatus, trimmedRejectReason, changedBy); err != nil {
+			return err
+		}
 
-	// สำหรับการตีกลับ บันทึกเหตุผล
-	if formStatus == 3 {
-		typeLog.RejectReason = trimmedRejectReason
-	}
+		// 2. กำหนด logType
+		logType := "approval"
+		if formStatus == 3 {
+			logType = "rejection"
+		}
 
-	if err := u.repo.SaveAwardTypeLog(ctx, typeLog); err != nil {
-		return err
-	}
+		// 3. บันทึก award type log (เก็บ old/new เฉพาะกรณีมีการเปลี่ยนประเภท)
+		typeLog := &models.AwardTypeLog{
+			FormID:    formID,
+			UserID:    changedBy,
+			LogType:   logType,
+			ChangedAt: time.Now(),
+		}
 
-	if shouldLogSigned := shouldCreateSignedLog(formStatus); shouldLogSigned {
-		signedLog := &models.AwardSignedLog{
-			FormID:   formID,
-			UserID:   changedBy,
-			SignedAt: time.Now(),
+		// สำหรับการตีกลับ บันทึกเหตุผล
+		if formStatus == 3 {
+			typeLog.RejectReason = trimmedRejectReason
 		}
-		if err := u.repo.CreateAwardSignedLog(ctx, signedLog); err != nil {
+
+		if err := u.repo.SaveAwardTypeLog(ctx, typeLog); err != nil {
 			return err
 		}
+		return u.publishWebhook(ctx, eventType, form, formStatus, changedBy)
+	})
+	if err != nil {
+		return err
 	}
 
+	u.notify(ctx, notificationEventForStatus(formStatus), form, formStatus, changedBy, trimmedRejectReason)
 	return nil
 }
 
@@ -665,12 +930,18 @@ func (u *awardUseCase) UpdateFormStatusWithSignedLog(ctx context.Context, formID
 	if form == nil {
 		return errors.New("form not found")
 	}
+	if err := checkConsentResolved(form); err != nil {
+		return err
+	}
 	if formStatus == 0 {
 		return errors.New("form_status is required")
 	}
 	if form.FormStatusID == formStatus {
 		return nil
 	}
+	if err := u.checkStageWindow(ctx, form); err != nil {
+		return err
+	}
 
 	trimmedRejectReason := strings.TrimSpace(rejectReason)
 	if isRejectOrReturnStatus(formStatus) && trimmedRejectReason == "" {
@@ -680,24 +951,41 @@ func (u *awardUseCase) UpdateFormStatusWithSignedLog(ctx context.Context, formID
 		trimmedRejectReason = ""
 	}
 
-	if err := u.repo.UpdateFormStatus(ctx, formID, formStatus, trimmedRejectReason); err != nil {
-		return err
-	}
-
-	if shouldLogSigned := shouldCreateSignedLog(formStatus); shouldLogSigned {
-		signedLog := &models.AwardSignedLog{
-			FormID:   formID,
-			UserID:   changedBy,
-			SignedAt: time.Now(),
-		}
-		if err := u.repo.CreateAwardSignedLog(ctx, signedLog); err != nil {
+	eventType := notificationEventForStatus(formStatus)
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.updateStatusAndSign(ctx, formID, formStatus, trimmedRejectReason, changedBy); err != nil {
 			return err
 		}
+		return u.publishWebhook(ctx, eventType, form, formStatus, changedBy)
+	})
+	if err != nil {
+		return err
 	}
 
+	u.notify(ctx, notificationEventForStatus(formStatus), form, formStatus, changedBy, trimmedRejectReason)
 	return nil
 }
 
+// updateStatusAndSign เปลี่ยนสถานะ ถ้าเป็นขั้นที่ต้องลงนามจะลงลายมือชื่อดิจิทัลบน canonical hash ใน transaction เดียวกัน
+// ฟอร์มเสร็จสิ้นจะออกรหัสตรวจสอบเกียรติบัตรใน transaction นั้นด้วย ออกรหัสไม่สำเร็จ = สถานะไม่เปลี่ยน
+func (u *awardUseCase) updateStatusAndSign(ctx context.Context, formID uint, formStatus int, rejectReason string, changedBy uint) error {
+	if !shouldCreateSignedLog(formStatus) {
+		return u.repo.UpdateFormStatus(ctx, formID, formStatus, rejectReason)
+	}
+	return u.repo.UpdateFormStatusAndSign(ctx, formID, formStatus, rejectReason, func(ctx context.Context, form *models.AwardForm) (*models.AwardSignedLog, error) {
+		signedLog, err := u.signatureService.SignForm(ctx, form, formStatus, changedBy)
+		if err != nil {
+			return nil, err
+		}
+		if formStatus == formStatusCompleted {
+			if _, err := u.verificationService.IssueForForm(ctx, formID); err != nil {
+				return nil, err
+			}
+		}
+		return signedLog, nil
+	})
+}
+
 func (u *awardUseCase) IsCommitteeChairman(ctx context.Context, userID uint) (bool, error) {
 	if userID == 0 {
 		return false, errors.New("invalid user id")
@@ -705,6 +993,18 @@ func (u *awardUseCase) IsCommitteeChairman(ctx context.Context, userID uint) (bo
 	return u.repo.IsCommitteeChairman(ctx, userID)
 }
 
+// IsFormCommitteeChairman ผู้ใช้เป็นประธานคณะกรรมการของภาคเรียนของฟอร์มหรือไม่ (ใช้ตรวจสิทธิ์ลงนามฟอร์มนั้น)
+func (u *awardUseCase) IsFormCommitteeChairman(ctx context.Context, formID uint, userID uint) (bool, error) {
+	form, err := u.repo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		return false, err
+	}
+	if form == nil {
+		return false, errors.New("form not found")
+	}
+	return u.repo.IsFormCommitteeMember(ctx, form, userID, true)
+}
+
 func (u *awardUseCase) CommitteeVote(ctx context.Context, formID uint, operation string, votedBy uint) (*awardformdto.CommitteeVoteResult, error) {
 	form, err := u.repo.GetByFormID(ctx, int(formID))
 	if err != nil {
@@ -713,8 +1013,11 @@ func (u *awardUseCase) CommitteeVote(ctx context.Context, formID uint, operation
 	if form == nil {
 		return nil, errors.New("form not found")
 	}
+	if err := u.checkStageWindow(ctx, form); err != nil {
+		return nil, err
+	}
 
-	isEligible, err := u.repo.IsCommitteeNonChairman(ctx, votedBy)
+	isEligible, err := u.repo.IsFormCommitteeMember(ctx, form, votedBy, false)
 	if err != nil {
 		return nil, err
 	}
@@ -740,7 +1043,7 @@ func (u *awardUseCase) CommitteeVote(ctx context.Context, formID uint, operation
 		return nil, err
 	}
 
-	totalCommittee, err := u.repo.CountNonChairmanCommittees(ctx)
+	totalCommittee, err := u.repo.CountFormNonChairmanCommittee(ctx, form)
 	if err != nil {
 		return nil, err
 	}
@@ -762,19 +1065,15 @@ func (u *awardUseCase) CommitteeVote(ctx context.Context, formID uint, operation
 	hasRejectMajority := rejectCount > half
 	hasMajority := hasApproveMajority || hasRejectMajority
 	currentFormStatusID := form.FormStatusID
+	rejectReason := ""
 	if hasApproveMajority {
-		if err := u.repo.UpdateFormStatus(ctx, formID, 9, ""); err != nil {
-			return nil, err
-		}
 		currentFormStatusID = 9
 	} else if hasRejectMajority {
-		if err := u.repo.UpdateFormStatus(ctx, formID, 10, "คณะกรรมการไม่เห็นชอบ"); err != nil {
-			return nil, err
-		}
 		currentFormStatusID = 10
+		rejectReason = "คณะกรรมการไม่เห็นชอบ"
 	}
 
-	return &awardformdto.CommitteeVoteResult{
+	result := &awardformdto.CommitteeVoteResult{
 		Operation:      normalized,
 		ApproveCount:   approvedCount,
 		RejectCount:    rejectCount,
@@ -783,7 +1082,56 @@ func (u *awardUseCase) CommitteeVote(ctx context.Context, formID uint, operation
 		HasMajority:    hasMajority,
 		MajorityTarget: (totalCommittee / 2) + 1,
 		FormStatusID:   currentFormStatusID,
-	}, nil
+	}
+
+	// สถานะจากเสียงข้างมากกับ webhook ของการลงคะแนน commit พร้อมกัน
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if currentFormStatusID != form.FormStatusID {
+			if err := u.repo.UpdateFormStatus(ctx, formID, currentFormStatusID, rejectReason); err != nil {
+				return err
+			}
+			if err := u.publishWebhook(ctx, NotificationEventVoteMajority, form, currentFormStatusID, votedBy); err != nil {
+				return err
+			}
+		}
+		if u.webhookService == nil {
+			return nil
+		}
+		return u.webhookService.Publish(ctx, models.WebhookEventCommitteeVoteCast, webhookdto.VoteCastData{
+			FormID:         formID,
+			ApproveCount:   result.ApproveCount,
+			RejectCount:    result.RejectCount,
+			TotalVoters:    result.TotalVoters,
+			VotedCount:     result.VotedCount,
+			HasMajority:    result.HasMajority,
+			MajorityTarget: result.MajorityTarget,
+			FormStatusID:   result.FormStatusID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if currentFormStatusID != form.FormStatusID {
+		u.notify(ctx, NotificationEventVoteMajority, form, currentFormStatusID, votedBy, "")
+	}
+
+	if u.realtimeService != nil {
+		u.realtimeService.Publish(ctx, RealtimeEventVoteTally, formID, RealtimeTarget{
+			CampusID: form.CampusID,
+			Roles:    realtimeVoteRoles,
+		}, realtimedto.VoteTally{
+			FormID:         formID,
+			ApproveCount:   result.ApproveCount,
+			RejectCount:    result.RejectCount,
+			TotalVoters:    result.TotalVoters,
+			VotedCount:     result.VotedCount,
+			HasMajority:    result.HasMajority,
+			MajorityTarget: result.MajorityTarget,
+			FormStatusID:   result.FormStatusID,
+		})
+	}
+
+	return result, nil
 }
 
 func mapFormStatusToApprovalStatus(formStatus int) (string, bool) {
@@ -806,6 +1154,18 @@ func shouldCreateSignedLog(formStatus int) bool {
 	}
 }
 
+// pendingStageByStatus ขั้นพิจารณา (role) ที่ฟอร์มในสถานะนั้นรออยู่
+var pendingStageByStatus = map[int]int{1: 2, 2: 3, 4: 4, 6: 5, 8: 6, 9: 6, 11: 7}
+
+// checkStageWindow ตรวจช่วงเวลาพิจารณาของขั้นที่ฟอร์มรออยู่ ตามปฏิทินของวิทยาเขตของฟอร์ม
+func (u *awardUseCase) checkStageWindow(ctx context.Context, form *models.AwardForm) error {
+	stage, ok := pendingStageByStatus[form.FormStatusID]
+	if !ok {
+		return nil
+	}
+	return u.academicYearService.CheckStageWindow(ctx, form.CampusID, form.AcademicYear, form.Semester, form.AwardTypeID, stage, time.Now())
+}
+
 func isRejectOrReturnStatus(formStatus int) bool {
 	switch formStatus {
 	case 3, 5, 7, 10:
@@ -815,8 +1175,22 @@ func isRejectOrReturnStatus(formStatus int) bool {
 	}
 }
 
-func (u *awardUseCase) GetAllAwardTypes(ctx context.Context) ([]string, error) {
-	return u.repo.GetAllAwardTypes(ctx)
+// GetAllAwardTypes ชื่อประเภทรางวัลที่เปิดรับในปีการศึกษาที่เปิดรับสมัครล่าสุดของวิทยาเขต (รายละเอียดเต็มอยู่ที่ /api/award-types)
+func (u *awardUseCase) GetAllAwardTypes(ctx context.Context, campusID int) ([]string, error) {
+	academicYear := 0
+	if current, err := u.academicYearService.GetLatestAbleRegister(ctx, campusID); err == nil && current != nil {
+		academicYear = current.Year
+	}
+
+	awardTypes, err := u.awardTypeService.GetAll(ctx, academicYear, false)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(awardTypes))
+	for _, awardType := range awardTypes {
+		names = append(names, awardType.NameTH)
+	}
+	return names, nil
 }
 
 func (u *awardUseCase) GetAwardTypeLogs(ctx context.Context, req awardformdto.SearchAwardTypeLogRequest) ([]awardformdto.AwardTypeLogResponse, error) {
@@ -850,22 +1224,63 @@ func (u *awardUseCase) GetAwardTypeLogs(ctx context.Context, req awardformdto.Se
 	return response, nil
 }
 
-func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (*awardformdto.PaginatedAnnouncementAwardResponse, error) {
+// announcementSection หมวดของหน้าประกาศผล (หนึ่งหมวดต่อหมวดของแคตตาล็อกประเภทรางวัล)
+type announcementSection struct {
+	key   string
+	label string
+}
+
+var announcementSections = func() []announcementSection {
+	sections := make([]announcementSection, 0, len(awardCategories))
+	for _, category := range awardCategories {
+		sections = append(sections, announcementSection{key: category.key, label: category.label})
+	}
+	return sections
+}()
+
+// announcementSectionOf หมวดของหน้าประกาศผลจากหมวดของประเภทรางวัล (ไม่รู้จัก = อื่นๆ)
+func announcementSectionOf(category string) announcementSection {
+	for _, section := range announcementSections {
+		if section.key == category {
+			return section
+		}
+	}
+	return announcementSections[len(announcementSections)-1]
+}
+
+// announcementTerm ปี/ภาคเรียนที่ประกาศผลเลือกใช้ พร้อมตัวเลือกสำหรับตัวกรองของหน้าประกาศ
+type announcementTerm struct {
+	year            int
+	semester        int
+	topAwardTypes   []string
+	semesterOptions []int
+	termTypes       []models.TermType
+}
+
+// resolveAnnouncementTerm ใช้ปี/ภาคเรียนตามคำขอ ถ้าไม่ระบุใช้ประกาศล่าสุด (ยังไม่มีประกาศ = ภาคเรียนปัจจุบัน)
+func (u *awardUseCase) resolveAnnouncementTerm(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (*announcementTerm, error) {
 	if campusID == 0 {
 		return nil, errors.New("invalid campus id")
 	}
 
-	const sectionLimit = 1000
-
 	latestYear, latestSemester, topAwardTypes, err := u.repo.GetAnnouncementDefaults(ctx, campusID)
 	if err != nil {
 		return nil, err
 	}
+	// ยังไม่มีประกาศที่เผยแพร่ ใช้ภาคเรียนปัจจุบันตามปฏิทินของวิทยาเขต
+	if latestYear == 0 {
+		if current, err := u.academicYearService.GetCurrentSemester(ctx, campusID); err == nil && current != nil {
+			latestYear, latestSemester = current.Year, current.Semester
+		}
+	}
 
-	academicYearOptions, err := u.repo.GetAnnouncementAcademicYears(ctx, campusID)
+	termTypes, err := u.termTypes(ctx, campusID)
 	if err != nil {
 		return nil, err
 	}
+	if req.Semester > 0 && findTermType(termTypes, req.Semester) == nil {
+		return nil, fmt.Errorf("invalid semester filter: semester %d is not a term of this campus calendar", req.Semester)
+	}
 
 	selectedYear := req.AcademicYear
 	if selectedYear == 0 {
@@ -876,6 +1291,8 @@ func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int,
 	if err != nil {
 		return nil, err
 	}
+	// เรียงตามลำดับภาคการศึกษาของวิทยาเขต (ล่าสุดก่อน) แทนเลขภาคเรียน
+	sortSemestersByTerm(termTypes, semesterOptions)
 
 	selectedSemester := req.Semester
 	if selectedSemester == 0 {
@@ -886,57 +1303,135 @@ func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int,
 		}
 	}
 
-	const fixedSortBy = "name"
-	const fixedSortOrder = "asc"
+	return &announcementTerm{
+		year:            selectedYear,
+		semester:        selectedSemester,
+		topAwardTypes:   topAwardTypes,
+		semesterOptions: semesterOptions,
+		termTypes:       termTypes,
+	}, nil
+}
 
-	fixedCategoryTypes := []string{
-		"กิจกรรมนอกหลักสูตร",
-		"ด้านกิจกรรมเสริมหลักสูตร",
-		"ความคิดสร้างสรรค์และนวัตกรรม",
-		"ด้านความคิดสร้างสรรค์และนวัตกรรม",
-		"ความประพฤติดี",
-		"ด้านประพฤติดี",
+// announcementItem แปลงแถวของประกาศเป็นรายการในหมวด label
+func announcementItem(row repository.AnnouncementAwardRow, label string) awardformdto.AnnouncementAwardItem {
+	return awardformdto.AnnouncementAwardItem{
+		FormID:           row.FormID,
+		CampusID:         row.CampusID,
+		AcademicYear:     row.AcademicYear,
+		Semester:         row.Semester,
+		AwardType:        row.AwardType,
+		AwardTypeGroup:   label,
+		FacultyID:        row.FacultyID,
+		FacultyName:      row.FacultyName,
+		StudentNumber:    row.StudentNumber,
+		Prefix:           row.Prefix,
+		StudentFirstname: row.StudentFirstname,
+		StudentLastname:  row.StudentLastname,
+		DisplayName:      strings.TrimSpace(strings.TrimSpace(row.Prefix) + " " + strings.TrimSpace(row.StudentFirstname) + " " + strings.TrimSpace(row.StudentLastname)),
 	}
+}
+
+// announcementKeyword คำค้นของหมวด key ในคำขอหน้าประกาศ
+func announcementKeyword(req awardformdto.AnnouncementAwardRequest, key string) string {
+	switch key {
+	case "extracurricular":
+		return strings.TrimSpace(req.KeywordExtracurricular)
+	case "creativity":
+		return strings.TrimSpace(req.KeywordCreativity)
+	case "behavior":
+		return strings.TrimSpace(req.KeywordBehavior)
+	default:
+		return strings.TrimSpace(req.KeywordOther)
+	}
+}
+
+func (u *awardUseCase) ResolveAnnouncementRequest(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (awardformdto.AnnouncementAwardRequest, error) {
+	term, err := u.resolveAnnouncementTerm(ctx, campusID, req)
+	if err != nil {
+		return req, err
+	}
+	req.AcademicYear = term.year
+	req.Semester = term.semester
+	return req, nil
+}
+
+func (u *awardUseCase) EachAnnouncementAward(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest, batchSize int, emit func(awardformdto.AnnouncementAwardItem) error) error {
+	for _, section := range announcementSections {
+		for page := 1; ; page++ {
+			filter := repository.AnnouncementFilter{
+				CampusID:     campusID,
+				Keyword:      announcementKeyword(req, section.key),
+				AcademicYear: req.AcademicYear,
+				Semester:     req.Semester,
+				Page:         page,
+				Limit:        batchSize,
+				SortBy:       "name",
+				SortOrder:    "asc",
+			}
+			rows, total, err := u.repo.GetAnnouncementAwardsByCategory(ctx, filter, section.key)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if err := emit(announcementItem(row, section.label)); err != nil {
+					return err
+				}
+			}
+			if len(rows) == 0 || int64(page*batchSize) >= total {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int, req awardformdto.AnnouncementAwardRequest) (*awardformdto.PaginatedAnnouncementAwardResponse, error) {
+	const sectionLimit = 1000
+
+	term, err := u.resolveAnnouncementTerm(ctx, campusID, req)
+	if err != nil {
+		return nil, err
+	}
+	selectedYear, selectedSemester := term.year, term.semester
+	topAwardTypes, semesterOptions := term.topAwardTypes, term.semesterOptions
+
+	academicYearOptions, err := u.repo.GetAnnouncementAcademicYears(ctx, campusID)
+	if err != nil {
+		return nil, err
+	}
+
+	termOptions := make([]awardformdto.TermOption, 0, len(semesterOptions))
+	for _, semester := range semesterOptions {
+		termOptions = append(termOptions, awardformdto.TermOption{Label: termName(term.termTypes, semester), Value: semester})
+	}
+
+	const fixedSortBy = "name"
+	const fixedSortOrder = "asc"
 
 	type sectionConfig struct {
-		key         string
-		label       string
-		keyword     string
-		page        int
-		awardTypes  []string
-		isOtherType bool
-	}
-
-	sectionsCfg := []sectionConfig{
-		{
-			key:        "extracurricular",
-			label:      "กิจกรรมนอกหลักสูตร",
-			keyword:    strings.TrimSpace(req.KeywordExtracurricular),
-			page:       req.PageExtracurricular,
-			awardTypes: []string{"กิจกรรมนอกหลักสูตร", "ด้านกิจกรรมเสริมหลักสูตร"},
-		},
-		{
-			key:        "creativity",
-			label:      "ความคิดสร้างสรรค์และนวัตกรรม",
-			keyword:    strings.TrimSpace(req.KeywordCreativity),
-			page:       req.PageCreativity,
-			awardTypes: []string{"ความคิดสร้างสรรค์และนวัตกรรม", "ด้านความคิดสร้างสรรค์และนวัตกรรม"},
-		},
-		{
-			key:        "behavior",
-			label:      "ความประพฤติดี",
-			keyword:    strings.TrimSpace(req.KeywordBehavior),
-			page:       req.PageBehavior,
-			awardTypes: []string{"ความประพฤติดี", "ด้านประพฤติดี"},
-		},
-		{
-			key:         "other",
-			label:       "อื่นๆ",
-			keyword:     strings.TrimSpace(req.KeywordOther),
-			page:        req.PageOther,
-			awardTypes:  fixedCategoryTypes,
-			isOtherType: true,
-		},
+		announcementSection
+		keyword string
+		page    int
+	}
+
+	sectionInputs := map[string]struct {
+		keyword string
+		page    int
+	}{
+		"extracurricular": {req.KeywordExtracurricular, req.PageExtracurricular},
+		"creativity":      {req.KeywordCreativity, req.PageCreativity},
+		"behavior":        {req.KeywordBehavior, req.PageBehavior},
+		"other":           {req.KeywordOther, req.PageOther},
+	}
+
+	sectionsCfg := make([]sectionConfig, 0, len(announcementSections))
+	for _, section := range announcementSections {
+		input := sectionInputs[section.key]
+		sectionsCfg = append(sectionsCfg, sectionConfig{
+			announcementSection: section,
+			keyword:             strings.TrimSpace(input.keyword),
+			page:                input.page,
+		})
 	}
 
 	sections := make([]awardformdto.AnnouncementAwardSection, 0, len(sectionsCfg))
@@ -963,7 +1458,7 @@ func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int,
 			SortOrder:    fixedSortOrder,
 		}
 
-		rows, total, fetchErr := u.repo.GetAnnouncementAwardsByCategory(ctx, filter, section.awardTypes, section.isOtherType)
+		rows, total, fetchErr := u.repo.GetAnnouncementAwardsByCategory(ctx, filter, section.key)
 		if fetchErr != nil {
 			return nil, fetchErr
 		}
@@ -978,21 +1473,7 @@ func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int,
 
 		sectionData := make([]awardformdto.AnnouncementAwardItem, 0, len(rows))
 		for _, row := range rows {
-			sectionData = append(sectionData, awardformdto.AnnouncementAwardItem{
-				FormID:           row.FormID,
-				CampusID:         row.CampusID,
-				AcademicYear:     row.AcademicYear,
-				Semester:         row.Semester,
-				AwardType:        row.AwardType,
-				AwardTypeGroup:   section.label,
-				FacultyID:        row.FacultyID,
-				FacultyName:      row.FacultyName,
-				StudentNumber:    row.StudentNumber,
-				Prefix:           row.Prefix,
-				StudentFirstname: row.StudentFirstname,
-				StudentLastname:  row.StudentLastname,
-				DisplayName:      strings.TrimSpace(strings.TrimSpace(row.Prefix) + " " + strings.TrimSpace(row.StudentFirstname) + " " + strings.TrimSpace(row.StudentLastname)),
-			})
+			sectionData = append(sectionData, announcementItem(row, section.label))
 		}
 
 		sections = append(sections, awardformdto.AnnouncementAwardSection{
@@ -1039,6 +1520,7 @@ func (u *awardUseCase) GetAnnouncementAwards(ctx context.Context, campusID int,
 			AcademicYears:    academicYearOptions,
 			Semester:         selectedSemester,
 			Semesters:        semesterOptions,
+			TermOptions:      termOptions,
 			AwardType:        "",
 			FacultyID:        0,
 			Keyword:          "",
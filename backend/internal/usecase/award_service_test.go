@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// formStatusDriver ฐานข้อมูลจำลองที่มีฟอร์มเดียว คืนแถวของ Award_Form และบันทึกคำสั่งเขียนทั้งหมด
+type formStatusDriver struct {
+	mu           sync.Mutex
+	formStatusID int64
+	writes       []string
+}
+
+func (d *formStatusDriver) Open(string) (driver.Conn, error) { return &formStatusConn{d: d}, nil }
+
+type formStatusConn struct{ d *formStatusDriver }
+
+func (c *formStatusConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *formStatusConn) Close() error              { return nil }
+func (c *formStatusConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *formStatusConn) Commit() error             { return nil }
+func (c *formStatusConn) Rollback() error           { return nil }
+
+func (c *formStatusConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.writes = append(c.d.writes, query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *formStatusConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if strings.Contains(query, `FROM "Award_Form"`) {
+		return &formStatusRows{columns: []string{"form_id", "form_status_id"}, values: [][]driver.Value{{int64(1), c.d.formStatusID}}}, nil
+	}
+	if !strings.HasPrefix(strings.TrimSpace(strings.ToUpper(query)), "SELECT") {
+		c.d.mu.Lock()
+		c.d.writes = append(c.d.writes, query)
+		c.d.mu.Unlock()
+	}
+	return &formStatusRows{}, nil
+}
+
+type formStatusRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *formStatusRows) Columns() []string { return r.columns }
+func (r *formStatusRows) Close() error      { return nil }
+func (r *formStatusRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newFormStatusTestUseCase(t *testing.T, formStatusID int) (*awardUseCase, *formStatusDriver) {
+	t.Helper()
+	d := &formStatusDriver{formStatusID: int64(formStatusID)}
+	name := "form-status-" + t.Name()
+	sql.Register(name, d)
+	sqlDB, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &awardUseCase{repo: repository.NewAwardRepository(db)}, d
+}
+
+func TestUpdateFormStatusRejectsFormsWithoutNomineeConsent(t *testing.T) {
+	for _, formStatusID := range []int{formStatusAwaitingConsent, formStatusConsentDeclined} {
+		t.Run(fmt.Sprintf("status %d", formStatusID), func(t *testing.T) {
+			u, d := newFormStatusTestUseCase(t, formStatusID)
+			ctx := context.Background()
+			updates := map[string]func() error{
+				"UpdateFormStatus":              func() error { return u.UpdateFormStatus(ctx, 1, 2, "", 10) },
+				"UpdateFormStatusWithLog":       func() error { return u.UpdateFormStatusWithLog(ctx, 1, 8, "", 10) },
+				"UpdateFormStatusWithSignedLog": func() error { return u.UpdateFormStatusWithSignedLog(ctx, 1, 12, "", 10) },
+			}
+			for name, update := range updates {
+				if err := update(); !errors.Is(err, ErrNominationPendingConsent) {
+					t.Errorf("%s: got %v, want ErrNominationPendingConsent", name, err)
+				}
+			}
+			if len(d.writes) > 0 {
+				t.Errorf("form was written: %v", d.writes)
+			}
+		})
+	}
+}
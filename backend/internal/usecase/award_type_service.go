@@ -0,0 +1,466 @@
+package usecase
+
+import (
+	awardtypedto "backend/internal/dto/award_type_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// awardCategories หมวดของประเภทรางวัลตามลำดับที่แสดงในหน้าประกาศผล
+var awardCategories = []struct {
+	key   string
+	label string
+}{
+	{models.AwardCategoryExtracurricular, "กิจกรรมนอกหลักสูตร"},
+	{models.AwardCategoryCreativity, "ความคิดสร้างสรรค์และนวัตกรรม"},
+	{models.AwardCategoryBehavior, "ความประพฤติดี"},
+	{models.AwardCategoryOther, "อื่นๆ"},
+}
+
+var awardTypeCodePattern = regexp.MustCompile(`^[A-Z0-9_]{2,50}$`)
+
+// awardSubmitterRoles role ที่ส่งฟอร์มได้ (นิสิต, องค์กร)
+var awardSubmitterRoles = map[int]bool{1: true, 8: true}
+
+func awardCategoryLabel(category string) string {
+	for _, c := range awardCategories {
+		if c.key == category {
+			return c.label
+		}
+	}
+	return ""
+}
+
+// awardCategoryFromFilter แปลงค่าตัวกรองของหน้าค้นหา (key หรือชื่อหมวดภาษาไทย) เป็นหมวด
+func awardCategoryFromFilter(value string) (string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "ประเภทอื่นๆ" {
+		return models.AwardCategoryOther, true
+	}
+	for _, c := range awardCategories {
+		if normalized == c.key || normalized == c.label {
+			return c.key, true
+		}
+	}
+	return "", false
+}
+
+// AwardEligibility ข้อมูลผู้ถูกเสนอชื่อที่ใช้ตรวจเงื่อนไขของประเภทรางวัล
+type AwardEligibility struct {
+	RoleID      int
+	StudentYear int
+	GPA         *float64 // nil = ยังไม่ทราบ (องค์กรเสนอชื่อ นิสิตกรอกเองตอนยินยอม) จะยังไม่ตรวจ GPA ขั้นต่ำ
+}
+
+type AwardTypeService interface {
+	// GetAll academicYear > 0 จะคำนวณสถานะเปิด/ปิดของปีนั้น includeInactive = false ตัดประเภทที่ปิดอยู่ออก
+	GetAll(ctx context.Context, academicYear int, includeInactive bool) ([]awardtypedto.AwardTypeResponse, error)
+	GetByID(ctx context.Context, awardTypeID uint) (*awardtypedto.AwardTypeResponse, error)
+	Create(ctx context.Context, req awardtypedto.AwardTypeRequest) (*awardtypedto.AwardTypeResponse, error)
+	Update(ctx context.Context, awardTypeID uint, req awardtypedto.AwardTypeRequest) (*awardtypedto.AwardTypeResponse, error)
+	SetYearActive(ctx context.Context, awardTypeID uint, academicYear int, req awardtypedto.SetYearActiveRequest) (*awardtypedto.AwardTypeResponse, error)
+	// UpdateFormSchema แทนที่นิยามฟิลด์ของฟอร์ม คำตอบของฟอร์มที่ส่งไปแล้วไม่ถูกแก้ไข
+	UpdateFormSchema(ctx context.Context, awardTypeID uint, req awardtypedto.FormSchemaRequest) (*awardtypedto.AwardTypeResponse, error)
+
+	// Resolve หาประเภทรางวัลจาก code, ชื่อไทย/อังกฤษ หรือชื่อเดิม (LegacyNames)
+	Resolve(ctx context.Context, value string) (*models.AwardType, error)
+	// CheckEligibility ตรวจว่าประเภทรางวัลเปิดในปีการศึกษา และผู้ถูกเสนอชื่อผ่านเงื่อนไขทั้งหมด
+	CheckEligibility(ctx context.Context, awardType *models.AwardType, academicYear int, candidate AwardEligibility) error
+	// CategoryTypeIDs award_type_id ของประเภทในหมวด category (หมวด other คืนประเภทของหมวดหลักทั้งหมด)
+	CategoryTypeIDs(ctx context.Context, category string) ([]uint, error)
+	// FormSchemas ประเภทรางวัลทั้งหมดพร้อมนิยามฟิลด์ ใช้ตรวจตัวกรองคำตอบของการค้นหา
+	FormSchemas(ctx context.Context) ([]models.AwardType, error)
+}
+
+type awardTypeService struct {
+	repo repository.AwardTypeRepository
+}
+
+func NewAwardTypeService(repo repository.AwardTypeRepository) AwardTypeService {
+	return &awardTypeService{repo: repo}
+}
+
+func (s *awardTypeService) GetAll(ctx context.Context, academicYear int, includeInactive bool) ([]awardtypedto.AwardTypeResponse, error) {
+	awardTypes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var yearSettings map[uint]bool
+	if academicYear > 0 {
+		if yearSettings, err = s.repo.GetYearSettings(ctx, academicYear); err != nil {
+			return nil, err
+		}
+	}
+
+	response := make([]awardtypedto.AwardTypeResponse, 0, len(awardTypes))
+	for i := range awardTypes {
+		item := mapToAwardTypeResponse(&awardTypes[i])
+		active := awardTypes[i].IsActive
+		if academicYear > 0 {
+			if yearActive, ok := yearSettings[awardTypes[i].AwardTypeID]; ok {
+				active = yearActive
+			}
+			item.AcademicYear = academicYear
+			item.ActiveInYear = &active
+		}
+		if !active && !includeInactive {
+			continue
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+func (s *awardTypeService) GetByID(ctx context.Context, awardTypeID uint) (*awardtypedto.AwardTypeResponse, error) {
+	awardType, err := s.get(ctx, awardTypeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, awardType)
+}
+
+func (s *awardTypeService) Create(ctx context.Context, req awardtypedto.AwardTypeRequest) (*awardtypedto.AwardTypeResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !awardTypeCodePattern.MatchString(code) {
+		return nil, errors.New("code must be 2-50 characters of A-Z, 0-9 or _")
+	}
+	if _, err := s.repo.GetByCode(ctx, code); err == nil {
+		return nil, fmt.Errorf("award type code %s already exists", code)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	awardType := &models.AwardType{Code: code, IsActive: true, CreatedAt: now}
+	if err := s.apply(ctx, awardType, req); err != nil {
+		return nil, err
+	}
+	awardType.UpdatedAt = now
+	if err := s.repo.Create(ctx, awardType); err != nil {
+		return nil, err
+	}
+	if err := s.linkForms(ctx, awardType); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, awardType)
+}
+
+func (s *awardTypeService) Update(ctx context.Context, awardTypeID uint, req awardtypedto.AwardTypeRequest) (*awardtypedto.AwardTypeResponse, error) {
+	awardType, err := s.get(ctx, awardTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if code := strings.TrimSpace(req.Code); code != "" && !strings.EqualFold(code, awardType.Code) {
+		return nil, errors.New("code cannot be changed")
+	}
+
+	if err := s.apply(ctx, awardType, req); err != nil {
+		return nil, err
+	}
+	awardType.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, awardType); err != nil {
+		return nil, err
+	}
+	if err := s.linkForms(ctx, awardType); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, awardType)
+}
+
+func (s *awardTypeService) SetYearActive(ctx context.Context, awardTypeID uint, academicYear int, req awardtypedto.SetYearActiveRequest) (*awardtypedto.AwardTypeResponse, error) {
+	if academicYear <= 0 {
+		return nil, errors.New("invalid academic year")
+	}
+	if req.IsActive == nil {
+		return nil, errors.New("is_active is required")
+	}
+	awardType, err := s.get(ctx, awardTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveYearSetting(ctx, &models.AwardTypeYear{
+		AwardTypeID:  awardType.AwardTypeID,
+		AcademicYear: academicYear,
+		IsActive:     *req.IsActive,
+		UpdatedAt:    time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, awardType)
+}
+
+func (s *awardTypeService) UpdateFormSchema(ctx context.Context, awardTypeID uint, req awardtypedto.FormSchemaRequest) (*awardtypedto.AwardTypeResponse, error) {
+	awardType, err := s.get(ctx, awardTypeID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := normalizeFormFields(req.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	awardType.FormFields = fields
+	awardType.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, awardType); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, awardType)
+}
+
+func (s *awardTypeService) Resolve(ctx context.Context, value string) (*models.AwardType, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errors.New("award_type is required")
+	}
+	awardTypes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range awardTypes {
+		awardType := &awardTypes[i]
+		if strings.EqualFold(awardType.Code, value) || awardType.NameTH == value || (awardType.NameEN != "" && strings.EqualFold(awardType.NameEN, value)) {
+			return awardType, nil
+		}
+	}
+	for i := range awardTypes {
+		for _, name := range awardTypes[i].LegacyNames {
+			if strings.TrimSpace(name) == value {
+				return &awardTypes[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("award type %q not found", value)
+}
+
+func (s *awardTypeService) CheckEligibility(ctx context.Context, awardType *models.AwardType, academicYear int, candidate AwardEligibility) error {
+	active := awardType.IsActive
+	yearSettings, err := s.repo.GetYearSettings(ctx, academicYear)
+	if err != nil {
+		return err
+	}
+	if yearActive, ok := yearSettings[awardType.AwardTypeID]; ok {
+		active = yearActive
+	}
+	if !active {
+		return fmt.Errorf("award type %s is not open for academic year %d", awardType.NameTH, academicYear)
+	}
+
+	reasons := make([]string, 0)
+	if len(awardType.AllowedRoles) > 0 && !containsInt(awardType.AllowedRoles, candidate.RoleID) {
+		reasons = append(reasons, fmt.Sprintf("submissions from role %d are not accepted", candidate.RoleID))
+	}
+	if len(awardType.AllowedStudentYears) > 0 && !containsInt(awardType.AllowedStudentYears, candidate.StudentYear) {
+		reasons = append(reasons, fmt.Sprintf("student year %d is not allowed (allowed: %s)", candidate.StudentYear, joinInts(awardType.AllowedStudentYears)))
+	}
+	if awardType.MinGPA != nil && candidate.GPA != nil && *candidate.GPA < *awardType.MinGPA {
+		reasons = append(reasons, fmt.Sprintf("GPA %.2f is below the minimum %.2f", *candidate.GPA, *awardType.MinGPA))
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("not eligible for %s: %s", awardType.NameTH, strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+func (s *awardTypeService) CategoryTypeIDs(ctx context.Context, category string) ([]uint, error) {
+	awardTypes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0)
+	for _, awardType := range awardTypes {
+		inMainCategory := awardType.Category != models.AwardCategoryOther
+		if (category == models.AwardCategoryOther && inMainCategory) || (category != models.AwardCategoryOther && awardType.Category == category) {
+			ids = append(ids, awardType.AwardTypeID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *awardTypeService) FormSchemas(ctx context.Context) ([]models.AwardType, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *awardTypeService) get(ctx context.Context, awardTypeID uint) (*models.AwardType, error) {
+	awardType, err := s.repo.GetByID(ctx, awardTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("award type not found")
+		}
+		return nil, err
+	}
+	return awardType, nil
+}
+
+// apply ตรวจและคัดลอกค่าจาก request (ยกเว้น code)
+func (s *awardTypeService) apply(ctx context.Context, awardType *models.AwardType, req awardtypedto.AwardTypeRequest) error {
+	nameTH := strings.TrimSpace(req.NameTH)
+	if nameTH == "" {
+		return errors.New("name_th is required")
+	}
+	category := strings.TrimSpace(req.Category)
+	if category == "" {
+		category = models.AwardCategoryOther
+	}
+	if awardCategoryLabel(category) == "" {
+		return fmt.Errorf("invalid category %q", category)
+	}
+	if req.MinGPA != nil && (*req.MinGPA < 0 || *req.MinGPA > 4) {
+		return errors.New("min_gpa must be between 0 and 4")
+	}
+
+	years := uniqueSortedInts(req.AllowedStudentYears)
+	for _, year := range years {
+		if year < 1 || year > 8 {
+			return errors.New("allowed_student_years must be between 1 and 8")
+		}
+	}
+	roles := uniqueSortedInts(req.AllowedRoles)
+	for _, role := range roles {
+		if !awardSubmitterRoles[role] {
+			return fmt.Errorf("role %d cannot submit awards (allowed: 1, 8)", role)
+		}
+	}
+
+	// ชื่อ (รวมชื่อเดิม) ต้องไม่ซ้ำกับประเภทอื่น เพื่อให้ Resolve ได้ผลเดียว
+	legacyNames := make([]string, 0, len(req.LegacyNames))
+	names := map[string]bool{nameTH: true}
+	for _, name := range req.LegacyNames {
+		name = strings.TrimSpace(name)
+		if name == "" || names[name] {
+			continue
+		}
+		names[name] = true
+		legacyNames = append(legacyNames, name)
+	}
+	others, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.AwardTypeID == awardType.AwardTypeID {
+			continue
+		}
+		for _, name := range append([]string{other.NameTH}, other.LegacyNames...) {
+			if names[strings.TrimSpace(name)] {
+				return fmt.Errorf("name %q is already used by award type %s", name, other.Code)
+			}
+		}
+	}
+
+	awardType.NameTH = nameTH
+	awardType.NameEN = strings.TrimSpace(req.NameEN)
+	awardType.Description = strings.TrimSpace(req.Description)
+	awardType.Category = category
+	if req.IsActive != nil {
+		awardType.IsActive = *req.IsActive
+	}
+	awardType.MinGPA = req.MinGPA
+	awardType.AllowedStudentYears = years
+	awardType.AllowedRoles = roles
+	awardType.LegacyNames = legacyNames
+	return nil
+}
+
+// linkForms ผูกฟอร์มเดิมที่ใช้ชื่อของประเภทนี้แต่ยังไม่มี award_type_id
+func (s *awardTypeService) linkForms(ctx context.Context, awardType *models.AwardType) error {
+	_, err := s.repo.LinkForms(ctx, awardType.AwardTypeID, append([]string{awardType.NameTH}, awardType.LegacyNames...))
+	return err
+}
+
+func (s *awardTypeService) detail(ctx context.Context, awardType *models.AwardType) (*awardtypedto.AwardTypeResponse, error) {
+	settings, err := s.repo.GetYearSettingsByType(ctx, awardType.AwardTypeID)
+	if err != nil {
+		return nil, err
+	}
+	response := mapToAwardTypeResponse(awardType)
+	for _, setting := range settings {
+		response.YearSettings = append(response.YearSettings, awardtypedto.AwardTypeYearResponse{
+			AcademicYear: setting.AcademicYear,
+			IsActive:     setting.IsActive,
+			UpdatedAt:    setting.UpdatedAt,
+		})
+	}
+	return &response, nil
+}
+
+func mapToAwardTypeResponse(awardType *models.AwardType) awardtypedto.AwardTypeResponse {
+	return awardtypedto.AwardTypeResponse{
+		AwardTypeID:         awardType.AwardTypeID,
+		Code:                awardType.Code,
+		NameTH:              awardType.NameTH,
+		NameEN:              awardType.NameEN,
+		Description:         awardType.Description,
+		Category:            awardType.Category,
+		CategoryLabel:       awardCategoryLabel(awardType.Category),
+		IsActive:            awardType.IsActive,
+		MinGPA:              awardType.MinGPA,
+		AllowedStudentYears: nonNilInts(awardType.AllowedStudentYears),
+		AllowedRoles:        nonNilInts(awardType.AllowedRoles),
+		LegacyNames:         nonNilStrings(awardType.LegacyNames),
+		FormFields:          nonNilFormFields(awardType.FormFields),
+		UpdatedAt:           awardType.UpdatedAt,
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func uniqueSortedInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func nonNilInts(values []int) []int {
+	if values == nil {
+		return []int{}
+	}
+	return values
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nonNilFormFields(fields []models.AwardFormField) []models.AwardFormField {
+	if fields == nil {
+		return []models.AwardFormField{}
+	}
+	return fields
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule cron แบบ 5 ช่อง: นาที ชั่วโมง วันที่ เดือน วันในสัปดาห์ (0 = อาทิตย์)
+// รองรับ *, */n, a-b, a-b/n และรายการคั่นด้วย comma
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = []cronField{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, errors.New("cron expression must have 5 fields (minute hour day month weekday)")
+	}
+
+	bits := make([]uint64, 5)
+	for i, part := range parts {
+		value, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron field %d (%q): %w", i+1, part, err)
+		}
+		bits[i] = value
+	}
+
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			rangePart = item[:idx]
+			n, err := strconv.Atoi(item[idx+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.New("invalid step")
+			}
+			step = n
+		}
+
+		start, end := bounds.min, bounds.max
+		if rangePart != "*" {
+			if idx := strings.Index(rangePart, "-"); idx >= 0 {
+				a, errA := strconv.Atoi(rangePart[:idx])
+				b, errB := strconv.Atoi(rangePart[idx+1:])
+				if errA != nil || errB != nil || a > b {
+					return 0, errors.New("invalid range")
+				}
+				start, end = a, b
+			} else {
+				n, err := strconv.Atoi(rangePart)
+				if err != nil {
+					return 0, errors.New("invalid value")
+				}
+				start, end = n, n
+				if step > 1 {
+					end = bounds.max
+				}
+			}
+		}
+		if start < bounds.min || end > bounds.max {
+			return 0, fmt.Errorf("value out of range %d-%d", bounds.min, bounds.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// NextCronRun เวลาถัดไปของ cron expr หลังจาก after (ใช้ตอน seed schedule ที่เปิดไว้ตั้งแต่แรก)
+func NextCronRun(expr string, after time.Time) (time.Time, error) {
+	cron, err := parseCron(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cron.Next(after), nil
+}
+
+// Next เวลาถัดไป (ละเอียดระดับนาที) ที่ตรงกับ schedule หลังจาก after
+func (c *cronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches ถ้ากำหนดทั้งวันที่และวันในสัปดาห์ ตรงอย่างใดอย่างหนึ่งก็พอ (เหมือน cron มาตรฐาน)
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
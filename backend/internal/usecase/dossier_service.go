@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"archive/zip"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// formStatusCommitteeReview คือสถานะ "อนุมัติโดยกองพัฒนานิสิต" (รอคณะกรรมการโหวต)
+const formStatusCommitteeReview = 8
+
+const dossierFontFamily = "dossier"
+
+// errDossierFontUnavailable ฟอนต์มาตรฐานของ PDF แสดงภาษาไทยไม่ได้ จึงไม่ออกแฟ้มถ้าไม่มีฟอนต์ไทย
+var errDossierFontUnavailable = errors.New("dossier font unavailable: set PDF_FONT_PATH to a Thai TTF font (e.g. THSarabunNew.ttf)")
+
+var disablePdfcpuConfigOnce sync.Once
+
+type DossierService interface {
+	BuildFormDossier(ctx context.Context, formID uint, viewer *models.User) ([]byte, string, error)
+	BuildCommitteeDossierZip(ctx context.Context, viewer *models.User) ([]byte, string, error)
+}
+
+type dossierService struct {
+	awardRepo *repository.AwardRepository
+	fontPath  string
+}
+
+// NewDossierService สร้าง service สำหรับออกแฟ้มเสนอชื่อ (PDF)
+// fontPath คือไฟล์ TTF ที่รองรับภาษาไทย (เช่น THSarabunNew.ttf) ถ้าไม่มีจะออกแฟ้มไม่ได้
+func NewDossierService(awardRepo *repository.AwardRepository, fontPath string) DossierService {
+	// ไม่ให้ pdfcpu สร้าง config directory ใน home ของ process
+	disablePdfcpuConfigOnce.Do(api.DisableConfigDir)
+
+	if fontPath != "" {
+		if _, err := os.Stat(fontPath); err != nil {
+			log.Printf("dossier: font %s not found, dossiers are disabled: %v", fontPath, err)
+			fontPath = ""
+		}
+	} else {
+		log.Printf("dossier: PDF_FONT_PATH is not set, dossiers are disabled")
+	}
+
+	return &dossierService{awardRepo: awardRepo, fontPath: fontPath}
+}
+
+func (s *dossierService) BuildFormDossier(ctx context.Context, formID uint, viewer *models.User) ([]byte, string, error) {
+	form, err := s.awardRepo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		return nil, "", errors.New("form not found")
+	}
+	// สิทธิ์และการซ่อนข้อมูลส่วนบุคคลเหมือนการดูรายละเอียดฟอร์ม
+	hidden, err := newFormReadAccess(s.awardRepo, viewer).authorize(ctx, form)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := s.renderDossier(ctx, form, hidden)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, fmt.Sprintf("dossier-%d.pdf", form.FormID), nil
+}
+
+// BuildCommitteeDossierZip รวมแฟ้มของทุกฟอร์มในวิทยาเขตที่อยู่ในขั้นคณะกรรมการเป็นไฟล์ zip
+func (s *dossierService) BuildCommitteeDossierZip(ctx context.Context, viewer *models.User) ([]byte, string, error) {
+	if viewer.RoleID != 5 && viewer.RoleID != 6 {
+		return nil, "", errors.New("forbidden")
+	}
+
+	formIDs, err := s.awardRepo.GetFormIDsByStatus(ctx, viewer.CampusID, formStatusCommitteeReview)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	access := newFormReadAccess(s.awardRepo, viewer)
+	for _, formID := range formIDs {
+		form, err := s.awardRepo.GetByFormID(ctx, int(formID))
+		if err != nil {
+			return nil, "", err
+		}
+		hidden, err := access.authorize(ctx, form)
+		if errors.Is(err, errFormForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		content, err := s.renderDossier(ctx, form, hidden)
+		if err != nil {
+			return nil, "", fmt.Errorf("form %d: %w", formID, err)
+		}
+
+		w, err := zw.Create(fmt.Sprintf("dossier-%d-%s.pdf", form.FormID, form.StudentNumber))
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), fmt.Sprintf("committee-dossiers-%s.zip", time.Now().Format("20060102")), nil
+}
+
+type dossierAttachment struct {
+	file    models.AwardFileDirectory
+	content []byte
+	merged  bool
+}
+
+// renderDossier hidden คือฟิลด์ส่วนบุคคลที่ถูกซ่อนจากผู้ดาวน์โหลด (แสดงว่าไม่แสดงตามสิทธิ์แทนค่าว่าง)
+func (s *dossierService) renderDossier(ctx context.Context, form *models.AwardForm, hidden []string) ([]byte, error) {
+	if s.fontPath == "" {
+		return nil, errDossierFontUnavailable
+	}
+	names, err := s.awardRepo.GetFormLookupNames(ctx, form.FormID)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := s.awardRepo.GetFormTimeline(ctx, form.FormID)
+	if err != nil {
+		return nil, err
+	}
+
+	// อ่านและตรวจไฟล์แนบก่อน ไฟล์ที่เสียจะไม่ถูกรวมแต่จะระบุไว้ในหน้าสรุป
+	conf := model.NewDefaultConfiguration()
+	attachments := make([]dossierAttachment, 0, len(form.AwardFiles))
+	for _, f := range form.AwardFiles {
+		att := dossierAttachment{file: f}
+		if strings.EqualFold(f.FileType, "pdf") {
+			if content, readErr := os.ReadFile(f.FilePath); readErr == nil {
+				if api.Validate(bytes.NewReader(content), conf) == nil {
+					att.content = content
+					att.merged = true
+				}
+			}
+		}
+		attachments = append(attachments, att)
+	}
+
+	summary, err := s.renderSummary(form, hidden, names, timeline, attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	readers := []io.ReadSeeker{bytes.NewReader(summary)}
+	for _, att := range attachments {
+		if att.merged {
+			readers = append(readers, bytes.NewReader(att.content))
+		}
+	}
+	if len(readers) == 1 {
+		return summary, nil
+	}
+
+	var out bytes.Buffer
+	if err := api.MergeRaw(readers, &out, false, conf); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func (s *dossierService) renderSummary(form *models.AwardForm, hidden []string, names *repository.FormLookupNames, timeline []repository.FormTimelineRow, attachments []dossierAttachment) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+
+	pdf.AddUTF8Font(dossierFontFamily, "", s.fontPath)
+	pdf.AddUTF8Font(dossierFontFamily, "B", s.fontPath)
+	family, baseSize := dossierFontFamily, 14.0
+
+	pdf.AddPage()
+	pdf.SetFont(family, "B", baseSize+6)
+	pdf.CellFormat(0, 10, fmt.Sprintf("แฟ้มเสนอชื่อรับรางวัล (Nomination Dossier) #%d", form.FormID), "", 1, "C", false, 0, "")
+	pdf.SetFont(family, "", baseSize-2)
+	pdf.CellFormat(0, 6, "สร้างเมื่อ "+time.Now().Format("2006-01-02 15:04"), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	section := func(title string) {
+		pdf.Ln(2)
+		pdf.SetFont(family, "B", baseSize+2)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(0, 8, title, "", 1, "L", true, 0, "")
+		pdf.SetFont(family, "", baseSize)
+	}
+	field := func(label string, value string) {
+		pdf.SetFont(family, "B", baseSize)
+		pdf.CellFormat(55, 7, label, "", 0, "L", false, 0, "")
+		pdf.SetFont(family, "", baseSize)
+		pdf.MultiCell(0, 7, value, "", "L", false)
+	}
+
+	section("ข้อมูลฟอร์ม")
+	field("ชื่อ-นามสกุล", strings.TrimSpace(form.StudentFirstname+" "+form.StudentLastname))
+	field("รหัสนิสิต", form.StudentNumber)
+	field("อีเมล", form.StudentEmail)
+	field("ชั้นปี", fmt.Sprintf("%d", form.StudentYear))
+	field("คณะ", names.FacultyName)
+	field("ภาควิชา", names.DepartmentName)
+	field("วิทยาเขต", names.CampusName)
+	field("ปีการศึกษา / ภาคเรียน", fmt.Sprintf("%d / %d", form.AcademicYear, form.Semester))
+	field("ประเภทรางวัล", form.AwardType)
+	field("สถานะ", names.FormStatusName)
+	field("อาจารย์ที่ปรึกษา", form.AdvisorName)
+	personal := func(label string, key string, value string) {
+		if containsString(hidden, key) {
+			value = "(ไม่แสดงตามสิทธิ์ของผู้ดาวน์โหลด)"
+		}
+		field(label, value)
+	}
+	personal("เบอร์โทรศัพท์", personalFieldPhoneNumber, form.StudentPhoneNumber)
+	personal("ที่อยู่", personalFieldAddress, form.StudentAddress)
+	personal("เกรดเฉลี่ย", personalFieldGPA, fmt.Sprintf("%.2f", form.GPA))
+	if !form.StudentDateOfBirth.IsZero() || containsString(hidden, personalFieldDateOfBirth) {
+		personal("วันเกิด", personalFieldDateOfBirth, form.StudentDateOfBirth.Format("2006-01-02"))
+	}
+	if form.OrgName != "" {
+		field("หน่วยงานที่เสนอชื่อ", form.OrgName)
+		field("ประเภทหน่วยงาน", form.OrgType)
+		field("ที่ตั้งหน่วยงาน", form.OrgLocation)
+		field("เบอร์หน่วยงาน", form.OrgPhoneNumber)
+	}
+	field("วันที่ส่ง", form.CreatedAt.Format("2006-01-02 15:04"))
+	field("รายละเอียด", form.FormDetail)
+	if form.RejectReason != "" {
+		field("เหตุผลการตีกลับ", form.RejectReason)
+	}
+
+	section("ประวัติการดำเนินการ")
+	if len(timeline) == 0 {
+		pdf.MultiCell(0, 7, "- ยังไม่มีประวัติ -", "", "L", false)
+	}
+	for _, event := range timeline {
+		line := fmt.Sprintf("%s  %s  โดย %s", event.OccurredAt.Format("2006-01-02 15:04"), describeTimelineEvent(event), event.ActorName)
+		pdf.MultiCell(0, 7, line, "", "L", false)
+	}
+
+	section("ไฟล์แนบ")
+	if len(attachments) == 0 {
+		pdf.MultiCell(0, 7, "- ไม่มีไฟล์แนบ -", "", "L", false)
+	}
+	for i, att := range attachments {
+		note := "แนบท้ายเอกสารนี้"
+		if !att.merged {
+			note = "ไม่สามารถรวมไฟล์ได้ (ไฟล์หายหรือเสียหาย)"
+		}
+		pdf.MultiCell(0, 7, fmt.Sprintf("%d. %s (%.1f KB) - %s", i+1, att.file.FilePath, float64(att.file.FileSize)/1024, note), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func describeTimelineEvent(event repository.FormTimelineRow) string {
+	switch event.EventType {
+	case "approval":
+		if event.Operation == "reject" {
+			return "ตีกลับ/ไม่อนุมัติ: " + event.Reason
+		}
+		return "อนุมัติ"
+	case "vote":
+		if event.Operation == "reject" {
+			return "คณะกรรมการโหวตไม่เห็นชอบ"
+		}
+		return "คณะกรรมการโหวตเห็นชอบ"
+	case "award_type":
+		switch event.Operation {
+		case "award_type_change":
+			return fmt.Sprintf("เปลี่ยนประเภทรางวัล: %s -> %s", event.OldValue, event.NewValue)
+		case "rejection":
+			return "กองพัฒนานิสิตตีกลับ: " + event.Reason
+		default:
+			return "กองพัฒนานิสิตอนุมัติ"
+		}
+	case "signed":
+		return "ลงนาม"
+	default:
+		return event.EventType
+	}
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ชุดข้อมูลที่ส่งออกได้
+const (
+	ExportDatasetSearch       = "search"
+	ExportDatasetAnnouncement = "announcement"
+	ExportDatasetApprovalLogs = "approval-logs"
+)
+
+const (
+	exportSearchBatchSize = 500
+	exportDateTimeLayout  = "2006-01-02 15:04"
+)
+
+// exportColumn คอลัมน์หนึ่งของไฟล์ส่งออก Label เป็นหัวตารางภาษาไทย
+type exportColumn[T any] struct {
+	Key     string
+	Label   string
+	Default bool
+	Value   func(row T, names *exportLookups) string
+}
+
+// exportLookups ชื่อคณะ/ภาควิชา/สถานะ ที่โหลดครั้งเดียวต่อการส่งออก
+type exportLookups struct {
+	faculties   map[int]string
+	departments map[int]string
+	statuses    map[int]string
+}
+
+var searchExportColumns = []exportColumn[awardformdto.AwardFormResponse]{
+	{"form_id", "รหัสฟอร์ม", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string {
+		return strconv.FormatUint(uint64(r.FormID), 10)
+	}},
+	{"student_number", "รหัสนิสิต", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.StudentNumber }},
+	{"student_name", "ชื่อ-นามสกุล", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string {
+		return strings.TrimSpace(r.StudentFirstname + " " + r.StudentLastname)
+	}},
+	{"student_email", "อีเมล", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.StudentEmail }},
+	{"faculty", "คณะ", true, func(r awardformdto.AwardFormResponse, n *exportLookups) string { return n.faculties[r.FacultyID] }},
+	{"department", "ภาควิชา", true, func(r awardformdto.AwardFormResponse, n *exportLookups) string { return n.departments[r.DepartmentID] }},
+	{"student_year", "ชั้นปี", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return exportInt(r.StudentYear) }},
+	{"academic_year", "ปีการศึกษา", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return exportInt(r.AcademicYear) }},
+	{"semester", "ภาคเรียน", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return exportInt(r.Semester) }},
+	{"award_type", "ประเภทรางวัล", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.AwardType }},
+	{"form_status", "สถานะ", true, func(r awardformdto.AwardFormResponse, n *exportLookups) string { return n.statuses[r.FormStatusID] }},
+	{"advisor_name", "อาจารย์ที่ปรึกษา", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.AdvisorName }},
+	{"gpa", "เกรดเฉลี่ย", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string {
+		if r.GPA == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(r.GPA, 'f', 2, 64)
+	}},
+	{"student_phone_number", "เบอร์โทรศัพท์", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.StudentPhoneNumber }},
+	{"student_address", "ที่อยู่", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.StudentAddress }},
+	{"org_name", "หน่วยงานที่เสนอชื่อ", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return r.OrgName }},
+	{"created_at", "วันที่ส่ง", true, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return exportTime(r.CreatedAt) }},
+	{"latest_update", "อัปเดตล่าสุด", false, func(r awardformdto.AwardFormResponse, _ *exportLookups) string { return exportTime(r.LatestUpdate) }},
+}
+
+var announcementExportColumns = []exportColumn[awardformdto.AnnouncementAwardItem]{
+	{"award_type_group", "หมวดรางวัล", true, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return r.AwardTypeGroup }},
+	{"award_type", "ประเภทรางวัล", true, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return r.AwardType }},
+	{"student_number", "รหัสนิสิต", true, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return r.StudentNumber }},
+	{"display_name", "ชื่อ-นามสกุล", true, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return r.DisplayName }},
+	{"faculty", "คณะ", true, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return r.FacultyName }},
+	{"academic_year", "ปีการศึกษา", false, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return exportInt(r.AcademicYear) }},
+	{"semester", "ภาคเรียน", false, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string { return exportInt(r.Semester) }},
+	{"form_id", "รหัสฟอร์ม", false, func(r awardformdto.AnnouncementAwardItem, _ *exportLookups) string {
+		return strconv.FormatUint(uint64(r.FormID), 10)
+	}},
+}
+
+var approvalLogExportColumns = []exportColumn[awardformdto.ApprovalLogHistoryResponse]{
+	{"operation_date", "วันที่ดำเนินการ", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return exportTime(r.OperationDate)
+	}},
+	{"operation", "ผลการพิจารณา", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return exportOperationLabel(r.Operation)
+	}},
+	{"student_number", "รหัสนิสิต", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string { return r.StudentNumber }},
+	{"student_name", "ชื่อ-นามสกุล", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return strings.TrimSpace(r.StudentFirstname + " " + r.StudentLastname)
+	}},
+	{"academic_year", "ปีการศึกษา", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return exportInt(r.AcademicYear)
+	}},
+	{"award_type", "ประเภทรางวัล", true, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string { return r.AwardType }},
+	{"form_id", "รหัสฟอร์ม", false, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return strconv.FormatUint(uint64(r.FormID), 10)
+	}},
+	{"approval_log_id", "รหัสรายการ", false, func(r awardformdto.ApprovalLogHistoryResponse, _ *exportLookups) string {
+		return strconv.FormatUint(uint64(r.ApprovalLogID), 10)
+	}},
+}
+
+// ExportPlan ผลการตรวจรูปแบบไฟล์และคอลัมน์ก่อนเริ่ม stream (หลังเริ่ม stream แล้วเปลี่ยน HTTP status ไม่ได้)
+type ExportPlan struct {
+	Dataset string
+	Format  string
+	Columns []string
+}
+
+func (p *ExportPlan) ContentType() string {
+	if p.Format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (p *ExportPlan) FileName(now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", p.Dataset, now.Format("20060102-150405"), p.Format)
+}
+
+// ExportStream เขียนไฟล์ส่งออกลง w ได้มาหลังตรวจตัวกรองผ่านแล้ว ข้อผิดพลาดจากตรงนี้เปลี่ยน HTTP status ไม่ได้
+type ExportStream func(ctx context.Context, w io.Writer) error
+
+type ExportService interface {
+	GetColumns(dataset string) ([]awardformdto.ExportColumnResponse, error)
+	// PrepareExport ตรวจสิทธิ์ รูปแบบไฟล์ (csv, xlsx) และคอลัมน์ (คั่นด้วย , ว่าง = คอลัมน์เริ่มต้น)
+	PrepareExport(user *models.User, dataset string, format string, columns string) (*ExportPlan, error)
+	// Export* ตรวจตัวกรองและดึงข้อมูลชุดแรกก่อนคืน ExportStream เพื่อให้ตัวกรองผิดตอบ 400 ได้ก่อนเริ่มส่งไฟล์
+	ExportSearch(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.SearchAwardRequest) (ExportStream, error)
+	ExportAnnouncement(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.AnnouncementAwardRequest) (ExportStream, error)
+	ExportApprovalLogs(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.SearchApprovalLogRequest) (ExportStream, error)
+}
+
+type exportService struct {
+	awardUseCase   AwardUseCase
+	facultyRepo    repository.FacultyRepository
+	departmentRepo repository.DepartmentRepository
+	formStatusRepo repository.FormStatusRepository
+}
+
+func NewExportService(awardUseCase AwardUseCase, facultyRepo repository.FacultyRepository, departmentRepo repository.DepartmentRepository, formStatusRepo repository.FormStatusRepository) ExportService {
+	return &exportService{
+		awardUseCase:   awardUseCase,
+		facultyRepo:    facultyRepo,
+		departmentRepo: departmentRepo,
+		formStatusRepo: formStatusRepo,
+	}
+}
+
+func (s *exportService) GetColumns(dataset string) ([]awardformdto.ExportColumnResponse, error) {
+	switch dataset {
+	case ExportDatasetSearch:
+		return exportColumnResponses(searchExportColumns), nil
+	case ExportDatasetAnnouncement:
+		return exportColumnResponses(announcementExportColumns), nil
+	case ExportDatasetApprovalLogs:
+		return exportColumnResponses(approvalLogExportColumns), nil
+	default:
+		return nil, errors.New("dataset must be search, announcement or approval-logs")
+	}
+}
+
+func (s *exportService) PrepareExport(user *models.User, dataset string, format string, columns string) (*ExportPlan, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = ExportFormatXLSX
+	}
+	if format != ExportFormatCSV && format != ExportFormatXLSX {
+		return nil, errors.New("format must be csv or xlsx")
+	}
+
+	var keys []string
+	var err error
+	switch dataset {
+	case ExportDatasetSearch:
+		// ผลค้นหามีข้อมูลส่วนบุคคล ส่งออกได้เฉพาะผู้พิจารณา (นิสิต/องค์กรใช้ /my/submissions)
+		if user.RoleID == 1 || user.RoleID == 8 {
+			return nil, errors.New("forbidden: only reviewers can export search results")
+		}
+		keys, err = selectExportColumns(searchExportColumns, columns)
+	case ExportDatasetAnnouncement:
+		keys, err = selectExportColumns(announcementExportColumns, columns)
+	case ExportDatasetApprovalLogs:
+		keys, err = selectExportColumns(approvalLogExportColumns, columns)
+	default:
+		return nil, errors.New("dataset must be search, announcement or approval-logs")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportPlan{Dataset: dataset, Format: format, Columns: keys}, nil
+}
+
+// ExportSearch ส่งออกผลค้นหาด้วย scope เดียวกับ GetByKeyword ดึงทีละ batch เพื่อไม่ต้องโหลดทั้งหมดในหน่วยความจำ
+func (s *exportService) ExportSearch(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.SearchAwardRequest) (ExportStream, error) {
+	lookups, err := s.loadLookups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrder := req.SortOrder
+	if strings.TrimSpace(sortOrder) == "" {
+		sortOrder = req.Arrangement
+	}
+	fetch := func(ctx context.Context, page int) (*awardformdto.PaginatedAwardResponse, error) {
+		return s.awardUseCase.GetByKeyword(ctx, user.UserID, user.RoleID, user.CampusID,
+			req.Keyword, req.Date, req.StudentYear, req.AcademicYear, req.Semester, req.AwardType, req.Answers, req.SortBy, sortOrder, page, exportSearchBatchSize)
+	}
+
+	// หน้าแรกดึงก่อนเริ่ม stream ตัวกรองภาคเรียน/คำตอบที่ผิดจึงตอบกลับเป็น error ได้
+	first, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return writeExport(plan, w, "ผลการค้นหา", searchExportColumns, lookups, func(emit func(awardformdto.AwardFormResponse) error) error {
+			result := first
+			for page := 1; ; page++ {
+				if page > 1 {
+					next, err := fetch(ctx, page)
+					if err != nil {
+						return err
+					}
+					result = next
+				}
+				for _, row := range result.Data {
+					if err := emit(row); err != nil {
+						return err
+					}
+				}
+				if page >= result.Pagination.TotalPages {
+					return nil
+				}
+			}
+		})
+	}, nil
+}
+
+// ExportAnnouncement ส่งออกประกาศผลของวิทยาเขตผู้ใช้ เรียงตามหมวดรางวัลเหมือนหน้าประกาศ เขียนทีละ batch ระหว่างดึง
+func (s *exportService) ExportAnnouncement(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.AnnouncementAwardRequest) (ExportStream, error) {
+	req, err := s.awardUseCase.ResolveAnnouncementRequest(ctx, user.CampusID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return writeExport(plan, w, "ประกาศผล", announcementExportColumns, nil, func(emit func(awardformdto.AnnouncementAwardItem) error) error {
+			return s.awardUseCase.EachAnnouncementAward(ctx, user.CampusID, req, exportSearchBatchSize, emit)
+		})
+	}, nil
+}
+
+// ExportApprovalLogs ส่งออกประวัติการพิจารณาของผู้ใช้ในวิทยาเขตตัวเอง (scope เดียวกับ /my/approval-logs)
+func (s *exportService) ExportApprovalLogs(ctx context.Context, plan *ExportPlan, user *models.User, req awardformdto.SearchApprovalLogRequest) (ExportStream, error) {
+	sortOrder := req.SortOrder
+	if strings.TrimSpace(sortOrder) == "" {
+		sortOrder = req.Arrangement
+	}
+	fetch := func(ctx context.Context, page int) (*awardformdto.PaginatedApprovalLogResponse, error) {
+		return s.awardUseCase.GetApprovalHistory(ctx, user.UserID, user.CampusID,
+			req.Keyword, req.Date, req.Operation, req.SortBy, sortOrder, page, 0)
+	}
+
+	first, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return writeExport(plan, w, "ประวัติการพิจารณา", approvalLogExportColumns, nil, func(emit func(awardformdto.ApprovalLogHistoryResponse) error) error {
+			result := first
+			for page := 1; ; page++ {
+				if page > 1 {
+					next, err := fetch(ctx, page)
+					if err != nil {
+						return err
+					}
+					result = next
+				}
+				for _, row := range result.Data {
+					if err := emit(row); err != nil {
+						return err
+					}
+				}
+				if page >= result.Pagination.TotalPages {
+					return nil
+				}
+			}
+		})
+	}, nil
+}
+
+func (s *exportService) loadLookups(ctx context.Context) (*exportLookups, error) {
+	lookups := &exportLookups{
+		faculties:   make(map[int]string),
+		departments: make(map[int]string),
+		statuses:    make(map[int]string),
+	}
+
+	faculties, err := s.facultyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range faculties {
+		lookups.faculties[int(f.FacultyID)] = f.FacultyName
+	}
+
+	departments, err := s.departmentRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range departments {
+		lookups.departments[int(d.DepartmentID)] = d.DepartmentName
+	}
+
+	statuses, err := s.formStatusRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range statuses {
+		lookups.statuses[int(st.FormStatusID)] = st.FormStatusName
+	}
+	return lookups, nil
+}
+
+// writeExport เขียนหัวตารางตามคอลัมน์ที่เลือก แล้วเขียนทุกแถวที่ produce ส่งมา
+func writeExport[T any](plan *ExportPlan, w io.Writer, sheetName string, all []exportColumn[T], lookups *exportLookups, produce func(emit func(T) error) error) error {
+	byKey := make(map[string]exportColumn[T], len(all))
+	for _, column := range all {
+		byKey[column.Key] = column
+	}
+	columns := make([]exportColumn[T], 0, len(plan.Columns))
+	header := make([]string, 0, len(plan.Columns))
+	for _, key := range plan.Columns {
+		columns = append(columns, byKey[key])
+		header = append(header, byKey[key].Label)
+	}
+
+	writer, err := newTabularWriter(plan.Format, w, sheetName)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteRow(header); err != nil {
+		return err
+	}
+
+	err = produce(func(row T) error {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = column.Value(row, lookups)
+		}
+		return writer.WriteRow(values)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func selectExportColumns[T any](all []exportColumn[T], columns string) ([]string, error) {
+	keys := make([]string, 0)
+	if strings.TrimSpace(columns) == "" {
+		for _, column := range all {
+			if column.Default {
+				keys = append(keys, column.Key)
+			}
+		}
+		return keys, nil
+	}
+
+	known := make(map[string]bool, len(all))
+	for _, column := range all {
+		known[column.Key] = true
+	}
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(columns, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" || seen[key] {
+			continue
+		}
+		if !known[key] {
+			return nil, fmt.Errorf("unknown column %q", key)
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one column is required")
+	}
+	return keys, nil
+}
+
+func exportColumnResponses[T any](all []exportColumn[T]) []awardformdto.ExportColumnResponse {
+	response := make([]awardformdto.ExportColumnResponse, 0, len(all))
+	for _, column := range all {
+		response = append(response, awardformdto.ExportColumnResponse{Key: column.Key, Label: column.Label, Default: column.Default})
+	}
+	return response
+}
+
+func exportInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+func exportTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(exportDateTimeLayout)
+}
+
+func exportOperationLabel(operation string) string {
+	switch operation {
+	case "approve":
+		return "อนุมัติ"
+	case "reject":
+		return "ไม่อนุมัติ"
+	default:
+		return operation
+	}
+}
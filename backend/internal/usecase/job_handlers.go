@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// handler ของงานแต่ละชนิด ผูกเข้ากับ worker ใน server.NewJobWorker
+
+// RetentionPurgeJobHandler payload: {"dry_run": false}
+func RetentionPurgeJobHandler(s RetentionService) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload struct {
+			DryRun bool `json:"dry_run"`
+		}
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid payload: %w", err))
+		}
+
+		run, err := s.RunPurge(ctx, nil, payload.DryRun)
+		if err != nil {
+			return err
+		}
+		log.Printf("jobs: retention run %d %s, forms=%d files=%d", run.RunID, run.Status, run.FormsAffected, run.FilesDeleted)
+		return nil
+	}
+}
+
+func SLACheckJobHandler(s SLAService) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		_, err := s.RunCheck(ctx)
+		return err
+	}
+}
+
+// notificationJobPayload การแจ้งเตือนหนึ่งรายการที่ส่งผ่านช่องทาง Channel (สร้างโดย queuedChannel)
+type notificationJobPayload struct {
+	Channel      string              `json:"channel"`
+	UserID       uint                `json:"user_id"`
+	Notification models.Notification `json:"notification"`
+}
+
+// NotificationSendJobHandler ส่งการแจ้งเตือนที่เข้าคิวไว้ผ่านช่องทางจริง (เช่น SMTP) ถ้าส่งไม่สำเร็จจะลองใหม่ตาม backoff
+func NotificationSendJobHandler(repo repository.NotificationRepository, channels ...NotificationChannel) JobHandler {
+	byName := make(map[string]NotificationChannel, len(channels))
+	for _, channel := range channels {
+		byName[channel.Name()] = channel
+	}
+
+	return func(ctx context.Context, job *models.Job) error {
+		var payload notificationJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid payload: %w", err))
+		}
+
+		channel, ok := byName[payload.Channel]
+		if !ok {
+			return PermanentJobError(fmt.Errorf("notification channel %q is not configured on this worker", payload.Channel))
+		}
+
+		users, err := repo.GetUsersByIDs(ctx, []uint{payload.UserID})
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return PermanentJobError(fmt.Errorf("user %d not found", payload.UserID))
+		}
+		return channel.Send(ctx, users[0], &payload.Notification)
+	}
+}
+
+// WebhookDeliverJobHandler payload: {"delivery_id": 1} ครั้งสุดท้ายของคิวที่ยังล้มเหลวจะบันทึก delivery เป็น failed
+func WebhookDeliverJobHandler(s WebhookService) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload struct {
+			DeliveryID uint `json:"delivery_id"`
+		}
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil || payload.DeliveryID == 0 {
+			return PermanentJobError(fmt.Errorf("invalid payload: %s", job.Payload))
+		}
+		return s.Deliver(ctx, payload.DeliveryID, job.Attempts >= job.MaxAttempts)
+	}
+}
+
+// AnnouncementPublishJobHandler payload: {"version_id": 1} เผยแพร่ประกาศที่ตั้งเวลาไว้ (ถ้าถูกยกเลิก/ตั้งเวลาใหม่จะไม่ทำอะไร)
+func AnnouncementPublishJobHandler(s AnnouncementService) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload struct {
+			VersionID uint `json:"version_id"`
+		}
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil || payload.VersionID == 0 {
+			return PermanentJobError(fmt.Errorf("invalid payload: %s", job.Payload))
+		}
+		return s.PublishScheduled(ctx, payload.VersionID)
+	}
+}
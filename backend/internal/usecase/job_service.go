@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	jobdto "backend/internal/dto/job_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ชนิดของงานเบื้องหลัง
+const (
+	JobTypeRetentionPurge      = "retention.purge"
+	JobTypeSLACheck            = "sla.check"
+	JobTypeNotificationSend    = "notification.send"
+	JobTypeWebhookDeliver      = "webhook.deliver"
+	JobTypeAnnouncementPublish = "announcement.publish"
+)
+
+// manualJobTypes งานที่ผู้ดูแลสร้างเองผ่าน API หรือตั้ง schedule ได้
+var manualJobTypes = map[string]bool{
+	JobTypeRetentionPurge: true,
+	JobTypeSLACheck:       true,
+}
+
+const (
+	defaultJobMaxAttempts = 5
+	maxJobMaxAttempts     = 20
+)
+
+// EnqueueOptions RunAt ว่าง = ทำทันที, UniqueKey ใช้กันสร้างงานซ้ำ
+type EnqueueOptions struct {
+	RunAt       time.Time
+	MaxAttempts int
+	UniqueKey   string
+	CreatedBy   *uint
+}
+
+type JobService interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, opts EnqueueOptions) (*models.Job, error)
+	EnqueueManual(ctx context.Context, req jobdto.EnqueueJobRequest, createdBy uint) (*jobdto.JobResponse, error)
+
+	GetJobs(ctx context.Context, status, jobType string, page, limit int) ([]jobdto.JobResponse, int64, error)
+	GetJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error)
+	GetStats(ctx context.Context) (*jobdto.JobStatsResponse, error)
+	// RetryJob นำงานที่ dead หรือ cancelled กลับเข้าคิวโดยเริ่มนับจำนวนครั้งใหม่
+	RetryJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error)
+	CancelJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error)
+
+	GetSchedules(ctx context.Context) ([]jobdto.ScheduleResponse, error)
+	UpdateSchedule(ctx context.Context, name string, req jobdto.UpdateScheduleRequest, updatedBy uint) (*jobdto.ScheduleResponse, error)
+}
+
+type jobService struct {
+	repo repository.JobRepository
+}
+
+func NewJobService(repo repository.JobRepository) JobService {
+	return &jobService{repo: repo}
+}
+
+func (s *jobService) Enqueue(ctx context.Context, jobType string, payload interface{}, opts EnqueueOptions) (*models.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		raw = []byte("{}")
+	}
+
+	now := time.Now()
+	job := &models.Job{
+		Type:        jobType,
+		Payload:     string(raw),
+		Status:      models.JobStatusPending,
+		RunAt:       opts.RunAt,
+		MaxAttempts: opts.MaxAttempts,
+		CreatedBy:   opts.CreatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = now
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = defaultJobMaxAttempts
+	}
+	if opts.UniqueKey != "" {
+		job.UniqueKey = &opts.UniqueKey
+	}
+
+	created, err := s.repo.Create(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("a job with the same unique key already exists")
+	}
+	return job, nil
+}
+
+func (s *jobService) EnqueueManual(ctx context.Context, req jobdto.EnqueueJobRequest, createdBy uint) (*jobdto.JobResponse, error) {
+	jobType := strings.TrimSpace(req.Type)
+	if !manualJobTypes[jobType] {
+		return nil, fmt.Errorf("unsupported job type: %s", jobType)
+	}
+	if req.MaxAttempts < 0 || req.MaxAttempts > maxJobMaxAttempts {
+		return nil, fmt.Errorf("max_attempts must be between 1 and %d", maxJobMaxAttempts)
+	}
+
+	payload, err := normalizeJobPayload(req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := EnqueueOptions{MaxAttempts: req.MaxAttempts, CreatedBy: &createdBy}
+	if req.RunAt != nil {
+		opts.RunAt = *req.RunAt
+	}
+	job, err := s.Enqueue(ctx, jobType, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	response := mapToJobResponse(*job)
+	return &response, nil
+}
+
+func (s *jobService) GetJobs(ctx context.Context, status, jobType string, page, limit int) ([]jobdto.JobResponse, int64, error) {
+	jobs, total, err := s.repo.GetJobs(ctx, repository.JobFilter{Status: status, Type: jobType}, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]jobdto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		responses = append(responses, mapToJobResponse(job))
+	}
+	return responses, total, nil
+}
+
+func (s *jobService) GetJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error) {
+	job, err := s.repo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("job not found")
+		}
+		return nil, err
+	}
+	response := mapToJobResponse(*job)
+	return &response, nil
+}
+
+func (s *jobService) GetStats(ctx context.Context) (*jobdto.JobStatsResponse, error) {
+	counts, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range []string{models.JobStatusPending, models.JobStatusRunning, models.JobStatusSucceeded, models.JobStatusDead, models.JobStatusCancelled} {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	return &jobdto.JobStatsResponse{Counts: counts}, nil
+}
+
+func (s *jobService) RetryJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error) {
+	now := time.Now()
+	return s.transition(ctx, jobID, []string{models.JobStatusDead, models.JobStatusCancelled}, map[string]interface{}{
+		"status":      models.JobStatusPending,
+		"attempts":    0,
+		"run_at":      now,
+		"finished_at": nil,
+		"updated_at":  now,
+	}, "only dead or cancelled jobs can be retried")
+}
+
+func (s *jobService) CancelJob(ctx context.Context, jobID uint) (*jobdto.JobResponse, error) {
+	now := time.Now()
+	return s.transition(ctx, jobID, []string{models.JobStatusPending}, map[string]interface{}{
+		"status":      models.JobStatusCancelled,
+		"finished_at": now,
+		"updated_at":  now,
+	}, "only pending jobs can be cancelled")
+}
+
+func (s *jobService) transition(ctx context.Context, jobID uint, fromStatuses []string, updates map[string]interface{}, conflictMessage string) (*jobdto.JobResponse, error) {
+	if _, err := s.GetJob(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateJobStatus(ctx, jobID, fromStatuses, updates)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New(conflictMessage)
+	}
+	return s.GetJob(ctx, jobID)
+}
+
+func (s *jobService) GetSchedules(ctx context.Context) ([]jobdto.ScheduleResponse, error) {
+	schedules, err := s.repo.GetSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]jobdto.ScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, mapToScheduleResponse(schedule))
+	}
+	return responses, nil
+}
+
+func (s *jobService) UpdateSchedule(ctx context.Context, name string, req jobdto.UpdateScheduleRequest, updatedBy uint) (*jobdto.ScheduleResponse, error) {
+	schedule, err := s.repo.GetSchedule(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("job schedule not found")
+		}
+		return nil, err
+	}
+
+	if req.CronExpr != nil {
+		schedule.CronExpr = strings.TrimSpace(*req.CronExpr)
+	}
+	if req.Enabled != nil {
+		schedule.Enabled = *req.Enabled
+	}
+	if req.Payload != nil {
+		payload, err := normalizeJobPayload(*req.Payload)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Payload = string(payload)
+	}
+
+	cron, err := parseCron(schedule.CronExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.NextRunAt = nil
+	if schedule.Enabled {
+		next := cron.Next(time.Now())
+		schedule.NextRunAt = &next
+	}
+	schedule.UpdatedBy = &updatedBy
+	schedule.UpdatedAt = time.Now()
+	if err := s.repo.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	response := mapToScheduleResponse(*schedule)
+	return &response, nil
+}
+
+// normalizeJobPayload payload ต้องเป็น JSON object (ว่าง = {})
+func normalizeJobPayload(raw json.RawMessage) (json.RawMessage, error) {
+	if len(strings.TrimSpace(string(raw))) == 0 || string(raw) == "null" {
+		return json.RawMessage("{}"), nil
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, errors.New("payload must be a JSON object")
+	}
+	return raw, nil
+}
+
+func mapToJobResponse(job models.Job) jobdto.JobResponse {
+	return jobdto.JobResponse{
+		JobID:       job.JobID,
+		Type:        job.Type,
+		Payload:     json.RawMessage(job.Payload),
+		Status:      job.Status,
+		RunAt:       job.RunAt,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		LockedBy:    job.LockedBy,
+		LockedAt:    job.LockedAt,
+		UniqueKey:   job.UniqueKey,
+		CreatedBy:   job.CreatedBy,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
+
+func mapToScheduleResponse(schedule models.JobSchedule) jobdto.ScheduleResponse {
+	return jobdto.ScheduleResponse{
+		Name:      schedule.Name,
+		Type:      schedule.Type,
+		Payload:   json.RawMessage(schedule.Payload),
+		CronExpr:  schedule.CronExpr,
+		Enabled:   schedule.Enabled,
+		NextRunAt: schedule.NextRunAt,
+		LastRunAt: schedule.LastRunAt,
+		UpdatedBy: schedule.UpdatedBy,
+		UpdatedAt: schedule.UpdatedAt,
+	}
+}
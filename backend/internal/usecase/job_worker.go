@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"backend/config"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	jobBaseBackoff      = 30 * time.Second
+	jobMaxBackoff       = time.Hour
+	jobMaintenanceEvery = time.Minute
+)
+
+// JobHandler ทำงานหนึ่งงาน คืน error เพื่อให้ลองใหม่ หรือห่อด้วย PermanentJobError เพื่อย้ายไป dead ทันที
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+type JobWorker interface {
+	Register(jobType string, handler JobHandler)
+	// Run ดึงงานจากคิวจนกว่า ctx จะถูกยกเลิก แล้วรอให้งานที่กำลังทำเสร็จก่อนคืนค่า
+	Run(ctx context.Context)
+}
+
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError ใช้กับข้อผิดพลาดที่ลองใหม่ก็ไม่หาย เช่น payload ไม่ถูกต้อง
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+type jobWorker struct {
+	repo     repository.JobRepository
+	cfg      config.JobWorkerConfig
+	workerID string
+	handlers map[string]JobHandler
+}
+
+func NewJobWorker(repo repository.JobRepository, cfg config.JobWorkerConfig) JobWorker {
+	hostname, _ := os.Hostname()
+	return &jobWorker{
+		repo:     repo,
+		cfg:      cfg,
+		workerID: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		handlers: make(map[string]JobHandler),
+	}
+}
+
+func (w *jobWorker) Register(jobType string, handler JobHandler) {
+	w.handlers[jobType] = handler
+}
+
+func (w *jobWorker) Run(ctx context.Context) {
+	jobTypes := make([]string, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		jobTypes = append(jobTypes, jobType)
+	}
+	sort.Strings(jobTypes)
+	log.Printf("jobs: worker %s started (concurrency=%d, types=%v)", w.workerID, w.cfg.Concurrency, jobTypes)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.maintain(ctx)
+	}()
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx, jobTypes)
+		}()
+	}
+	wg.Wait()
+	log.Printf("jobs: worker %s stopped", w.workerID)
+}
+
+func (w *jobWorker) poll(ctx context.Context, jobTypes []string) {
+	for ctx.Err() == nil {
+		job, err := w.repo.Claim(ctx, w.workerID, jobTypes)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("jobs: claim failed: %v", err)
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+		w.process(job)
+	}
+}
+
+// process ใช้ context แยกจาก ctx ของ worker เพื่อให้งานที่เริ่มแล้วทำจนเสร็จตอนปิด worker (ภายใน LockTimeout)
+func (w *jobWorker) process(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.LockTimeout)
+	defer cancel()
+
+	err := w.runHandler(ctx, job)
+	if err == nil {
+		if err := w.repo.Complete(ctx, job.JobID); err != nil {
+			log.Printf("jobs: complete job %d: %v", job.JobID, err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	var permanent *permanentJobError
+	if !errors.As(err, &permanent) && job.Attempts < job.MaxAttempts {
+		next := time.Now().Add(jobBackoff(job.Attempts))
+		retryAt = &next
+	}
+	if retryAt == nil {
+		log.Printf("jobs: job %d (%s) moved to dead letter after %d attempts: %v", job.JobID, job.Type, job.Attempts, err)
+	}
+	if err := w.repo.Fail(ctx, job.JobID, err.Error(), retryAt); err != nil {
+		log.Printf("jobs: fail job %d: %v", job.JobID, err)
+	}
+}
+
+func (w *jobWorker) runHandler(ctx context.Context, job *models.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return PermanentJobError(fmt.Errorf("no handler for job type %q", job.Type))
+	}
+	return handler(ctx, job)
+}
+
+// maintain คืนงานที่ค้างล็อก และสร้างงานจาก cron ที่ถึงเวลา ทุก 1 นาที
+func (w *jobWorker) maintain(ctx context.Context) {
+	ticker := time.NewTicker(jobMaintenanceEvery)
+	defer ticker.Stop()
+	for {
+		if released, err := w.repo.ReleaseStale(ctx, time.Now().Add(-w.cfg.LockTimeout)); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("jobs: release stale jobs: %v", err)
+			}
+		} else if released > 0 {
+			log.Printf("jobs: released %d stale jobs", released)
+		}
+
+		if _, err := w.repo.EnqueueDueSchedules(ctx, time.Now(), nextScheduleRun); err != nil && ctx.Err() == nil {
+			log.Printf("jobs: enqueue scheduled jobs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func nextScheduleRun(schedule models.JobSchedule) (time.Time, error) {
+	cron, err := parseCron(schedule.CronExpr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule %s: %w", schedule.Name, err)
+	}
+	return cron.Next(time.Now()), nil
+}
+
+// jobBackoff 30s, 1m, 2m, ... สูงสุด 1 ชั่วโมง บวก jitter ไม่เกิน 20% เพื่อไม่ให้งานที่ล้มพร้อมกันกลับมาพร้อมกัน
+func jobBackoff(attempt int) time.Duration {
+	backoff := jobBaseBackoff
+	for i := 1; i < attempt && backoff < jobMaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, jobMaxBackoff)
+	return backoff + time.Duration(rand.Int63n(int64(backoff/5)+1))
+}
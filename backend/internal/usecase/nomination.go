@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NominationCSVColumns คอลัมน์ของไฟล์เสนอชื่อแบบกลุ่ม (แถวแรกเป็นหัวตาราง เรียงลำดับใดก็ได้)
+// form_answers เป็น JSON object ของคำตอบฟิลด์เฉพาะประเภทรางวัล (ไม่บังคับ)
+// GPA, ที่อยู่ และวันเกิด นิสิตกรอกเองตอนยินยอม จึงไม่มีในไฟล์
+var NominationCSVColumns = []string{
+	"student_number",
+	"student_firstname",
+	"student_lastname",
+	"student_email",
+	"faculty_id",
+	"department_id",
+	"award_type",
+	"student_year",
+	"advisor_name",
+	"student_phone_number",
+	"form_detail",
+	"form_answers",
+}
+
+// maxBulkNominationRows จำกัดจำนวนแถวต่อไฟล์
+const maxBulkNominationRows = 500
+
+// สถานะของการเสนอชื่อโดยองค์กรก่อนเข้าสู่การพิจารณา (ดู SeedFormStatus)
+const (
+	formStatusAwaitingConsent = 13
+	formStatusConsentDeclined = 14
+)
+
+// ErrNominationPendingConsent ฟอร์มที่รอนิสิตยินยอมหรือนิสิตปฏิเสธแล้ว ยังไม่เข้าสู่การพิจารณา
+// ออกจากสถานะ 13 ได้ทาง AcceptNomination/DeclineNomination เท่านั้น
+var ErrNominationPendingConsent = errors.New("form is awaiting or was declined by the nominee's consent")
+
+// checkConsentResolved กันผู้พิจารณาเปลี่ยนสถานะฟอร์มที่ยังไม่ผ่านความยินยอมของนิสิต
+func checkConsentResolved(form *models.AwardForm) error {
+	if form.FormStatusID == formStatusAwaitingConsent || form.FormStatusID == formStatusConsentDeclined {
+		return ErrNominationPendingConsent
+	}
+	return nil
+}
+
+// matchNominee นิสิตที่ฟอร์มเสนอชื่อจะผูกด้วย ต้องตรงทั้งรหัสนิสิตและอีเมลบัญชี (เหมือน linkNominations) ไม่ตรงคืน nil
+func (u *awardUseCase) matchNominee(ctx context.Context, studentNumber, email string) *models.Student {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+	nominee, err := u.studentService.GetStudentByStudentNumber(ctx, studentNumber)
+	if err != nil || nominee == nil || !strings.EqualFold(strings.TrimSpace(nominee.User.Email), email) {
+		return nil
+	}
+	return nominee
+}
+
+func (u *awardUseCase) GetNominations(ctx context.Context, userID uint, req awardformdto.NominationListRequest) (*awardformdto.PaginatedAwardResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := repository.NominationFilter{
+		NominatorUserID: userID,
+		AcademicYear:    req.AcademicYear,
+		Semester:        req.Semester,
+		FormStatusID:    req.FormStatus,
+		Keyword:         req.Keyword,
+		Page:            page,
+		Limit:           limit,
+	}
+	if strings.TrimSpace(req.AwardType) != "" {
+		awardType, err := u.awardTypeService.Resolve(ctx, req.AwardType)
+		if err != nil {
+			return nil, err
+		}
+		filter.AwardTypeID = awardType.AwardTypeID
+	}
+
+	forms, total, err := u.repo.GetNominations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// องค์กรผู้เสนอชื่อไม่เห็นข้อมูลส่วนตัวที่นิสิตกรอกตอนยินยอม (ดู hiddenPersonalFields)
+	response, err := newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, forms)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &awardformdto.PaginatedAwardResponse{
+		Data: response,
+		Pagination: awardformdto.PaginationMeta{
+			CurrentPage: page,
+			TotalPages:  totalPages,
+			TotalItems:  total,
+			Limit:       limit,
+		},
+	}, nil
+}
+
+// BulkNominate เสนอชื่อจากไฟล์ CSV ทีละแถวด้วยเงื่อนไขเดียวกับ SubmitAward
+// แถวที่ผิดจะถูกข้ามและรายงานกลับ แถวที่ผ่านถูกบันทึกทันที (ไม่ใช่ all-or-nothing)
+func (u *awardUseCase) BulkNominate(ctx context.Context, userID uint, file io.Reader) (*awardformdto.BulkNominationResponse, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("nomination file is empty")
+		}
+		return nil, fmt.Errorf("invalid nomination file: %w", err)
+	}
+	columns, err := nominationColumnIndex(header)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([][]string, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid nomination file: %w", err)
+		}
+		if isBlankRecord(record) {
+			records = append(records, nil)
+			continue
+		}
+		records = append(records, record)
+		if len(records) > maxBulkNominationRows {
+			return nil, fmt.Errorf("nomination file must not exceed %d rows", maxBulkNominationRows)
+		}
+	}
+
+	response := &awardformdto.BulkNominationResponse{Rows: make([]awardformdto.BulkNominationRowResult, 0, len(records))}
+	for i, record := range records {
+		if record == nil {
+			continue
+		}
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		result := awardformdto.BulkNominationRowResult{Row: i + 2, StudentNumber: value("student_number")}
+		req, err := nominationRequestFromRow(value)
+		if err == nil {
+			result.Linked = u.matchNominee(ctx, strings.TrimSpace(req.StudentNumber), req.StudentEmail) != nil
+			err = u.SubmitAward(ctx, userID, req, nil)
+		}
+
+		if err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			result.Linked = false
+			response.Failed++
+		} else {
+			result.Status = "created"
+			response.Created++
+		}
+		response.Rows = append(response.Rows, result)
+	}
+	response.Total = len(response.Rows)
+	return response, nil
+}
+
+// nominationColumnIndex ตำแหน่งของแต่ละคอลัมน์จากหัวตาราง (ไม่สนตัวพิมพ์ และตัด BOM ของ Excel)
+func nominationColumnIndex(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(NominationCSVColumns))
+	for _, column := range NominationCSVColumns {
+		known[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("invalid nomination file: unknown column %q", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("invalid nomination file: duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+
+	missing := make([]string, 0)
+	for _, column := range NominationCSVColumns {
+		if _, ok := columns[column]; !ok && column != "form_answers" {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("invalid nomination file: missing columns %s", strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// nominationRequestFromRow ตรวจค่าในแถวเหมือนที่ handler ตรวจ form value ของการส่งฟอร์มแบบองค์กร
+func nominationRequestFromRow(value func(string) string) (awardformdto.SubmitAwardRequest, error) {
+	var req awardformdto.SubmitAwardRequest
+	for _, column := range NominationCSVColumns {
+		if column != "form_answers" && value(column) == "" {
+			return req, fmt.Errorf("%s is required", column)
+		}
+	}
+
+	req.StudentNumber = value("student_number")
+	req.StudentFirstname = value("student_firstname")
+	req.StudentLastname = value("student_lastname")
+	req.StudentEmail = value("student_email")
+	req.AwardType = value("award_type")
+	req.AdvisorName = value("advisor_name")
+	req.StudentPhoneNumber = value("student_phone_number")
+	req.FormDetail = value("form_detail")
+
+	var err error
+	if req.FacultyID, err = strconv.Atoi(value("faculty_id")); err != nil || req.FacultyID <= 0 {
+		return req, errors.New("faculty_id must be a valid number")
+	}
+	if req.DepartmentID, err = strconv.Atoi(value("department_id")); err != nil || req.DepartmentID <= 0 {
+		return req, errors.New("department_id must be a valid number")
+	}
+	if req.StudentYear, err = strconv.Atoi(value("student_year")); err != nil || req.StudentYear <= 0 {
+		return req, errors.New("student_year must be a valid number")
+	}
+	if answers := value("form_answers"); answers != "" {
+		if err := json.Unmarshal([]byte(answers), &req.FormAnswers); err != nil {
+			return req, errors.New("form_answers must be a JSON object")
+		}
+	}
+	return req, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *awardUseCase) GetPendingConsents(ctx context.Context, userID uint) ([]awardformdto.AwardFormResponse, error) {
+	forms, err := u.repo.GetAwaitingConsent(ctx, userID, formStatusAwaitingConsent)
+	if err != nil {
+		return nil, err
+	}
+	return newFormReadAccess(u.repo, &models.User{UserID: userID}).mapForms(ctx, forms)
+}
+
+// AcceptNomination นิสิตยินยอมและกรอกข้อมูลส่วนตัว ฟอร์มจึงเข้าสู่การพิจารณา (สถานะ 1)
+func (u *awardUseCase) AcceptNomination(ctx context.Context, userID uint, formID uint, req awardformdto.NomineeConsentRequest) error {
+	form, err := u.nominationForConsent(ctx, userID, formID)
+	if err != nil {
+		return err
+	}
+
+	if req.GPA == nil || *req.GPA < 0 || *req.GPA > 4 {
+		return errors.New("gpa is required and must be between 0 and 4")
+	}
+	address := strings.TrimSpace(req.StudentAddress)
+	if address == "" {
+		return errors.New("student_address is required")
+	}
+	dob, err := time.Parse("2006-01-02", strings.TrimSpace(req.StudentDateOfBirth))
+	if err != nil {
+		return errors.New("student_date_of_birth is required (YYYY-MM-DD)")
+	}
+
+	// GPA ขั้นต่ำของประเภทรางวัลยังไม่ได้ตรวจตอนองค์กรส่ง
+	awardType, err := u.awardTypeService.Resolve(ctx, form.AwardType)
+	if err != nil {
+		return err
+	}
+	if err := u.awardTypeService.CheckEligibility(ctx, awardType, form.AcademicYear, AwardEligibility{
+		RoleID:      form.SubmitterRoleID,
+		StudentYear: form.StudentYear,
+		GPA:         req.GPA,
+	}); err != nil {
+		return err
+	}
+
+	changes := models.AwardForm{
+		GPA:                *req.GPA,
+		StudentAddress:     address,
+		StudentDateOfBirth: dob,
+	}
+	columns := []string{"gpa", "student_address", "student_date_of_birth"}
+	if phone := strings.TrimSpace(req.StudentPhoneNumber); phone != "" {
+		changes.StudentPhoneNumber = phone
+		columns = append(columns, "student_phone_number")
+	}
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.RespondNomination(ctx, formID, userID, formStatusAwaitingConsent, 1, changes, columns...); err != nil {
+			return err
+		}
+		return u.publishWebhook(ctx, NotificationEventConsentAccepted, form, 1, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.notify(ctx, NotificationEventConsentAccepted, form, 1, userID, "")
+	return nil
+}
+
+// DeclineNomination นิสิตปฏิเสธการเสนอชื่อ ฟอร์มสิ้นสุดโดยไม่เข้าสู่การพิจารณา
+func (u *awardUseCase) DeclineNomination(ctx context.Context, userID uint, formID uint, req awardformdto.NomineeDeclineRequest) error {
+	form, err := u.nominationForConsent(ctx, userID, formID)
+	if err != nil {
+		return err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	err = u.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.RespondNomination(ctx, formID, userID, formStatusAwaitingConsent, formStatusConsentDeclined, models.AwardForm{
+			RejectReason: reason,
+		}, "reject_reason"); err != nil {
+			return err
+		}
+		return u.publishWebhook(ctx, NotificationEventConsentDeclined, form, formStatusConsentDeclined, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.notify(ctx, NotificationEventConsentDeclined, form, formStatusConsentDeclined, userID, reason)
+	return nil
+}
+
+// nominationForConsent ตรวจเบื้องต้นก่อนตอบรับ/ปฏิเสธ (repository ตรวจซ้ำใน transaction)
+func (u *awardUseCase) nominationForConsent(ctx context.Context, userID uint, formID uint) (*models.AwardForm, error) {
+	form, err := u.repo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("form not found")
+		}
+		return nil, err
+	}
+	if form.NomineeUserID == nil || *form.NomineeUserID != userID {
+		return nil, errors.New("forbidden: only the nominee can respond to this nomination")
+	}
+	if form.FormStatusID != formStatusAwaitingConsent {
+		return nil, errors.New("form is not awaiting nominee consent")
+	}
+	return form, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"backend/config"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const (
+	NotificationChannelInApp = "in_app"
+	NotificationChannelEmail = "email"
+)
+
+// NotificationChannel ช่องทางส่งการแจ้งเตือน เพิ่มช่องทางใหม่ได้โดย implement interface นี้แล้วส่งเข้า NewNotificationService
+type NotificationChannel interface {
+	Name() string
+	Send(ctx context.Context, recipient models.User, notification *models.Notification) error
+}
+
+type inAppChannel struct {
+	repo     repository.NotificationRepository
+	realtime RealtimeService
+}
+
+// NewInAppChannel บันทึกการแจ้งเตือนลง inbox ในระบบ (ตาราง Notification) และ push ให้ client ที่เปิด stream อยู่ (realtime เป็น nil ได้)
+func NewInAppChannel(repo repository.NotificationRepository, realtime RealtimeService) NotificationChannel {
+	return &inAppChannel{repo: repo, realtime: realtime}
+}
+
+func (c *inAppChannel) Name() string {
+	return NotificationChannelInApp
+}
+
+func (c *inAppChannel) Send(ctx context.Context, recipient models.User, notification *models.Notification) error {
+	record := *notification
+	record.UserID = recipient.UserID
+	if err := c.repo.Create(ctx, &record); err != nil {
+		return err
+	}
+
+	if c.realtime != nil {
+		var formID uint
+		if record.FormID != nil {
+			formID = *record.FormID
+		}
+		c.realtime.Publish(ctx, RealtimeEventNotification, formID, RealtimeTarget{
+			UserIDs: []uint{record.UserID},
+		}, mapToNotificationResponses([]models.Notification{record})[0])
+	}
+	return nil
+}
+
+type queuedChannel struct {
+	name string
+	jobs JobService
+}
+
+// NewQueuedChannel ส่งการแจ้งเตือนผ่านคิวงานแทนการส่งทันที (ลองใหม่อัตโนมัติเมื่อส่งไม่สำเร็จ)
+// worker ต้องลงทะเบียน NotificationSendJobHandler พร้อมช่องทางจริงที่ชื่อ name
+func NewQueuedChannel(name string, jobs JobService) NotificationChannel {
+	return &queuedChannel{name: name, jobs: jobs}
+}
+
+func (c *queuedChannel) Name() string {
+	return c.name
+}
+
+func (c *queuedChannel) Send(ctx context.Context, recipient models.User, notification *models.Notification) error {
+	_, err := c.jobs.Enqueue(ctx, JobTypeNotificationSend, notificationJobPayload{
+		Channel:      c.name,
+		UserID:       recipient.UserID,
+		Notification: *notification,
+	}, EnqueueOptions{})
+	return err
+}
+
+type emailChannel struct {
+	cfg             config.SMTPConfig
+	frontendBaseURL string
+}
+
+// NewEmailChannel ส่งอีเมลผ่าน SMTP (ใช้ STARTTLS อัตโนมัติถ้า server รองรับ)
+func NewEmailChannel(cfg config.SMTPConfig, frontendBaseURL string) NotificationChannel {
+	return &emailChannel{cfg: cfg, frontendBaseURL: strings.TrimRight(frontendBaseURL, "/")}
+}
+
+func (c *emailChannel) Name() string {
+	return NotificationChannelEmail
+}
+
+func (c *emailChannel) Send(ctx context.Context, recipient models.User, notification *models.Notification) error {
+	to := strings.TrimSpace(recipient.Email)
+	if to == "" {
+		return errors.New("recipient has no email")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body := notification.Message
+	if notification.FormID != nil && c.frontendBaseURL != "" {
+		body += fmt.Sprintf("\n\nดูรายละเอียด: %s/awards/%d", c.frontendBaseURL, *notification.FormID)
+	}
+
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(c.cfg.Host, c.cfg.Port)
+	return smtp.SendMail(addr, auth, c.cfg.From, []string{to}, buildEmailMessage(c.cfg.From, to, notification.Title, body))
+}
+
+// buildEmailMessage สร้างอีเมล text/plain UTF-8 (encode base64 เพราะเนื้อหาเป็นภาษาไทย)
+func buildEmailMessage(from, to, subject, body string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+	return msg.Bytes()
+}
@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	notificationdto "backend/internal/dto/notification_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// เหตุการณ์ของ workflow ที่ส่งการแจ้งเตือน
+const (
+	NotificationEventSubmitted    = "form.submitted"
+	NotificationEventApproved     = "form.approved"
+	NotificationEventRejected     = "form.rejected"
+	NotificationEventVoteMajority = "form.vote_majority"
+	NotificationEventSigned       = "form.signed"
+	NotificationEventAwardType    = "form.award_type_changed"
+	NotificationEventSLAReminder  = "form.sla_reminder"
+	NotificationEventSLAEscalated = "form.sla_escalated"
+
+	// การเสนอชื่อโดยองค์กรที่ต้องรอนิสิตยินยอม
+	NotificationEventConsentRequested = "form.consent_requested"
+	NotificationEventConsentAccepted  = "form.consent_accepted"
+	NotificationEventConsentDeclined  = "form.consent_declined"
+)
+
+const (
+	notificationDispatchTimeout = 30 * time.Second
+	bellLatestLimit             = 5
+)
+
+// NotificationEvent การเปลี่ยนสถานะของฟอร์มหนึ่งครั้ง FormStatusID คือสถานะหลังเปลี่ยน
+// Recipients ใช้กับการเตือน SLA ซึ่งผู้เรียกเป็นผู้กำหนดผู้รับเอง
+type NotificationEvent struct {
+	Type         string
+	Form         models.AwardForm
+	FormStatusID int
+	ActorID      uint
+	Reason       string
+	Recipients   []uint
+
+	// PreviousAwardType ประเภทรางวัลเดิม (เฉพาะ NotificationEventAwardType)
+	PreviousAwardType string
+}
+
+type NotificationService interface {
+	// Publish ส่งการแจ้งเตือนแบบ background ไม่ทำให้ request ที่เปลี่ยนสถานะล้มเหลว
+	Publish(ctx context.Context, event NotificationEvent)
+	GetPreferences(ctx context.Context, userID uint) ([]notificationdto.ChannelPreference, error)
+	UpdatePreferences(ctx context.Context, userID uint, req notificationdto.UpdatePreferencesRequest) ([]notificationdto.ChannelPreference, error)
+
+	// inbox ของผู้ใช้ (ช่องทาง in_app)
+	GetInbox(ctx context.Context, userID uint, unreadOnly bool, page, limit int) ([]notificationdto.NotificationResponse, int64, error)
+	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
+	GetBell(ctx context.Context, userID uint) (*notificationdto.BellResponse, error)
+	MarkAsRead(ctx context.Context, userID uint, notificationID uint) error
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+}
+
+type notificationService struct {
+	repo     repository.NotificationRepository
+	channels []NotificationChannel
+}
+
+func NewNotificationService(repo repository.NotificationRepository, channels ...NotificationChannel) NotificationService {
+	return &notificationService{repo: repo, channels: channels}
+}
+
+// notificationEventForStatus แปลงสถานะปลายทางเป็นชนิดเหตุการณ์ (ใช้กับการอนุมัติ/ตีกลับ/ลงนาม)
+func notificationEventForStatus(formStatus int) string {
+	switch {
+	case isRejectOrReturnStatus(formStatus):
+		return NotificationEventRejected
+	case shouldCreateSignedLog(formStatus):
+		return NotificationEventSigned
+	default:
+		return NotificationEventApproved
+	}
+}
+
+func (s *notificationService) Publish(ctx context.Context, event NotificationEvent) {
+	// ไม่ใช้ ctx ของ request เพราะจะถูกยกเลิกทันทีที่ตอบกลับ
+	go func() {
+		dispatchCtx, cancel := context.WithTimeout(context.Background(), notificationDispatchTimeout)
+		defer cancel()
+		if err := s.dispatch(dispatchCtx, event); err != nil {
+			log.Printf("notification: %s form %d: %v", event.Type, event.Form.FormID, err)
+		}
+	}()
+}
+
+func (s *notificationService) dispatch(ctx context.Context, event NotificationEvent) error {
+	statusName, err := s.repo.GetFormStatusName(ctx, event.FormStatusID)
+	if err != nil {
+		statusName = fmt.Sprintf("%d", event.FormStatusID)
+	}
+
+	if event.Type == NotificationEventSLAReminder || event.Type == NotificationEventSLAEscalated {
+		return s.deliver(ctx, event.Recipients, map[uint]bool{}, buildSLANotification(event, statusName))
+	}
+
+	studentIDs := s.studentRecipients(ctx, event.Form)
+	approverIDs, err := s.nextApprovers(ctx, event.Form, event.FormStatusID)
+	if err != nil {
+		return err
+	}
+
+	sent := map[uint]bool{event.ActorID: true}
+	if err := s.deliver(ctx, studentIDs, sent, buildStudentNotification(event, statusName)); err != nil {
+		return err
+	}
+	if len(approverIDs) > 0 && event.Type != NotificationEventRejected && event.Type != NotificationEventAwardType {
+		if err := s.deliver(ctx, approverIDs, sent, buildApproverNotification(event, statusName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) deliver(ctx context.Context, userIDs []uint, sent map[uint]bool, notification *models.Notification) error {
+	pending := make([]uint, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != 0 && !sent[id] {
+			sent[id] = true
+			pending = append(pending, id)
+		}
+	}
+
+	users, err := s.repo.GetUsersByIDs(ctx, pending)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		enabled, err := s.enabledChannels(ctx, user.UserID)
+		if err != nil {
+			log.Printf("notification: preferences of user %d: %v", user.UserID, err)
+			continue
+		}
+		for _, channel := range s.channels {
+			if !enabled[channel.Name()] {
+				continue
+			}
+			if err := channel.Send(ctx, user, notification); err != nil {
+				log.Printf("notification: %s to user %d: %v", channel.Name(), user.UserID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// studentRecipients ผู้ส่งฟอร์ม และนิสิตเจ้าของชื่อ (กรณีองค์กรเป็นผู้เสนอชื่อ)
+func (s *notificationService) studentRecipients(ctx context.Context, form models.AwardForm) []uint {
+	if form.NomineeUserID != nil {
+		return formPartyUserIDs(&form)
+	}
+	recipients := []uint{form.UserID}
+	if form.StudentNumber != "" {
+		if studentUserID, err := s.repo.GetUserIDByStudentNumber(ctx, form.StudentNumber); err == nil && studentUserID != form.UserID {
+			recipients = append(recipients, studentUserID)
+		}
+	}
+	return recipients
+}
+
+// nextApprovers ผู้ที่ต้องพิจารณาฟอร์มในขั้นถัดไปตามสถานะปัจจุบัน (ดู requiredFormStatusByRole)
+func (s *notificationService) nextApprovers(ctx context.Context, form models.AwardForm, formStatus int) ([]uint, error) {
+	return approverUserIDs(ctx, s.repo, form, formStatus)
+}
+
+// approverUserIDs ผู้มีหน้าที่พิจารณาฟอร์มที่อยู่ในสถานะ formStatus
+func approverUserIDs(ctx context.Context, repo repository.NotificationRepository, form models.AwardForm, formStatus int) ([]uint, error) {
+	switch formStatus {
+	case 1:
+		return repo.GetHeadOfDepartmentUserIDs(ctx, form.DepartmentID)
+	case 2:
+		return repo.GetFacultyApproverUserIDs(ctx, 3, form.FacultyID)
+	case 4:
+		return repo.GetFacultyApproverUserIDs(ctx, 4, form.FacultyID)
+	case 6:
+		return repo.GetUserIDsByRoleAndCampus(ctx, 5, form.CampusID)
+	case formStatusCommitteeReview:
+		return repo.GetCommitteeUserIDs(ctx, form.CampusID, false)
+	case 9:
+		return repo.GetCommitteeUserIDs(ctx, form.CampusID, true)
+	case 11:
+		return repo.GetUserIDsByRoleAndCampus(ctx, 7, form.CampusID)
+	default:
+		return nil, nil
+	}
+}
+
+func buildStudentNotification(event NotificationEvent, statusName string) *models.Notification {
+	form := event.Form
+	n := newFormNotification(event)
+
+	switch event.Type {
+	case NotificationEventSubmitted:
+		n.Title = fmt.Sprintf("ส่งฟอร์มเสนอชื่อ #%d เรียบร้อยแล้ว", form.FormID)
+		n.Message = fmt.Sprintf("ฟอร์มเสนอชื่อรางวัล %s ปีการศึกษา %d ภาคเรียนที่ %d ถูกส่งเข้าสู่การพิจารณาแล้ว", form.AwardType, form.AcademicYear, form.Semester)
+	case NotificationEventRejected:
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d ไม่ผ่านการพิจารณา", form.FormID)
+		n.Message = "สถานะ: " + statusName
+		if strings.TrimSpace(event.Reason) != "" {
+			n.Message += "\nเหตุผล: " + strings.TrimSpace(event.Reason)
+		}
+	case NotificationEventVoteMajority:
+		n.Title = fmt.Sprintf("คณะกรรมการลงมติฟอร์มเสนอชื่อ #%d แล้ว", form.FormID)
+		n.Message = "สถานะ: " + statusName
+	case NotificationEventSigned:
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d ได้รับการลงนามแล้ว", form.FormID)
+		n.Message = "สถานะ: " + statusName
+	case NotificationEventConsentRequested:
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d รอการยินยอมจากนิสิต", form.FormID)
+		n.Message = fmt.Sprintf("%s เสนอชื่อ %s %s (%s) รับรางวัล %s ปีการศึกษา %d ภาคเรียนที่ %d\nนิสิตต้องตอบรับและกรอกข้อมูลส่วนตัวก่อนฟอร์มจะเข้าสู่การพิจารณา",
+			form.OrgName, form.StudentFirstname, form.StudentLastname, form.StudentNumber, form.AwardType, form.AcademicYear, form.Semester)
+	case NotificationEventConsentAccepted:
+		n.Title = fmt.Sprintf("นิสิตยินยอมการเสนอชื่อ #%d แล้ว", form.FormID)
+		n.Message = fmt.Sprintf("ฟอร์มเสนอชื่อรางวัล %s ถูกส่งเข้าสู่การพิจารณาแล้ว", form.AwardType)
+	case NotificationEventConsentDeclined:
+		n.Title = fmt.Sprintf("นิสิตปฏิเสธการเสนอชื่อ #%d", form.FormID)
+		n.Message = fmt.Sprintf("%s %s (%s) ปฏิเสธการเสนอชื่อรางวัล %s", form.StudentFirstname, form.StudentLastname, form.StudentNumber, form.AwardType)
+		if strings.TrimSpace(event.Reason) != "" {
+			n.Message += "\nเหตุผล: " + strings.TrimSpace(event.Reason)
+		}
+	case NotificationEventAwardType:
+		n.Title = fmt.Sprintf("ประเภทรางวัลของฟอร์มเสนอชื่อ #%d ถูกเปลี่ยน", form.FormID)
+		n.Message = fmt.Sprintf("ประเภทรางวัล: %s -> %s", event.PreviousAwardType, form.AwardType)
+	default:
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d ผ่านการอนุมัติ", form.FormID)
+		n.Message = "สถานะ: " + statusName
+	}
+	return n
+}
+
+func buildApproverNotification(event NotificationEvent, statusName string) *models.Notification {
+	form := event.Form
+	n := newFormNotification(event)
+	n.Title = fmt.Sprintf("มีฟอร์มเสนอชื่อ #%d รอการพิจารณา", form.FormID)
+	n.Message = fmt.Sprintf("%s %s (%s) เสนอชื่อรางวัล %s\nสถานะปัจจุบัน: %s",
+		form.StudentFirstname, form.StudentLastname, form.StudentNumber, form.AwardType, statusName)
+	return n
+}
+
+// buildSLANotification การเตือนใกล้ครบกำหนด / แจ้งเกินกำหนด (Reason คือรายละเอียดกำหนดเวลา)
+func buildSLANotification(event NotificationEvent, statusName string) *models.Notification {
+	form := event.Form
+	n := newFormNotification(event)
+	if event.Type == NotificationEventSLAEscalated {
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d เกินกำหนดการพิจารณา", form.FormID)
+	} else {
+		n.Title = fmt.Sprintf("ฟอร์มเสนอชื่อ #%d ใกล้ครบกำหนดการพิจารณา", form.FormID)
+	}
+	n.Message = fmt.Sprintf("%s %s (%s) เสนอชื่อรางวัล %s\nสถานะปัจจุบัน: %s\n%s",
+		form.StudentFirstname, form.StudentLastname, form.StudentNumber, form.AwardType, statusName, event.Reason)
+	return n
+}
+
+func newFormNotification(event NotificationEvent) *models.Notification {
+	formID := event.Form.FormID
+	return &models.Notification{
+		FormID:    &formID,
+		EventType: event.Type,
+		CreatedAt: time.Now(),
+	}
+}
+
+// enabledChannels ช่องทางที่ผู้ใช้เปิดรับ (ค่าเริ่มต้นเปิดทุกช่องทาง)
+func (s *notificationService) enabledChannels(ctx context.Context, userID uint) (map[string]bool, error) {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make(map[string]bool, len(preferences))
+	for _, p := range preferences {
+		enabled[p.Channel] = p.Enabled
+	}
+	return enabled, nil
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID uint) ([]notificationdto.ChannelPreference, error) {
+	stored, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	storedByChannel := make(map[string]bool, len(stored))
+	for _, p := range stored {
+		storedByChannel[p.Channel] = p.Enabled
+	}
+
+	preferences := make([]notificationdto.ChannelPreference, 0, len(s.channels))
+	for _, channel := range s.channels {
+		enabled, ok := storedByChannel[channel.Name()]
+		if !ok {
+			enabled = true
+		}
+		preferences = append(preferences, notificationdto.ChannelPreference{Channel: channel.Name(), Enabled: enabled})
+	}
+	return preferences, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, userID uint, req notificationdto.UpdatePreferencesRequest) ([]notificationdto.ChannelPreference, error) {
+	known := make(map[string]bool, len(s.channels))
+	for _, channel := range s.channels {
+		known[channel.Name()] = true
+	}
+	for name := range req.Channels {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown channel: %s", name)
+		}
+	}
+
+	now := time.Now()
+	for name, enabled := range req.Channels {
+		if err := s.repo.UpsertPreference(ctx, &models.NotificationPreference{
+			UserID:    userID,
+			Channel:   name,
+			Enabled:   enabled,
+			UpdatedAt: now,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+func (s *notificationService) GetInbox(ctx context.Context, userID uint, unreadOnly bool, page, limit int) ([]notificationdto.NotificationResponse, int64, error) {
+	notifications, total, err := s.repo.GetByUserID(ctx, userID, unreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapToNotificationResponses(notifications), total, nil
+}
+
+func (s *notificationService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+func (s *notificationService) GetBell(ctx context.Context, userID uint) (*notificationdto.BellResponse, error) {
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	latest, _, err := s.repo.GetByUserID(ctx, userID, false, bellLatestLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &notificationdto.BellResponse{
+		UnreadCount: unread,
+		Latest:      mapToNotificationResponses(latest),
+	}, nil
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, userID uint, notificationID uint) error {
+	if err := s.repo.MarkAsRead(ctx, userID, notificationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	return s.repo.MarkAllAsRead(ctx, userID)
+}
+
+func mapToNotificationResponses(notifications []models.Notification) []notificationdto.NotificationResponse {
+	responses := make([]notificationdto.NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		response := notificationdto.NotificationResponse{
+			NotificationID: n.NotificationID,
+			FormID:         n.FormID,
+			EventType:      n.EventType,
+			Title:          n.Title,
+			Message:        n.Message,
+			IsRead:         n.IsRead,
+			ReadAt:         n.ReadAt,
+			CreatedAt:      n.CreatedAt,
+		}
+		if n.FormID != nil {
+			response.Link = fmt.Sprintf("/api/awards/details/%d", *n.FormID)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"archive/zip"
+	privacydto "backend/internal/dto/privacy_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const maxErasureReasonLength = 2000
+
+// PrivacyService สิทธิของเจ้าของข้อมูลตาม PDPA: ขอสำเนาข้อมูล และขอลบข้อมูล (กองพัฒนานิสิตตรวจก่อน)
+type PrivacyService interface {
+	ExportPersonalData(ctx context.Context, userID uint) ([]byte, string, error)
+
+	RequestErasure(ctx context.Context, user *models.User, reason string) (*models.ErasureRequest, error)
+	GetMyErasureRequests(ctx context.Context, userID uint) ([]models.ErasureRequest, error)
+	GetErasureRequests(ctx context.Context, status string, page, limit int) ([]models.ErasureRequest, int64, error)
+	ApproveErasure(ctx context.Context, requestID uint, reviewedBy uint, note string) (*models.ErasureRequest, error)
+	RejectErasure(ctx context.Context, requestID uint, reviewedBy uint, note string) (*models.ErasureRequest, error)
+}
+
+type privacyService struct {
+	repo                repository.PrivacyRepository
+	profileImageService ProfileImageService
+	uploadRoot          string
+}
+
+// NewPrivacyService uploadRoot คือโฟลเดอร์ที่เก็บไฟล์แนบ (เช่น "uploads") จะไม่อ่านหรือลบไฟล์นอกโฟลเดอร์นี้
+func NewPrivacyService(repo repository.PrivacyRepository, profileImageService ProfileImageService, uploadRoot string) PrivacyService {
+	return &privacyService{repo: repo, profileImageService: profileImageService, uploadRoot: filepath.Clean(uploadRoot)}
+}
+
+// ExportPersonalData รวบรวมข้อมูลทั้งหมดของผู้ใช้เป็น zip: data.json และไฟล์แนบใน files/
+func (s *privacyService) ExportPersonalData(ctx context.Context, userID uint) ([]byte, string, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errors.New("user not found")
+		}
+		return nil, "", err
+	}
+
+	export := privacydto.PersonalDataExport{
+		ExportedAt: time.Now(),
+		User: privacydto.UserExport{
+			UserID:       user.UserID,
+			Prefix:       user.Prefix,
+			Firstname:    user.Firstname,
+			Lastname:     user.Lastname,
+			Email:        user.Email,
+			ImagePath:    user.ImagePath,
+			Provider:     user.Provider,
+			RoleID:       user.RoleID,
+			CampusID:     user.CampusID,
+			CreatedAt:    user.CreatedAt,
+			LatestUpdate: user.LatestUpdate,
+		},
+		Files: make(map[string]string),
+	}
+
+	student, err := s.repo.GetStudentByUserID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if student != nil {
+		export.Student = &privacydto.StudentExport{
+			StudentID:     student.StudentID,
+			StudentNumber: student.StudentNumber,
+			FacultyID:     student.FacultyID,
+			DepartmentID:  student.DepartmentID,
+		}
+	}
+
+	if export.Forms, err = s.repo.GetFormsByUser(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	formIDs := make([]uint, 0, len(export.Forms))
+	for _, form := range export.Forms {
+		formIDs = append(formIDs, form.FormID)
+	}
+	if export.StatusLogs, err = s.repo.GetStatusLogs(ctx, formIDs); err != nil {
+		return nil, "", err
+	}
+	if export.ApprovalLogs, err = s.repo.GetApprovalLogs(ctx, formIDs); err != nil {
+		return nil, "", err
+	}
+	if export.TypeLogs, err = s.repo.GetTypeLogs(ctx, formIDs); err != nil {
+		return nil, "", err
+	}
+	if export.Notifications, err = s.repo.GetNotifications(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	if export.NotificationPreferences, err = s.repo.GetNotificationPreferences(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	if export.PublicationPreference, err = s.repo.GetPublicationPreference(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	if export.ErasureRequests, err = s.repo.GetErasureRequestsByUser(ctx, userID); err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	// ไฟล์ที่อ่านไม่ได้ (ถูกลบตามนโยบายการเก็บรักษาไปแล้ว) จะไม่อยู่ใน archive และไม่อยู่ใน Files
+	addFile := func(name string, source string) error {
+		localPath, ok := s.localUploadPath(source)
+		if !ok {
+			return nil
+		}
+		content, err := os.ReadFile(localPath)
+		if err != nil {
+			return nil
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(content); err != nil {
+			return err
+		}
+		export.Files[name] = source
+		return nil
+	}
+	for _, form := range export.Forms {
+		for _, f := range form.AwardFiles {
+			name := path.Join("files", fmt.Sprintf("form-%d", form.FormID), fmt.Sprintf("%d-%s", f.FileDirID, filepath.Base(f.FilePath)))
+			if err := addFile(name, f.FilePath); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if user.ImagePath != "" {
+		if err := addFile(path.Join("files", "profile", filepath.Base(user.ImagePath)), user.ImagePath); err != nil {
+			return nil, "", err
+		}
+	}
+
+	content, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+	w, err := zw.Create("data.json")
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := w.Write(content); err != nil {
+		return nil, "", err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), fmt.Sprintf("personal-data-%d-%s.zip", userID, time.Now().Format("20060102")), nil
+}
+
+// RequestErasure นิสิตยื่นคำขอลบข้อมูลได้ครั้งละ 1 คำขอที่รอพิจารณา
+func (s *privacyService) RequestErasure(ctx context.Context, user *models.User, reason string) (*models.ErasureRequest, error) {
+	if user.RoleID != 1 {
+		return nil, errors.New("forbidden: only students can request data erasure")
+	}
+	reason = strings.TrimSpace(reason)
+	if len([]rune(reason)) > maxErasureReasonLength {
+		return nil, fmt.Errorf("reason must not exceed %d characters", maxErasureReasonLength)
+	}
+
+	pending, err := s.repo.HasPendingErasureRequest(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("an erasure request is already pending")
+	}
+
+	request := &models.ErasureRequest{
+		UserID:      user.UserID,
+		Reason:      reason,
+		Status:      models.ErasureStatusPending,
+		RequestedAt: time.Now(),
+	}
+	if err := s.repo.CreateErasureRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *privacyService) GetMyErasureRequests(ctx context.Context, userID uint) ([]models.ErasureRequest, error) {
+	return s.repo.GetErasureRequestsByUser(ctx, userID)
+}
+
+func (s *privacyService) GetErasureRequests(ctx context.Context, status string, page, limit int) ([]models.ErasureRequest, int64, error) {
+	switch status {
+	case "", models.ErasureStatusPending, models.ErasureStatusRejected, models.ErasureStatusCompleted:
+	default:
+		return nil, 0, errors.New("status must be pending, rejected or completed")
+	}
+	return s.repo.GetErasureRequests(ctx, status, limit, (page-1)*limit)
+}
+
+// ApproveErasure ลบ/ทำให้ข้อมูลไม่ระบุตัวตนในฐานข้อมูลก่อน แล้วจึงลบไฟล์บน disk
+// ไฟล์ที่ลบไม่สำเร็จจะถูกระบุไว้ใน Summary ให้ผู้ดูแลลบเอง
+func (s *privacyService) ApproveErasure(ctx context.Context, requestID uint, reviewedBy uint, note string) (*models.ErasureRequest, error) {
+	result, err := s.repo.EraseUser(ctx, requestID, reviewedBy, strings.TrimSpace(note))
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []string
+	filesDeleted := 0
+	for _, filePath := range result.FilePaths {
+		localPath, ok := s.localUploadPath(filePath)
+		if !ok {
+			failed = append(failed, filePath)
+			continue
+		}
+		if err := os.Remove(localPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			failed = append(failed, filePath)
+			continue
+		}
+		filesDeleted++
+	}
+	if s.profileImageService != nil {
+		if err := s.profileImageService.Remove(result.ImagePath); err != nil {
+			failed = append(failed, result.ImagePath)
+		}
+	}
+
+	summary := fmt.Sprintf("deleted %d forms, anonymised %d finalized forms, deleted %d files", result.FormsDeleted, result.FormsAnonymised, filesDeleted)
+	if len(failed) > 0 {
+		summary += "; failed to delete: " + strings.Join(failed, ", ")
+	}
+	if err := s.repo.UpdateErasureSummary(ctx, requestID, summary); err != nil {
+		log.Printf("privacy: failed to save summary of erasure request %d: %v", requestID, err)
+	}
+
+	return s.getErasureRequest(ctx, requestID)
+}
+
+func (s *privacyService) RejectErasure(ctx context.Context, requestID uint, reviewedBy uint, note string) (*models.ErasureRequest, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, errors.New("note is required when rejecting an erasure request")
+	}
+	if _, err := s.getErasureRequest(ctx, requestID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RejectErasureRequest(ctx, requestID, reviewedBy, note); err != nil {
+		return nil, err
+	}
+	return s.getErasureRequest(ctx, requestID)
+}
+
+func (s *privacyService) getErasureRequest(ctx context.Context, requestID uint) (*models.ErasureRequest, error) {
+	request, err := s.repo.GetErasureRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("erasure request not found")
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+// localUploadPath แปลง path ของไฟล์ (เช่น uploads/pdf/x.pdf หรือ /uploads/user-profile/x.jpg) เป็น path บน disk
+// เฉพาะไฟล์ที่อยู่ใน uploadRoot เท่านั้น
+func (s *privacyService) localUploadPath(filePath string) (string, bool) {
+	if filePath == "" || strings.Contains(filePath, "://") {
+		return "", false
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(filePath, "/")))
+	rel, err := filepath.Rel(s.uploadRoot, cleaned)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return cleaned, true
+}
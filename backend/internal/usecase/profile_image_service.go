@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxProfileImageBytes ต้องน้อยกว่า BodyLimit ของ fiber (ค่าเริ่มต้น 4MB)
+	maxProfileImageBytes = 3 << 20
+	// maxProfileImagePixels กันไฟล์เล็กที่ขยายเป็นภาพขนาดมหาศาลตอน decode
+	maxProfileImagePixels = 40_000_000
+	minProfileImageSide   = 64
+
+	profileImageFullSize  = 1024
+	profileImageThumbSize = 256
+	profileImageQuality   = 85
+	profileThumbSuffix    = "_thumb"
+)
+
+var allowedProfileImageFormats = map[string]bool{"jpeg": true, "png": true, "webp": true}
+
+// ProfileImageService ตรวจสอบและแปลงรูปโปรไฟล์ก่อนบันทึก
+// รูปที่บันทึกจะเป็น JPEG ที่ไม่มี metadata (EXIF/GPS) หมุนตามค่า orientation แล้ว
+// มี 2 ขนาด: ขนาดเต็ม (ด้านยาวไม่เกิน 1024px) และ thumbnail สี่เหลี่ยมจัตุรัส 256px
+type ProfileImageService interface {
+	Save(userID uint, r io.Reader) (string, error)
+	Remove(imagePath string) error
+	IsStored(imagePath string) bool
+}
+
+type profileImageService struct {
+	dir string
+}
+
+// NewProfileImageService dir คือโฟลเดอร์ที่ถูก serve ผ่าน /uploads เช่น uploads/user-profile
+func NewProfileImageService(dir string) ProfileImageService {
+	return &profileImageService{dir: filepath.Clean(dir)}
+}
+
+// ProfileThumbnailPath คืน path ของ thumbnail ที่คู่กับรูปขนาดเต็ม
+func ProfileThumbnailPath(imagePath string) string {
+	ext := filepath.Ext(imagePath)
+	return strings.TrimSuffix(imagePath, ext) + profileThumbSuffix + ext
+}
+
+// Save ประมวลผลรูปแล้วบันทึกทั้ง 2 ขนาด คืนค่า image_path ของรูปขนาดเต็ม (เช่น /uploads/user-profile/12-1700000000.jpg)
+func (s *profileImageService) Save(userID uint, r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxProfileImageBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxProfileImageBytes {
+		return "", fmt.Errorf("profile image must not exceed %d MB", maxProfileImageBytes>>20)
+	}
+
+	img, err := decodeProfileImage(data)
+	if err != nil {
+		return "", err
+	}
+
+	full := resizeToFit(img, profileImageFullSize)
+	thumb := resizeToSquare(img, profileImageThumbSize)
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", errors.New("failed to prepare upload directory")
+	}
+
+	// ใช้ชื่อไฟล์ใหม่ทุกครั้ง เพื่อไม่ให้ browser/CDN แสดงรูปเก่าจาก cache
+	fileName := fmt.Sprintf("%d-%d.jpg", userID, time.Now().UnixNano())
+	fullPath := filepath.Join(s.dir, fileName)
+	thumbPath := ProfileThumbnailPath(fullPath)
+
+	if err := writeJPEG(fullPath, full); err != nil {
+		return "", err
+	}
+	if err := writeJPEG(thumbPath, thumb); err != nil {
+		_ = os.Remove(fullPath)
+		return "", err
+	}
+
+	return "/" + filepath.ToSlash(fullPath), nil
+}
+
+// Remove ลบรูปเดิม (และ thumbnail) เฉพาะไฟล์ที่อยู่ในโฟลเดอร์รูปโปรไฟล์เท่านั้น
+// image_path ที่เป็น URL ภายนอก (เช่นรูปจาก Google) จะถูกข้ามไป
+func (s *profileImageService) Remove(imagePath string) error {
+	localPath, ok := s.localPath(imagePath)
+	if !ok {
+		return nil
+	}
+
+	for _, p := range []string{localPath, ProfileThumbnailPath(localPath)} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsStored ตรวจว่า image_path ชี้ไปยังไฟล์ในโฟลเดอร์รูปโปรไฟล์หรือไม่
+func (s *profileImageService) IsStored(imagePath string) bool {
+	_, ok := s.localPath(imagePath)
+	return ok
+}
+
+func (s *profileImageService) localPath(imagePath string) (string, bool) {
+	imagePath = strings.TrimSpace(imagePath)
+	if imagePath == "" || strings.Contains(imagePath, "://") {
+		return "", false
+	}
+
+	cleaned := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(imagePath, "/")))
+	if filepath.Dir(cleaned) != s.dir {
+		return "", false
+	}
+	return cleaned, true
+}
+
+func decodeProfileImage(data []byte) (image.Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("unsupported image format: only JPEG, PNG and WebP are allowed")
+	}
+	if !allowedProfileImageFormats[format] {
+		return nil, errors.New("unsupported image format: only JPEG, PNG and WebP are allowed")
+	}
+	if cfg.Width < minProfileImageSide || cfg.Height < minProfileImageSide {
+		return nil, fmt.Errorf("profile image must be at least %dx%d pixels", minProfileImageSide, minProfileImageSide)
+	}
+	if cfg.Width*cfg.Height > maxProfileImagePixels {
+		return nil, errors.New("profile image dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image data")
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, nil
+}
+
+func writeJPEG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.New("failed to save profile image")
+	}
+	if err := jpeg.Encode(f, img, &jpeg.Options{Quality: profileImageQuality}); err != nil {
+		f.Close()
+		_ = os.Remove(path)
+		return errors.New("failed to save profile image")
+	}
+	return f.Close()
+}
+
+// newOpaqueCanvas JPEG ไม่มี alpha จึงรองพื้นสีขาวให้รูป PNG/WebP ที่โปร่งใส
+func newOpaqueCanvas(w, h int) *image.RGBA {
+	canvas := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	return canvas
+}
+
+// resizeToFit ย่อรูปให้ด้านยาวไม่เกิน maxSide โดยคงสัดส่วนเดิม (ไม่ขยายรูปเล็ก)
+func resizeToFit(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			h = h * maxSide / w
+			w = maxSide
+		} else {
+			w = w * maxSide / h
+			h = maxSide
+		}
+	}
+
+	dst := newOpaqueCanvas(max(w, 1), max(h, 1))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
+
+// resizeToSquare crop ตรงกลางให้เป็นสี่เหลี่ยมจัตุรัสแล้วย่อเป็น size x size
+func resizeToSquare(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	crop := image.Rect(x0, y0, x0+side, y0+side)
+
+	size = min(size, side)
+	dst := newOpaqueCanvas(size, size)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+	return dst
+}
+
+// applyOrientation หมุน/กลับรูปตามค่า EXIF Orientation (1-8)
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // กลับซ้าย-ขวา
+				dx, dy = w-1-x, y
+			case 3: // หมุน 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // กลับบน-ล่าง
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // หมุนตามเข็ม 90
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // หมุนทวนเข็ม 90
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, src.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation อ่านค่า Orientation (tag 0x0112) จาก APP1/Exif ของไฟล์ JPEG
+// คืนค่า 1 (ปกติ) ถ้าไม่พบหรืออ่านไม่ได้
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		// SOS หรือ EOI หมายถึงหมด header แล้ว
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		segLen := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if segLen < 2 || pos+2+segLen > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+segLen]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + segLen
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+	return 1
+}
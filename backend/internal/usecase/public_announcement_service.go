@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"backend/config"
+	announcementdto "backend/internal/dto/announcement_dto"
+	awardformdto "backend/internal/dto/award_form_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+
+	publicFeedSize = 20
+)
+
+// PublicAnnouncementService ประกาศผลสาธารณะ (ไม่ต้อง login) แสดงเฉพาะฉบับที่เผยแพร่ ฟิลด์ที่ได้รับความยินยอม
+// และตัดนิสิตที่ขอไม่ให้เผยแพร่ชื่อออก
+type PublicAnnouncementService interface {
+	GetList(ctx context.Context, query announcementdto.PublicAnnouncementQuery) (*announcementdto.PaginatedPublicAnnouncementResponse, error)
+	GetByID(ctx context.Context, announcementID uint) (*announcementdto.PublicAnnouncementResponse, error)
+	// GetFeed คืน RSS/Atom ของประกาศที่เผยแพร่ล่าสุดของวิทยาเขต baseURL ใช้สร้างลิงก์เมื่อไม่ได้ตั้ง FRONTEND_BASE_URL
+	GetFeed(ctx context.Context, campusID uint, format, baseURL, selfURL string) ([]byte, time.Time, error)
+
+	GetPublicationPreference(ctx context.Context, userID uint) (*announcementdto.PublicationPreferenceResponse, error)
+	UpdatePublicationPreference(ctx context.Context, userID uint, req announcementdto.UpdatePublicationPreferenceRequest) (*announcementdto.PublicationPreferenceResponse, error)
+}
+
+type publicAnnouncementService struct {
+	repo            repository.AnnouncementRepository
+	campusRepo      repository.CampusRepository
+	fields          []string
+	frontendBaseURL string
+}
+
+func NewPublicAnnouncementService(repo repository.AnnouncementRepository, campusRepo repository.CampusRepository, cfg config.PublicAnnouncementConfig, frontendBaseURL string) PublicAnnouncementService {
+	return &publicAnnouncementService{
+		repo:            repo,
+		campusRepo:      campusRepo,
+		fields:          cfg.Fields,
+		frontendBaseURL: strings.TrimRight(frontendBaseURL, "/"),
+	}
+}
+
+func (s *publicAnnouncementService) GetList(ctx context.Context, query announcementdto.PublicAnnouncementQuery) (*announcementdto.PaginatedPublicAnnouncementResponse, error) {
+	announcements, total, err := s.repo.GetPublishedList(ctx, query.CampusID, query.AcademicYear, query.Semester, query.Limit, (query.Page-1)*query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]announcementdto.PublicAnnouncementSummary, 0, len(announcements))
+	for i := range announcements {
+		summary, err := s.summary(ctx, &announcements[i])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, *summary)
+	}
+
+	totalPages := int(total) / query.Limit
+	if int(total)%query.Limit > 0 {
+		totalPages++
+	}
+	return &announcementdto.PaginatedPublicAnnouncementResponse{
+		Data: data,
+		Pagination: awardformdto.PaginationMeta{
+			CurrentPage: query.Page,
+			TotalPages:  totalPages,
+			TotalItems:  total,
+			Limit:       query.Limit,
+		},
+	}, nil
+}
+
+func (s *publicAnnouncementService) GetByID(ctx context.Context, announcementID uint) (*announcementdto.PublicAnnouncementResponse, error) {
+	announcement, err := s.repo.GetByID(ctx, announcementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("announcement not found")
+		}
+		return nil, err
+	}
+	if announcement.Status != models.AnnouncementStatusPublished || announcement.PublishedVersionID == nil {
+		return nil, errors.New("announcement not found")
+	}
+
+	summary, err := s.summary(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetPublicItems(ctx, *announcement.PublishedVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string][]announcementdto.PublicAnnouncementItem, len(announcementSections))
+	for _, item := range items {
+		key := announcementSectionOf(item.AwardCategory).key
+		byKey[key] = append(byKey[key], s.publicItem(item))
+	}
+	sections := make([]announcementdto.PublicAnnouncementSection, 0, len(announcementSections))
+	for _, section := range announcementSections {
+		sectionItems := byKey[section.key]
+		if sectionItems == nil {
+			sectionItems = make([]announcementdto.PublicAnnouncementItem, 0)
+		}
+		sections = append(sections, announcementdto.PublicAnnouncementSection{
+			Key:   section.key,
+			Label: section.label,
+			Items: sectionItems,
+		})
+	}
+
+	return &announcementdto.PublicAnnouncementResponse{
+		PublicAnnouncementSummary: *summary,
+		Fields:                    s.fields,
+		TotalItems:                len(items),
+		Sections:                  sections,
+	}, nil
+}
+
+// publicItem คัดเฉพาะฟิลด์ที่อยู่ใน PUBLIC_ANNOUNCEMENT_FIELDS
+func (s *publicAnnouncementService) publicItem(item models.AnnouncementItem) announcementdto.PublicAnnouncementItem {
+	var result announcementdto.PublicAnnouncementItem
+	for _, field := range s.fields {
+		switch field {
+		case config.PublicFieldPrefix:
+			result.Prefix = strings.TrimSpace(item.Prefix)
+		case config.PublicFieldName:
+			result.Name = strings.TrimSpace(strings.TrimSpace(item.StudentFirstname) + " " + strings.TrimSpace(item.StudentLastname))
+		case config.PublicFieldFaculty:
+			result.FacultyName = item.FacultyName
+		case config.PublicFieldAwardType:
+			result.AwardType = item.AwardType
+		case config.PublicFieldStudentNumber:
+			result.StudentNumber = item.StudentNumber
+		}
+	}
+	return result
+}
+
+func (s *publicAnnouncementService) summary(ctx context.Context, announcement *models.Announcement) (*announcementdto.PublicAnnouncementSummary, error) {
+	version, err := s.repo.GetVersion(ctx, *announcement.PublishedVersionID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &announcementdto.PublicAnnouncementSummary{
+		AnnouncementID: announcement.AnnouncementID,
+		CampusID:       announcement.CampusID,
+		AcademicYear:   announcement.AcademicYear,
+		Semester:       announcement.Semester,
+		Title:          announcement.Title,
+		Version:        version.Version,
+	}
+	if version.PublishedAt != nil {
+		summary.PublishedAt = *version.PublishedAt
+	}
+	return summary, nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// feedEntry ประกาศหนึ่งฉบับในฟีด (ฉบับแก้ไขนับเป็นรายการใหม่)
+type feedEntry struct {
+	id          string
+	title       string
+	link        string
+	summary     string
+	publishedAt time.Time
+}
+
+func (s *publicAnnouncementService) GetFeed(ctx context.Context, campusID uint, format, baseURL, selfURL string) ([]byte, time.Time, error) {
+	if format != FeedFormatRSS && format != FeedFormatAtom {
+		return nil, time.Time{}, fmt.Errorf("invalid feed format %q", format)
+	}
+	campus, err := s.campusRepo.GetByID(ctx, campusID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, time.Time{}, errors.New("campus not found")
+		}
+		return nil, time.Time{}, err
+	}
+
+	announcements, _, err := s.repo.GetPublishedList(ctx, int(campusID), 0, 0, publicFeedSize, 0)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	homeURL := baseURL + "/api/public/announcements?campus_id=" + fmt.Sprint(campusID)
+	if s.frontendBaseURL != "" {
+		homeURL = s.frontendBaseURL + "/announcement"
+	}
+
+	var lastModified time.Time
+	entries := make([]feedEntry, 0, len(announcements))
+	for i := range announcements {
+		summary, err := s.summary(ctx, &announcements[i])
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		link := fmt.Sprintf("%s/api/public/announcements/%d", baseURL, summary.AnnouncementID)
+		if s.frontendBaseURL != "" {
+			link = fmt.Sprintf("%s/announcement/%d", s.frontendBaseURL, summary.AnnouncementID)
+		}
+		text := fmt.Sprintf("%s ภาคเรียนที่ %d ปีการศึกษา %d", campus.CampusName, summary.Semester, summary.AcademicYear)
+		if summary.Version > 1 {
+			text += fmt.Sprintf(" (ฉบับแก้ไขครั้งที่ %d)", summary.Version-1)
+		}
+		entries = append(entries, feedEntry{
+			id:          fmt.Sprintf("%s#v%d", link, summary.Version),
+			title:       summary.Title,
+			link:        link,
+			summary:     text,
+			publishedAt: summary.PublishedAt,
+		})
+		if summary.PublishedAt.After(lastModified) {
+			lastModified = summary.PublishedAt
+		}
+	}
+
+	title := "ประกาศผลรางวัลนิสิตดีเด่น " + campus.CampusName
+	var doc interface{}
+	if format == FeedFormatRSS {
+		channel := rssChannel{
+			Title:       title,
+			Link:        homeURL,
+			Description: title,
+			Language:    "th",
+			AtomLink:    atomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(entries)),
+		}
+		if !lastModified.IsZero() {
+			channel.LastBuildDate = lastModified.Format(time.RFC1123Z)
+		}
+		for _, entry := range entries {
+			channel.Items = append(channel.Items, rssItem{
+				Title:       entry.title,
+				Link:        entry.link,
+				GUID:        rssGUID{IsPermaLink: "false", Value: entry.id},
+				PubDate:     entry.publishedAt.Format(time.RFC1123Z),
+				Description: entry.summary,
+			})
+		}
+		doc = rssFeed{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", Channel: channel}
+	} else {
+		updated := lastModified
+		if updated.IsZero() {
+			updated = time.Unix(0, 0)
+		}
+		feed := atomFeed{
+			Title:   title,
+			ID:      selfURL,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Links: []atomLink{
+				{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+				{Href: homeURL, Rel: "alternate"},
+			},
+			Entries: make([]atomEntry, 0, len(entries)),
+		}
+		for _, entry := range entries {
+			feed.Entries = append(feed.Entries, atomEntry{
+				Title:   entry.title,
+				ID:      entry.id,
+				Updated: entry.publishedAt.UTC().Format(time.RFC3339),
+				Link:    atomLink{Href: entry.link, Rel: "alternate"},
+				Summary: entry.summary,
+			})
+		}
+		doc = feed
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return append([]byte(xml.Header), body...), lastModified, nil
+}
+
+func (s *publicAnnouncementService) GetPublicationPreference(ctx context.Context, userID uint) (*announcementdto.PublicationPreferenceResponse, error) {
+	preference, err := s.repo.GetPublicationPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapToPublicationPreferenceResponse(preference), nil
+}
+
+func (s *publicAnnouncementService) UpdatePublicationPreference(ctx context.Context, userID uint, req announcementdto.UpdatePublicationPreferenceRequest) (*announcementdto.PublicationPreferenceResponse, error) {
+	if req.OptOut == nil {
+		return nil, errors.New("opt_out is required")
+	}
+	preference := &models.PublicationPreference{
+		UserID:    userID,
+		OptOut:    *req.OptOut,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.repo.SavePublicationPreference(ctx, preference); err != nil {
+		return nil, err
+	}
+	return mapToPublicationPreferenceResponse(preference), nil
+}
+
+func mapToPublicationPreferenceResponse(preference *models.PublicationPreference) *announcementdto.PublicationPreferenceResponse {
+	response := &announcementdto.PublicationPreferenceResponse{OptOut: preference.OptOut}
+	if !preference.UpdatedAt.IsZero() {
+		updatedAt := preference.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"backend/internal/models"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// ชนิดของ event ที่ส่งผ่าน real-time stream
+const (
+	RealtimeEventVoteTally    = "vote_tally"
+	RealtimeEventFormStatus   = "form_status"
+	RealtimeEventNotification = "notification"
+)
+
+const (
+	// realtimeChannel ชื่อ channel ของ Postgres LISTEN/NOTIFY ที่ทุก replica ฟังร่วมกัน
+	realtimeChannel = "award_realtime"
+	// payload ของ NOTIFY ต้องไม่เกิน 8000 bytes
+	maxRealtimePayload     = 7900
+	realtimeSubscriberSize = 32
+	maxListenBackoff       = 30 * time.Second
+)
+
+// RealtimeEvent ข้อมูลที่ส่งถึง client ผู้รับคือ UserIDs หรือผู้ใช้ที่มี role อยู่ใน Roles ของวิทยาเขต CampusID
+type RealtimeEvent struct {
+	Type     string          `json:"type"`
+	FormID   uint            `json:"form_id,omitempty"`
+	UserIDs  []uint          `json:"user_ids,omitempty"`
+	CampusID int             `json:"campus_id,omitempty"`
+	Roles    []int           `json:"roles,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type RealtimeService interface {
+	// Publish ส่ง event ผ่าน pg_notify เพื่อให้ทุก replica (รวมถึงตัวเอง) กระจายต่อให้ client ของตน
+	Publish(ctx context.Context, eventType string, formID uint, target RealtimeTarget, data interface{})
+	Subscribe(user *models.User) (<-chan RealtimeEvent, func())
+	// Run ฟัง LISTEN จน ctx ถูกยกเลิก (reconnect อัตโนมัติเมื่อการเชื่อมต่อหลุด)
+	Run(ctx context.Context)
+}
+
+// RealtimeTarget กำหนดผู้มีสิทธิ์รับ event
+type RealtimeTarget struct {
+	UserIDs  []uint
+	CampusID int
+	Roles    []int
+}
+
+type realtimeSubscriber struct {
+	userID   uint
+	roleID   int
+	campusID int
+	events   chan RealtimeEvent
+}
+
+type realtimeService struct {
+	db  *gorm.DB
+	dsn string
+
+	mu          sync.RWMutex
+	nextID      uint64
+	subscribers map[uint64]*realtimeSubscriber
+}
+
+// NewRealtimeService dsn ใช้เปิด connection แยกสำหรับ LISTEN (connection ใน pool ของ gorm ใช้ LISTEN ไม่ได้)
+func NewRealtimeService(db *gorm.DB, dsn string) RealtimeService {
+	return &realtimeService{db: db, dsn: dsn, subscribers: make(map[uint64]*realtimeSubscriber)}
+}
+
+func (s *realtimeService) Publish(ctx context.Context, eventType string, formID uint, target RealtimeTarget, data interface{}) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("realtime: marshal %s: %v", eventType, err)
+		return
+	}
+
+	payload, err := json.Marshal(RealtimeEvent{
+		Type:     eventType,
+		FormID:   formID,
+		UserIDs:  target.UserIDs,
+		CampusID: target.CampusID,
+		Roles:    target.Roles,
+		Data:     raw,
+	})
+	if err != nil {
+		log.Printf("realtime: marshal %s: %v", eventType, err)
+		return
+	}
+	if len(payload) > maxRealtimePayload {
+		log.Printf("realtime: %s payload too large (%d bytes), dropped", eventType, len(payload))
+		return
+	}
+
+	if err := s.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", realtimeChannel, string(payload)).Error; err != nil {
+		log.Printf("realtime: pg_notify %s: %v", eventType, err)
+	}
+}
+
+func (s *realtimeService) Subscribe(user *models.User) (<-chan RealtimeEvent, func()) {
+	sub := &realtimeSubscriber{
+		userID:   user.UserID,
+		roleID:   user.RoleID,
+		campusID: user.CampusID,
+		events:   make(chan RealtimeEvent, realtimeSubscriberSize),
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.subscribers[id] = sub
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers, id)
+			s.mu.Unlock()
+		})
+	}
+	return sub.events, unsubscribe
+}
+
+func (s *realtimeService) Run(ctx context.Context) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		connected, err := s.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = time.Second
+		}
+		log.Printf("realtime: listener stopped: %v (retry in %s)", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxListenBackoff {
+			backoff = maxListenBackoff
+		}
+	}
+}
+
+func (s *realtimeService) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, s.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+realtimeChannel); err != nil {
+		return false, err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var event RealtimeEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("realtime: invalid payload: %v", err)
+			continue
+		}
+		s.fanOut(event)
+	}
+}
+
+// fanOut ส่ง event ให้ client ของ replica นี้ที่มีสิทธิ์รับ client ที่รับไม่ทันจะถูกข้าม (ไม่ block ผู้อื่น)
+func (s *realtimeService) fanOut(event RealtimeEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.subscribers {
+		if !event.allows(sub) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("realtime: subscriber of user %d is too slow, %s dropped", sub.userID, event.Type)
+		}
+	}
+}
+
+func (e RealtimeEvent) allows(sub *realtimeSubscriber) bool {
+	for _, id := range e.UserIDs {
+		if id == sub.userID {
+			return true
+		}
+	}
+	if e.CampusID == 0 || e.CampusID != sub.campusID {
+		return false
+	}
+	for _, role := range e.Roles {
+		if role == sub.roleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	purgeStatusRunning = "running"
+	purgeStatusSuccess = "success"
+	purgeStatusFailed  = "failed"
+
+	maxRetainYears = 50
+)
+
+// retentionRedactions ค่าที่ใช้แทนข้อมูลส่วนบุคคลแต่ละประเภท
+// สถานะ ประเภทรางวัล คณะ/ภาค/วิทยาเขต และปีการศึกษา ยังคงอยู่เพื่อใช้ทำสถิติ
+var retentionRedactions = map[string]map[string]interface{}{
+	models.RetentionClassContact:     {"student_address": "", "student_phone_number": ""},
+	models.RetentionClassDateOfBirth: {"student_date_of_birth": nil},
+	models.RetentionClassGPA:         {"gpa": nil},
+}
+
+// retentionPseudonymised ฟิลด์ของประเภท identity ที่แทนด้วยนามแฝง (repository.PseudonymiseForms) แทนการลบ
+var retentionPseudonymised = []string{"student_email", "student_firstname", "student_lastname", "student_number"}
+
+type RetentionService interface {
+	GetPolicies(ctx context.Context) ([]models.RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, dataClass string, retainYears int, updatedBy uint) (*models.RetentionPolicy, error)
+	RunPurge(ctx context.Context, triggeredBy *uint, dryRun bool) (*models.RetentionPurgeRun, error)
+	GetRuns(ctx context.Context, page, limit int) ([]models.RetentionPurgeRun, int64, error)
+	GetRunByID(ctx context.Context, runID uint) (*models.RetentionPurgeRun, error)
+}
+
+type retentionService struct {
+	repo             repository.RetentionRepository
+	academicYearRepo repository.AcademicYearRepository
+	uploadRoot       string
+
+	// กันไม่ให้งานจากคิวและการสั่งรันเองทำงานซ้อนกันภายในโปรเซสเดียว
+	running sync.Mutex
+}
+
+// NewRetentionService uploadRoot คือโฟลเดอร์ที่เก็บไฟล์แนบ (เช่น "uploads") จะไม่ลบไฟล์นอกโฟลเดอร์นี้
+func NewRetentionService(repo repository.RetentionRepository, academicYearRepo repository.AcademicYearRepository, uploadRoot string) RetentionService {
+	return &retentionService{repo: repo, academicYearRepo: academicYearRepo, uploadRoot: filepath.Clean(uploadRoot)}
+}
+
+func (s *retentionService) GetPolicies(ctx context.Context) ([]models.RetentionPolicy, error) {
+	return s.repo.GetPolicies(ctx)
+}
+
+func (s *retentionService) UpdatePolicy(ctx context.Context, dataClass string, retainYears int, updatedBy uint) (*models.RetentionPolicy, error) {
+	if retainYears < 0 || retainYears > maxRetainYears {
+		return nil, fmt.Errorf("retain_years must be between 0 and %d", maxRetainYears)
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, dataClass)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("retention policy not found")
+		}
+		return nil, err
+	}
+
+	policy.RetainYears = retainYears
+	policy.UpdatedBy = &updatedBy
+	policy.UpdatedAt = time.Now()
+	if err := s.repo.UpdatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *retentionService) GetRuns(ctx context.Context, page, limit int) ([]models.RetentionPurgeRun, int64, error) {
+	return s.repo.GetRuns(ctx, limit, (page-1)*limit)
+}
+
+func (s *retentionService) GetRunByID(ctx context.Context, runID uint) (*models.RetentionPurgeRun, error) {
+	run, err := s.repo.GetRunByID(ctx, runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("purge run not found")
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+// RunPurge ลบข้อมูลส่วนบุคคลของฟอร์มที่เกินระยะเวลาเก็บรักษา แล้วบันทึกรายงานลง Retention_Purge_Run/Item
+// ฟอร์มของปีการศึกษา Y จะถูกลบเมื่อ Y < ปีการศึกษาปัจจุบัน - RetainYears
+// dryRun = true จะสร้างรายงานโดยไม่แก้ไขข้อมูลหรือไฟล์
+func (s *retentionService) RunPurge(ctx context.Context, triggeredBy *uint, dryRun bool) (*models.RetentionPurgeRun, error) {
+	if !s.running.TryLock() {
+		return nil, errors.New("a purge run is already in progress")
+	}
+	defer s.running.Unlock()
+
+	// นโยบายเก็บรักษาใช้ร่วมกันทุกวิทยาเขต จึงนับปีจากปฏิทินกลาง
+	current, err := s.academicYearRepo.GetCurrentSemester(ctx, 0)
+	if err != nil {
+		return nil, errors.New("current academic year is not configured")
+	}
+
+	policies, err := s.repo.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.RetentionPurgeRun{
+		StartedAt:   time.Now(),
+		TriggeredBy: triggeredBy,
+		DryRun:      dryRun,
+		Status:      purgeStatusRunning,
+		CurrentYear: current.Year,
+	}
+	if err := s.repo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	var items []models.RetentionPurgeItem
+	var failures []string
+	affected := make(map[uint]struct{})
+
+	for _, policy := range policies {
+		if policy.RetainYears <= 0 {
+			continue
+		}
+		beforeYear := current.Year - policy.RetainYears
+
+		var classItems []models.RetentionPurgeItem
+		var classErr error
+		if policy.DataClass == models.RetentionClassAttachments {
+			classItems, classErr = s.purgeAttachments(ctx, run, beforeYear)
+		} else {
+			classItems, classErr = s.redactFields(ctx, run, policy.DataClass, beforeYear)
+		}
+		if classErr != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", policy.DataClass, classErr))
+		}
+
+		for _, item := range classItems {
+			affected[item.FormID] = struct{}{}
+			run.BytesFreed += item.BytesFreed
+			if item.DataClass == models.RetentionClassAttachments && !strings.HasPrefix(item.Detail, "failed") {
+				run.FilesDeleted++
+			}
+		}
+		items = append(items, classItems...)
+	}
+
+	if err := s.repo.CreateItems(ctx, items); err != nil {
+		failures = append(failures, "report: "+err.Error())
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.FormsAffected = len(affected)
+	run.Status = purgeStatusSuccess
+	if len(failures) > 0 {
+		run.Status = purgeStatusFailed
+		run.ErrorMessage = strings.Join(failures, "; ")
+	}
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	run.Items = items
+	return run, nil
+}
+
+func (s *retentionService) redactFields(ctx context.Context, run *models.RetentionPurgeRun, dataClass string, beforeYear int) ([]models.RetentionPurgeItem, error) {
+	updates, ok := retentionRedactions[dataClass]
+	pseudonymise := dataClass == models.RetentionClassIdentity
+	if !ok && !pseudonymise {
+		return nil, errors.New("unknown data class")
+	}
+
+	candidates, err := s.repo.FindFormsToRedact(ctx, dataClass, beforeYear)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(updates))
+	for column := range updates {
+		fields = append(fields, column)
+	}
+	done, planned := "redacted ", "would redact "
+	if pseudonymise {
+		fields = append(fields, retentionPseudonymised...)
+		done, planned = "pseudonymised ", "would pseudonymise "
+	}
+	sort.Strings(fields)
+	detail := done + strings.Join(fields, ", ")
+	if run.DryRun {
+		detail = planned + strings.Join(fields, ", ")
+	}
+
+	formIDs := make([]uint, 0, len(candidates))
+	items := make([]models.RetentionPurgeItem, 0, len(candidates))
+	for _, candidate := range candidates {
+		formIDs = append(formIDs, candidate.FormID)
+		items = append(items, models.RetentionPurgeItem{
+			RunID:        run.RunID,
+			FormID:       candidate.FormID,
+			AcademicYear: candidate.AcademicYear,
+			DataClass:    dataClass,
+			Detail:       detail,
+		})
+	}
+
+	if run.DryRun || len(formIDs) == 0 {
+		return items, nil
+	}
+	if pseudonymise {
+		err = s.repo.PseudonymiseForms(ctx, formIDs)
+	} else {
+		err = s.repo.RedactForms(ctx, formIDs, updates)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MarkFormsPurged(ctx, formIDs); err != nil {
+		return items, err
+	}
+	return items, nil
+}
+
+func (s *retentionService) purgeAttachments(ctx context.Context, run *models.RetentionPurgeRun, beforeYear int) ([]models.RetentionPurgeItem, error) {
+	files, err := s.repo.FindFilesToPurge(ctx, beforeYear)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.RetentionPurgeItem, 0, len(files))
+	purgedForms := make([]uint, 0, len(files))
+	var failed int
+	for _, f := range files {
+		item := models.RetentionPurgeItem{
+			RunID:        run.RunID,
+			FormID:       f.FormID,
+			AcademicYear: f.AcademicYear,
+			DataClass:    models.RetentionClassAttachments,
+			Detail:       "deleted " + f.FilePath,
+			BytesFreed:   f.FileSize,
+		}
+
+		if run.DryRun {
+			item.Detail = "would delete " + f.FilePath
+			item.BytesFreed = 0
+		} else if err := s.deleteAttachment(ctx, f); err != nil {
+			item.Detail = fmt.Sprintf("failed %s: %v", f.FilePath, err)
+			item.BytesFreed = 0
+			failed++
+		} else {
+			purgedForms = append(purgedForms, f.FormID)
+		}
+		items = append(items, item)
+	}
+
+	if err := s.repo.MarkFormsPurged(ctx, purgedForms); err != nil {
+		return items, err
+	}
+	if failed > 0 {
+		return items, fmt.Errorf("%d file(s) could not be deleted", failed)
+	}
+	return items, nil
+}
+
+// deleteAttachment ลบไฟล์ออกจาก disk ก่อนแล้วจึงลบ record ถ้าไฟล์หายไปแล้วถือว่าลบสำเร็จ
+func (s *retentionService) deleteAttachment(ctx context.Context, f repository.RetentionFileCandidate) error {
+	cleaned := filepath.Clean(f.FilePath)
+	rel, err := filepath.Rel(s.uploadRoot, cleaned)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return errors.New("path is outside the upload directory")
+	}
+
+	if err := os.Remove(cleaned); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return s.repo.DeleteFileRecord(ctx, f.FileDirID)
+}
@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	signaturedto "backend/internal/dto/signature_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const signatureAlgorithm = "ed25519"
+
+type SignatureService interface {
+	SignForm(ctx context.Context, form *models.AwardForm, formStatus int, signerID uint) (*models.AwardSignedLog, error)
+	VerifyForm(ctx context.Context, formID uint) (*signaturedto.FormSignatureVerificationResponse, error)
+	GetKeysByUserID(ctx context.Context, userID uint) ([]signaturedto.SignerKeyResponse, error)
+	RotateKey(ctx context.Context, userID uint) (*signaturedto.SignerKeyResponse, error)
+}
+
+type signatureService struct {
+	keyRepo   repository.SignerKeyRepository
+	awardRepo *repository.AwardRepository
+	masterKey []byte
+}
+
+func NewSignatureService(keyRepo repository.SignerKeyRepository, awardRepo *repository.AwardRepository, masterKey []byte) SignatureService {
+	return &signatureService{keyRepo: keyRepo, awardRepo: awardRepo, masterKey: masterKey}
+}
+
+// canonicalDecision คือข้อมูลที่ถูกลงนาม: ผู้รับรางวัล ผลการพิจารณาของฟอร์ม (รางวัล ภาคเรียน สถานะที่ลงนาม) และ hash ของไฟล์แนบ
+// ผู้รับรางวัลผูกด้วย nominee_student_id (key ของ Student) ซึ่งไม่เปลี่ยนเมื่อชื่อ/รหัสนิสิตในฟอร์มถูกแทนด้วยนามแฝง
+// ไม่รวมข้อมูลส่วนบุคคลที่ถูกลบ/ปิดบังได้ตามนโยบายเก็บรักษาข้อมูลหรือคำขอลบข้อมูล และไม่รวม user_id ของผู้ส่ง
+// ลำดับฟิลด์ใน struct คือลำดับใน JSON ห้ามสลับเพราะจะทำให้ hash เดิมตรวจไม่ผ่าน
+type canonicalDecision struct {
+	Version          string                `json:"version"`
+	FormID           uint                  `json:"form_id"`
+	NomineeStudentID uint                  `json:"nominee_student_id"`
+	AwardTypeID      uint                  `json:"award_type_id"`
+	AwardType        string                `json:"award_type"`
+	AcademicYear     int                   `json:"academic_year"`
+	Semester         int                   `json:"semester"`
+	FormStatusID     int                   `json:"form_status_id"`
+	Attachments      []canonicalAttachment `json:"attachments"`
+}
+
+type canonicalAttachment struct {
+	FileDirID uint   `json:"file_dir_id"`
+	FileType  string `json:"file_type"`
+	FileSize  int64  `json:"file_size"`
+	SHA256    string `json:"sha256"`
+}
+
+// computeFormHash คำนวณ sha256 ของผลการพิจารณาแบบ canonical ณ สถานะที่ลงนาม รวม hash ของไฟล์แนบทุกไฟล์
+func computeFormHash(form *models.AwardForm, formStatus int) (string, error) {
+	attachments, err := canonicalAttachments(form.AwardFiles)
+	if err != nil {
+		return "", err
+	}
+
+	var nomineeStudentID, awardTypeID uint
+	if form.NomineeStudentID != nil {
+		nomineeStudentID = *form.NomineeStudentID
+	}
+	if form.AwardTypeID != nil {
+		awardTypeID = *form.AwardTypeID
+	}
+	return hashCanonical(canonicalDecision{
+		Version:          "award-decision/v2",
+		FormID:           form.FormID,
+		NomineeStudentID: nomineeStudentID,
+		AwardTypeID:      awardTypeID,
+		AwardType:        form.AwardType,
+		AcademicYear:     form.AcademicYear,
+		Semester:         form.Semester,
+		FormStatusID:     formStatus,
+		Attachments:      attachments,
+	})
+}
+
+func canonicalAttachments(awardFiles []models.AwardFileDirectory) ([]canonicalAttachment, error) {
+	files := make([]models.AwardFileDirectory, len(awardFiles))
+	copy(files, awardFiles)
+	sort.Slice(files, func(i, j int) bool { return files[i].FileDirID < files[j].FileDirID })
+
+	attachments := make([]canonicalAttachment, 0, len(files))
+	for _, f := range files {
+		fileHash, err := hashFile(f.FilePath)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			fileHash = "missing"
+		}
+		attachments = append(attachments, canonicalAttachment{
+			FileDirID: f.FileDirID,
+			FileType:  f.FileType,
+			FileSize:  f.FileSize,
+			SHA256:    fileHash,
+		})
+	}
+	return attachments, nil
+}
+
+func hashCanonical(v interface{}) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// signingMessage ผูกลายมือชื่อเข้ากับฟอร์มและขั้นตอนที่ลงนาม เพื่อไม่ให้นำลายมือชื่อไปใช้ซ้ำกับขั้นตอนอื่น
+func signingMessage(formID uint, formStatus int, contentHash string) []byte {
+	return []byte(fmt.Sprintf("award-signature/v1:%d:%d:%s", formID, formStatus, contentHash))
+}
+
+func (s *signatureService) SignForm(ctx context.Context, form *models.AwardForm, formStatus int, signerID uint) (*models.AwardSignedLog, error) {
+	key, err := s.getOrCreateActiveKey(ctx, signerID)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := s.decryptPrivateKey(key.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	contentHash, err := computeFormHash(form, formStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := ed25519.Sign(privateKey, signingMessage(form.FormID, formStatus, contentHash))
+
+	return &models.AwardSignedLog{
+		FormID:       form.FormID,
+		UserID:       signerID,
+		SignedAt:     time.Now(),
+		FormStatusID: formStatus,
+		KeyID:        key.KeyID,
+		Algorithm:    key.Algorithm,
+		ContentHash:  contentHash,
+		Signature:    base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+func (s *signatureService) VerifyForm(ctx context.Context, formID uint) (*signaturedto.FormSignatureVerificationResponse, error) {
+	form, err := s.awardRepo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		return nil, errors.New("form not found")
+	}
+
+	// current_hash คือ hash ของผลการพิจารณา ณ สถานะปัจจุบัน แต่ละลายมือชื่อเทียบกับ hash ณ สถานะที่ลงนาม
+	currentHash, err := computeFormHash(form, form.FormStatusID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.awardRepo.GetSignedLogsByFormID(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	allValid := len(logs) > 0
+	checks := make([]signaturedto.SignatureCheck, 0, len(logs))
+	for _, log := range logs {
+		signedHash, err := computeFormHash(form, log.FormStatusID)
+		if err != nil {
+			return nil, err
+		}
+
+		check := signaturedto.SignatureCheck{
+			SignedLogID:  log.SignedLogID,
+			UserID:       log.UserID,
+			FormStatusID: log.FormStatusID,
+			SignedAt:     log.SignedAt,
+			KeyID:        log.KeyID,
+			Algorithm:    log.Algorithm,
+			SignedHash:   log.ContentHash,
+			HashMatches:  log.ContentHash != "" && log.ContentHash == signedHash,
+		}
+
+		switch {
+		case log.Signature == "" || log.KeyID == 0:
+			check.Message = "no digital signature (signed before digital signing was enabled)"
+		default:
+			check.SignatureValid, check.Fingerprint, check.Message = s.verifySignedLog(ctx, log)
+			if check.SignatureValid && !check.HashMatches {
+				check.Message = "form content has changed since signing"
+				if form.PersonalDataPurgedAt != nil && form.PersonalDataPurgedAt.After(log.SignedAt) {
+					check.Message = "personal data was removed under the retention policy after signing"
+				}
+			}
+		}
+
+		if !check.SignatureValid || !check.HashMatches {
+			allValid = false
+		}
+		checks = append(checks, check)
+	}
+
+	return &signaturedto.FormSignatureVerificationResponse{
+		FormID:      formID,
+		CurrentHash: currentHash,
+		AllValid:    allValid,
+		Signatures:  checks,
+	}, nil
+}
+
+func (s *signatureService) verifySignedLog(ctx context.Context, log models.AwardSignedLog) (bool, string, string) {
+	key, err := s.keyRepo.GetByID(ctx, log.KeyID)
+	if err != nil {
+		return false, "", "signer key not found"
+	}
+	if key.UserID != log.UserID {
+		return false, key.Fingerprint, "signer key does not belong to signer"
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return false, key.Fingerprint, "invalid public key"
+	}
+	signature, err := base64.StdEncoding.DecodeString(log.Signature)
+	if err != nil {
+		return false, key.Fingerprint, "invalid signature encoding"
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), signingMessage(log.FormID, log.FormStatusID, log.ContentHash), signature) {
+		return false, key.Fingerprint, "signature does not match"
+	}
+	return true, key.Fingerprint, ""
+}
+
+func (s *signatureService) GetKeysByUserID(ctx context.Context, userID uint) ([]signaturedto.SignerKeyResponse, error) {
+	keys, err := s.keyRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]signaturedto.SignerKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, mapToSignerKeyResponse(key))
+	}
+	return response, nil
+}
+
+// RotateKey สร้างกุญแจใหม่ให้ผู้ลงนาม กุญแจเดิมถูกปลดแต่ยังใช้ตรวจลายมือชื่อเก่าได้
+func (s *signatureService) RotateKey(ctx context.Context, userID uint) (*signaturedto.SignerKeyResponse, error) {
+	key, err := s.newSignerKey(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keyRepo.Rotate(ctx, userID, key); err != nil {
+		return nil, err
+	}
+
+	response := mapToSignerKeyResponse(*key)
+	return &response, nil
+}
+
+func (s *signatureService) getOrCreateActiveKey(ctx context.Context, userID uint) (*models.SignerKey, error) {
+	key, err := s.keyRepo.GetActiveByUserID(ctx, userID)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	key, err = s.newSignerKey(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *signatureService) newSignerKey(userID uint) (*models.SignerKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.encryptPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := sha256.Sum256(publicKey)
+	return &models.SignerKey{
+		UserID:              userID,
+		Algorithm:           signatureAlgorithm,
+		PublicKey:           base64.StdEncoding.EncodeToString(publicKey),
+		EncryptedPrivateKey: encrypted,
+		Fingerprint:         hex.EncodeToString(fingerprint[:]),
+		IsActive:            true,
+		CreatedAt:           time.Now(),
+	}, nil
+}
+
+// encryptPrivateKey เข้ารหัส private key ด้วย AES-256-GCM (nonce || ciphertext แล้วเข้ารหัส base64)
+func (s *signatureService) encryptPrivateKey(privateKey ed25519.PrivateKey) (string, error) {
+	gcm, err := newMasterKeyAEAD(s.masterKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, privateKey, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *signatureService) decryptPrivateKey(encrypted string) (ed25519.PrivateKey, error) {
+	gcm, err := newMasterKeyAEAD(s.masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted signer key")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt signer key")
+	}
+	if len(plain) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid signer key size")
+	}
+	return ed25519.PrivateKey(plain), nil
+}
+
+func newMasterKeyAEAD(masterKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func mapToSignerKeyResponse(key models.SignerKey) signaturedto.SignerKeyResponse {
+	return signaturedto.SignerKeyResponse{
+		KeyID:       key.KeyID,
+		Algorithm:   key.Algorithm,
+		PublicKey:   key.PublicKey,
+		Fingerprint: key.Fingerprint,
+		IsActive:    key.IsActive,
+		CreatedAt:   key.CreatedAt,
+		RetiredAt:   key.RetiredAt,
+	}
+}
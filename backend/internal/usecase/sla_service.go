@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	sladto "backend/internal/dto/sla_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxSLAHours SLA ยาวสุดของหนึ่งขั้น (90 วัน)
+const maxSLAHours = 90 * 24
+
+type SLAService interface {
+	GetPolicies(ctx context.Context) ([]models.ApprovalSLA, error)
+	UpdatePolicy(ctx context.Context, formStatusID int, req sladto.UpdateSLAPolicyRequest, updatedBy uint) (*models.ApprovalSLA, error)
+	// RunCheck ส่งการเตือนฟอร์มที่ใกล้ครบกำหนด และ escalate ฟอร์มที่เกินกำหนด (แต่ละขั้นส่งชนิดละครั้ง)
+	RunCheck(ctx context.Context) (*sladto.SLACheckResult, error)
+	// GetOverdue ฟอร์มที่เกินกำหนด campusID/formStatusID เป็น 0 = ทั้งหมด
+	GetOverdue(ctx context.Context, campusID int, formStatusID int) (*sladto.OverdueDashboardResponse, error)
+}
+
+type slaService struct {
+	repo                repository.SLARepository
+	notificationRepo    repository.NotificationRepository
+	notificationService NotificationService
+
+	running sync.Mutex
+}
+
+func NewSLAService(repo repository.SLARepository, notificationRepo repository.NotificationRepository, ns NotificationService) SLAService {
+	return &slaService{repo: repo, notificationRepo: notificationRepo, notificationService: ns}
+}
+
+func (s *slaService) GetPolicies(ctx context.Context) ([]models.ApprovalSLA, error) {
+	return s.repo.GetPolicies(ctx)
+}
+
+func (s *slaService) UpdatePolicy(ctx context.Context, formStatusID int, req sladto.UpdateSLAPolicyRequest, updatedBy uint) (*models.ApprovalSLA, error) {
+	policy, err := s.repo.GetPolicy(ctx, formStatusID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("sla policy not found")
+		}
+		return nil, err
+	}
+
+	if req.DurationHours != nil {
+		policy.DurationHours = *req.DurationHours
+	}
+	if req.ReminderBeforeHours != nil {
+		policy.ReminderBeforeHours = *req.ReminderBeforeHours
+	}
+	if req.EscalateTo != nil {
+		policy.EscalateTo = strings.TrimSpace(*req.EscalateTo)
+	}
+
+	if policy.DurationHours < 0 || policy.DurationHours > maxSLAHours {
+		return nil, fmt.Errorf("duration_hours must be between 0 and %d", maxSLAHours)
+	}
+	if policy.ReminderBeforeHours < 0 || (policy.DurationHours > 0 && policy.ReminderBeforeHours >= policy.DurationHours) {
+		return nil, errors.New("reminder_before_hours must be less than duration_hours")
+	}
+	if policy.EscalateTo != models.SLAEscalateNextLevel && policy.EscalateTo != models.SLAEscalateStudentDevelopment {
+		return nil, fmt.Errorf("escalate_to must be %s or %s", models.SLAEscalateNextLevel, models.SLAEscalateStudentDevelopment)
+	}
+
+	policy.UpdatedBy = &updatedBy
+	policy.UpdatedAt = time.Now()
+	if err := s.repo.UpdatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *slaService) RunCheck(ctx context.Context) (*sladto.SLACheckResult, error) {
+	if !s.running.TryLock() {
+		return nil, errors.New("an sla check is already in progress")
+	}
+	defer s.running.Unlock()
+
+	policies, err := s.repo.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &sladto.SLACheckResult{CheckedAt: now}
+	for _, policy := range policies {
+		if policy.DurationHours <= 0 {
+			continue
+		}
+		duration := time.Duration(policy.DurationHours) * time.Hour
+		remindAfter := duration
+		if policy.ReminderBeforeHours > 0 && policy.ReminderBeforeHours < policy.DurationHours {
+			remindAfter = duration - time.Duration(policy.ReminderBeforeHours)*time.Hour
+		}
+
+		forms, err := s.repo.FindFormsInStepSince(ctx, policy.FormStatusID, 0, now.Add(-remindAfter))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, form := range forms {
+			dueAt := form.StepStartedAt.Add(duration)
+			approvers, err := approverUserIDs(ctx, s.notificationRepo, form, policy.FormStatusID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !now.Before(dueAt) {
+				targets, err := s.escalationTargets(ctx, policy, form)
+				if err != nil {
+					return nil, err
+				}
+				reason := fmt.Sprintf("ขั้น %s เกินกำหนดมาแล้ว %d ชั่วโมง (กำหนด %s)", policy.StepName, int(now.Sub(dueAt).Hours()), dueAt.Format("02/01/2006 15:04"))
+				sent, err := s.alert(ctx, form, policy, models.SLAAlertEscalation, NotificationEventSLAEscalated, mergeUserIDs(approvers, targets), reason)
+				if err != nil {
+					return nil, err
+				}
+				if sent {
+					result.Escalations++
+				}
+				continue
+			}
+
+			reason := fmt.Sprintf("ขั้น %s ครบกำหนดการพิจารณา %s", policy.StepName, dueAt.Format("02/01/2006 15:04"))
+			sent, err := s.alert(ctx, form, policy, models.SLAAlertReminder, NotificationEventSLAReminder, approvers, reason)
+			if err != nil {
+				return nil, err
+			}
+			if sent {
+				result.Reminders++
+			}
+		}
+	}
+	return result, nil
+}
+
+// alert บันทึกการแจ้งเตือนก่อนส่ง ถ้าเคยบันทึกแล้ว (รอบก่อนหรือ replica อื่น) จะไม่ส่งซ้ำ
+func (s *slaService) alert(ctx context.Context, form models.AwardForm, policy models.ApprovalSLA, kind string, eventType string, recipients []uint, reason string) (bool, error) {
+	created, err := s.repo.CreateAlert(ctx, &models.ApprovalSLAAlert{
+		FormID:         form.FormID,
+		FormStatusID:   policy.FormStatusID,
+		StepStartedAt:  form.StepStartedAt,
+		Kind:           kind,
+		RecipientCount: len(recipients),
+		CreatedAt:      time.Now(),
+	})
+	if err != nil || !created {
+		return false, err
+	}
+
+	if s.notificationService != nil && len(recipients) > 0 {
+		s.notificationService.Publish(ctx, NotificationEvent{
+			Type:         eventType,
+			Form:         form,
+			FormStatusID: policy.FormStatusID,
+			Reason:       reason,
+			Recipients:   recipients,
+		})
+	}
+	return true, nil
+}
+
+// slaNextLevelStatus สถานะของขั้นถัดไปที่ใช้ escalate แบบ next_level
+// ขั้นตั้งแต่กองพัฒนานิสิตขึ้นไปไม่มีผู้บังคับบัญชาในระบบ จึง escalate ไปกองพัฒนานิสิต
+var slaNextLevelStatus = map[int]int{
+	1: 2,
+	2: 4,
+	4: 6,
+}
+
+func (s *slaService) escalationTargets(ctx context.Context, policy models.ApprovalSLA, form models.AwardForm) ([]uint, error) {
+	if next, ok := slaNextLevelStatus[policy.FormStatusID]; ok && policy.EscalateTo == models.SLAEscalateNextLevel {
+		targets, err := approverUserIDs(ctx, s.notificationRepo, form, next)
+		if err != nil {
+			return nil, err
+		}
+		if len(targets) > 0 {
+			return targets, nil
+		}
+	}
+	return s.notificationRepo.GetUserIDsByRoleAndCampus(ctx, 5, form.CampusID)
+}
+
+func (s *slaService) GetOverdue(ctx context.Context, campusID int, formStatusID int) (*sladto.OverdueDashboardResponse, error) {
+	policies, err := s.repo.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &sladto.OverdueDashboardResponse{
+		GeneratedAt: now,
+		Steps:       []sladto.OverdueStep{},
+		Approvers:   []sladto.OverdueApprover{},
+	}
+
+	// ผู้พิจารณาของฟอร์มในภาค/คณะ/วิทยาเขตเดียวกันเป็นชุดเดียวกัน จึง cache ไว้ลดจำนวน query
+	approverCache := make(map[string][]uint)
+	approverForms := make(map[uint][]uint)
+
+	for _, policy := range policies {
+		if policy.DurationHours <= 0 || (formStatusID > 0 && policy.FormStatusID != formStatusID) {
+			continue
+		}
+		duration := time.Duration(policy.DurationHours) * time.Hour
+
+		forms, err := s.repo.FindFormsInStepSince(ctx, policy.FormStatusID, campusID, now.Add(-duration))
+		if err != nil {
+			return nil, err
+		}
+		if len(forms) == 0 {
+			continue
+		}
+
+		formIDs := make([]uint, 0, len(forms))
+		for _, form := range forms {
+			formIDs = append(formIDs, form.FormID)
+		}
+		escalated, err := s.repo.GetEscalatedFormIDs(ctx, formIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		step := sladto.OverdueStep{
+			FormStatusID:  policy.FormStatusID,
+			StepName:      policy.StepName,
+			DurationHours: policy.DurationHours,
+			OverdueCount:  len(forms),
+			Forms:         make([]sladto.OverdueForm, 0, len(forms)),
+		}
+		for _, form := range forms {
+			key := fmt.Sprintf("%d:%d:%d:%d", policy.FormStatusID, form.CampusID, form.FacultyID, form.DepartmentID)
+			approvers, ok := approverCache[key]
+			if !ok {
+				approvers, err = approverUserIDs(ctx, s.notificationRepo, form, policy.FormStatusID)
+				if err != nil {
+					return nil, err
+				}
+				approverCache[key] = approvers
+			}
+			for _, id := range approvers {
+				approverForms[id] = append(approverForms[id], form.FormID)
+			}
+
+			dueAt := form.StepStartedAt.Add(duration)
+			step.Forms = append(step.Forms, sladto.OverdueForm{
+				FormID:        form.FormID,
+				StudentName:   strings.TrimSpace(form.StudentFirstname + " " + form.StudentLastname),
+				StudentNumber: form.StudentNumber,
+				AwardType:     form.AwardType,
+				CampusID:      form.CampusID,
+				FacultyID:     form.FacultyID,
+				DepartmentID:  form.DepartmentID,
+				LatestUpdate:  form.LatestUpdate,
+				StepStartedAt: form.StepStartedAt,
+				DueAt:         dueAt,
+				OverdueHours:  int(now.Sub(dueAt).Hours()),
+				Escalated:     escalated[form.FormID],
+				ApproverIDs:   approvers,
+			})
+		}
+
+		response.TotalOverdue += len(forms)
+		response.Steps = append(response.Steps, step)
+	}
+
+	approverIDs := make([]uint, 0, len(approverForms))
+	for id := range approverForms {
+		approverIDs = append(approverIDs, id)
+	}
+	users, err := s.notificationRepo.GetUsersByIDs(ctx, approverIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		formIDs := approverForms[user.UserID]
+		response.Approvers = append(response.Approvers, sladto.OverdueApprover{
+			UserID:       user.UserID,
+			Name:         strings.TrimSpace(user.Prefix + user.Firstname + " " + user.Lastname),
+			Email:        user.Email,
+			RoleID:       user.RoleID,
+			OverdueCount: len(formIDs),
+			FormIDs:      formIDs,
+		})
+	}
+	sort.Slice(response.Approvers, func(i, j int) bool {
+		if response.Approvers[i].OverdueCount != response.Approvers[j].OverdueCount {
+			return response.Approvers[i].OverdueCount > response.Approvers[j].OverdueCount
+		}
+		return response.Approvers[i].UserID < response.Approvers[j].UserID
+	})
+
+	return response, nil
+}
+
+func mergeUserIDs(groups ...[]uint) []uint {
+	seen := make(map[uint]bool)
+	merged := []uint{}
+	for _, group := range groups {
+		for _, id := range group {
+			if id != 0 && !seen[id] {
+				seen[id] = true
+				merged = append(merged, id)
+			}
+		}
+	}
+	return merged
+}
@@ -12,6 +12,7 @@ type StudentService interface {
 	CreateStudent(ctx context.Context, userID uint, req *studentDTO.CreateStudentRequest) (*models.Student, error)
 	GetStudentByID(ctx context.Context, id uint) (*models.Student, error)
 	GetStudentByUserID(ctx context.Context, userID uint) (*models.Student, error)
+	GetStudentByStudentNumber(ctx context.Context, studentNumber string) (*models.Student, error)
 	GetAllStudents(ctx context.Context) ([]models.Student, error)
 	UpdateStudent(ctx context.Context, id uint, req *studentDTO.CreateStudentRequest) (*models.Student, error)
 	DeleteStudent(ctx context.Context, id uint) error
@@ -52,6 +53,10 @@ func (s *studentService) GetStudentByUserID(ctx context.Context, userID uint) (*
 	return s.repo.GetByUserID(ctx, userID)
 }
 
+func (s *studentService) GetStudentByStudentNumber(ctx context.Context, studentNumber string) (*models.Student, error) {
+	return s.repo.GetByStudentNumber(ctx, studentNumber)
+}
+
 func (s *studentService) GetAllStudents(ctx context.Context) ([]models.Student, error) {
 	return s.repo.GetAll(ctx)
 }
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	verificationdto "backend/internal/dto/verification_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// formStatusCompleted คือสถานะ "เสร็จสิ้น" (อธิการบดีลงนามแล้ว)
+const formStatusCompleted = 12
+
+var verificationEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type VerificationService interface {
+	IssueForForm(ctx context.Context, formID uint) (*models.AwardVerification, error)
+	GetByFormID(ctx context.Context, formID uint, viewer *models.User) (*verificationdto.VerificationCodeResponse, error)
+	Verify(ctx context.Context, code string) (*verificationdto.PublicVerificationResponse, error)
+	Revoke(ctx context.Context, formID uint, reason string, revokedBy uint) error
+}
+
+type verificationService struct {
+	repo      repository.AwardVerificationRepository
+	awardRepo *repository.AwardRepository
+}
+
+func NewVerificationService(repo repository.AwardVerificationRepository, awardRepo *repository.AwardRepository) VerificationService {
+	return &verificationService{repo: repo, awardRepo: awardRepo}
+}
+
+// IssueForForm ออกรหัสตรวจสอบให้ฟอร์มที่เสร็จสิ้นแล้ว ถ้ามีอยู่แล้วจะคืนค่าเดิม
+func (s *verificationService) IssueForForm(ctx context.Context, formID uint) (*models.AwardVerification, error) {
+	existing, err := s.repo.GetByFormID(ctx, formID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	form, err := s.awardRepo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		return nil, err
+	}
+	if form.FormStatusID != formStatusCompleted {
+		return nil, errors.New("verification code is only issued for completed forms")
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &models.AwardVerification{
+		FormID:   formID,
+		Code:     code,
+		IssuedAt: time.Now(),
+	}
+	if err := s.repo.Create(ctx, verification); err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
+
+func (s *verificationService) GetByFormID(ctx context.Context, formID uint, viewer *models.User) (*verificationdto.VerificationCodeResponse, error) {
+	form, err := s.awardRepo.GetByFormID(ctx, int(formID))
+	if err != nil {
+		return nil, errors.New("form not found")
+	}
+	if viewer.RoleID != 5 && !isFormParty(form, viewer.UserID) {
+		return nil, errors.New("forbidden")
+	}
+
+	verification, err := s.IssueForForm(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verificationdto.VerificationCodeResponse{
+		FormID:       verification.FormID,
+		Code:         formatVerificationCode(verification.Code),
+		IssuedAt:     verification.IssuedAt,
+		IsRevoked:    verification.IsRevoked,
+		RevokedAt:    verification.RevokedAt,
+		RevokeReason: verification.RevokeReason,
+	}, nil
+}
+
+func (s *verificationService) Verify(ctx context.Context, code string) (*verificationdto.PublicVerificationResponse, error) {
+	normalized := normalizeVerificationCode(code)
+	if normalized == "" {
+		return nil, errors.New("invalid verification code")
+	}
+
+	row, err := s.repo.GetPublicByCode(ctx, normalized)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("verification code not found")
+		}
+		return nil, err
+	}
+
+	status := "valid"
+	if row.IsRevoked {
+		status = "revoked"
+	}
+
+	return &verificationdto.PublicVerificationResponse{
+		Code:         formatVerificationCode(row.Code),
+		Status:       status,
+		Name:         strings.TrimSpace(strings.TrimSpace(row.Prefix) + " " + strings.TrimSpace(row.StudentFirstname) + " " + strings.TrimSpace(row.StudentLastname)),
+		AwardType:    row.AwardType,
+		AcademicYear: row.AcademicYear,
+		Semester:     row.Semester,
+		CampusName:   row.CampusName,
+		SignedAt:     row.SignedAt,
+		RevokedAt:    row.RevokedAt,
+	}, nil
+}
+
+func (s *verificationService) Revoke(ctx context.Context, formID uint, reason string, revokedBy uint) error {
+	trimmedReason := strings.TrimSpace(reason)
+	if trimmedReason == "" {
+		return errors.New("reason is required")
+	}
+
+	if _, err := s.IssueForForm(ctx, formID); err != nil {
+		return err
+	}
+
+	return s.repo.Revoke(ctx, formID, revokedBy, trimmedReason)
+}
+
+// generateVerificationCode สุ่มรหัส 96 bit (20 ตัวอักษร base32) เพื่อไม่ให้เดาได้
+func generateVerificationCode() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return verificationEncoding.EncodeToString(buf), nil
+}
+
+// normalizeVerificationCode รับได้ทั้งแบบมีขีดและไม่มีขีด ตัวพิมพ์เล็ก/ใหญ่
+func normalizeVerificationCode(code string) string {
+	replacer := strings.NewReplacer("-", "", " ", "")
+	normalized := strings.ToUpper(replacer.Replace(strings.TrimSpace(code)))
+	if len(normalized) != 20 {
+		return ""
+	}
+	if _, err := verificationEncoding.DecodeString(normalized); err != nil {
+		return ""
+	}
+	return normalized
+}
+
+// formatVerificationCode แบ่งรหัสเป็นกลุ่มละ 4 ตัวอักษร เช่น ABCD-EFGH-...
+func formatVerificationCode(code string) string {
+	var groups []string
+	for i := 0; i < len(code); i += 4 {
+		end := i + 4
+		if end > len(code) {
+			end = len(code)
+		}
+		groups = append(groups, code[i:end])
+	}
+	return strings.Join(groups, "-")
+}
@@ -0,0 +1,588 @@
+package usecase
+
+import (
+	webhookdto "backend/internal/dto/webhook_dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookMaxAttempts   = 8
+	webhookTimeout       = 10 * time.Second
+	webhookResponseLimit = 2048
+)
+
+// errWebhookAddressBlocked ปลายทางเป็นที่อยู่ภายใน (loopback, private, link-local) ซึ่งไม่อนุญาตให้ส่ง
+var errWebhookAddressBlocked = errors.New("webhook url must not point to a loopback, private or link-local address")
+
+// webhookSharedAddressSpace 100.64.0.0/10 (carrier-grade NAT) ซึ่ง net.IP.IsPrivate ไม่นับรวม
+var webhookSharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// webhookEvents เหตุการณ์ที่ endpoint เลือกรับได้
+var webhookEvents = map[string]bool{
+	models.WebhookEventAwardSubmitted:     true,
+	models.WebhookEventAwardStatusChanged: true,
+	models.WebhookEventAwardCompleted:     true,
+	models.WebhookEventCommitteeVoteCast:  true,
+}
+
+type WebhookService interface {
+	// Publish สร้าง delivery และงานในคิวให้ทุก endpoint ที่รับเหตุการณ์นี้
+	// ถ้า ctx ผูก transaction ไว้ (repository.WithTx) จะ commit พร้อมการเปลี่ยนแปลงที่เป็นต้นเหตุ
+	Publish(ctx context.Context, eventType string, data interface{}) error
+	// Deliver ส่ง delivery หนึ่งครั้ง finalAttempt = ครั้งสุดท้ายของคิว (ล้มเหลวจะเป็น failed)
+	Deliver(ctx context.Context, deliveryID uint, finalAttempt bool) error
+
+	GetEndpoints(ctx context.Context) ([]webhookdto.WebhookEndpointResponse, error)
+	GetEndpoint(ctx context.Context, endpointID uint) (*webhookdto.WebhookEndpointResponse, error)
+	CreateEndpoint(ctx context.Context, req webhookdto.CreateWebhookRequest, createdBy uint) (*webhookdto.WebhookEndpointResponse, error)
+	UpdateEndpoint(ctx context.Context, endpointID uint, req webhookdto.UpdateWebhookRequest) (*webhookdto.WebhookEndpointResponse, error)
+	DeleteEndpoint(ctx context.Context, endpointID uint) error
+	RotateSecret(ctx context.Context, endpointID uint) (*webhookdto.WebhookEndpointResponse, error)
+
+	// SendTest ส่ง event webhook.test ทันที (ไม่ผ่านคิว ไม่ลองใหม่) แล้วคืนผลการส่ง
+	SendTest(ctx context.Context, endpointID uint) (*webhookdto.WebhookDeliveryResponse, error)
+	GetDeliveries(ctx context.Context, endpointID uint, status string, page, limit int) ([]webhookdto.WebhookDeliveryResponse, int64, error)
+	Redeliver(ctx context.Context, deliveryID uint) (*webhookdto.WebhookDeliveryResponse, error)
+}
+
+type webhookService struct {
+	repo       repository.WebhookRepository
+	jobs       JobService
+	httpClient *http.Client
+}
+
+func NewWebhookService(repo repository.WebhookRepository, jobs JobService) WebhookService {
+	return &webhookService{
+		repo: repo,
+		jobs: jobs,
+		httpClient: &http.Client{
+			Timeout: webhookTimeout,
+			// ตรวจ IP ที่ resolve ได้จริงตอนเชื่อมต่อ กัน DNS ที่ชี้ไปเครือข่ายภายในหลังลงทะเบียน URL
+			// ไม่ใช้ HTTP(S)_PROXY เพราะถ้าผ่าน proxy จะตรวจได้แค่ IP ของ proxy ไม่ใช่ปลายทาง
+			Transport: &http.Transport{
+				Proxy: nil,
+				DialContext: (&net.Dialer{
+					Timeout: webhookTimeout,
+					Control: func(_, address string, _ syscall.RawConn) error {
+						host, _, err := net.SplitHostPort(address)
+						if err != nil {
+							return err
+						}
+						if ip := net.ParseIP(host); ip == nil || isInternalWebhookIP(ip) {
+							return errWebhookAddressBlocked
+						}
+						return nil
+					},
+				}).DialContext,
+				TLSHandshakeTimeout: webhookTimeout,
+			},
+			// ไม่ตาม redirect เพื่อให้ส่งไปเฉพาะ URL ที่ผู้ดูแลลงทะเบียนไว้
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+func (s *webhookService) Publish(ctx context.Context, eventType string, data interface{}) error {
+	endpoints, err := s.repo.GetActiveEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	var subscribers []models.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpointSubscribes(endpoint, eventType) {
+			subscribers = append(subscribers, endpoint)
+		}
+	}
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	event, payload, err := newWebhookEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range subscribers {
+		if _, err := s.enqueueDelivery(ctx, endpoint.EndpointID, event.ID, eventType, payload); err != nil {
+			return fmt.Errorf("enqueue %s for endpoint %d: %w", eventType, endpoint.EndpointID, err)
+		}
+	}
+	return nil
+}
+
+func (s *webhookService) enqueueDelivery(ctx context.Context, endpointID uint, eventID, eventType string, payload []byte) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		EndpointID: endpointID,
+		EventID:    eventID,
+		EventType:  eventType,
+		Payload:    string(payload),
+		Status:     models.WebhookDeliveryPending,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	_, err := s.jobs.Enqueue(ctx, JobTypeWebhookDeliver, map[string]uint{"delivery_id": delivery.DeliveryID}, EnqueueOptions{MaxAttempts: webhookMaxAttempts})
+	if err != nil {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = "enqueue failed: " + err.Error()
+		_ = s.repo.UpdateDelivery(ctx, delivery)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *webhookService) Deliver(ctx context.Context, deliveryID uint, finalAttempt bool) error {
+	delivery, err := s.repo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PermanentJobError(fmt.Errorf("webhook delivery %d not found", deliveryID))
+		}
+		return err
+	}
+	if delivery.Status == models.WebhookDeliverySuccess {
+		return nil
+	}
+
+	endpoint, err := s.repo.GetEndpointByID(ctx, delivery.EndpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PermanentJobError(fmt.Errorf("webhook endpoint %d not found", delivery.EndpointID))
+		}
+		return err
+	}
+	if !endpoint.IsActive {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = "endpoint is disabled"
+		_ = s.repo.UpdateDelivery(ctx, delivery)
+		return PermanentJobError(errors.New("webhook endpoint is disabled"))
+	}
+
+	sendErr := s.send(ctx, endpoint, delivery)
+	if sendErr != nil {
+		delivery.Status = models.WebhookDeliveryRetrying
+		var permanent *permanentJobError
+		if finalAttempt || errors.As(sendErr, &permanent) {
+			delivery.Status = models.WebhookDeliveryFailed
+		}
+	}
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// send POST payload ไปยัง endpoint และบันทึกผลลง delivery (ยังไม่ save)
+// header X-Webhook-Signature = "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+func (s *webhookService) send(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) error {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return PermanentJobError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "award-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.DeliveryID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(endpoint.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	delivery.DurationMs = time.Since(now).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		if errors.Is(err, errWebhookAddressBlocked) {
+			return PermanentJobError(err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = strings.ToValidUTF8(string(responseBody), "")
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		delivered := time.Now()
+		delivery.Status = models.WebhookDeliverySuccess
+		delivery.DeliveredAt = &delivered
+		return nil
+	}
+
+	err = fmt.Errorf("endpoint responded with HTTP %d", resp.StatusCode)
+	delivery.Error = err.Error()
+	// 410 Gone = ผู้รับแจ้งว่าไม่ต้องการรับอีก ไม่ต้องลองใหม่
+	if resp.StatusCode == http.StatusGone {
+		return PermanentJobError(err)
+	}
+	return err
+}
+
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookEvent(eventType string, data interface{}) (*webhookdto.WebhookEvent, []byte, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, nil, err
+	}
+	event := &webhookdto.WebhookEvent{
+		ID:        "evt_" + id,
+		Type:      eventType,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	return event, payload, nil
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (s *webhookService) GetEndpoints(ctx context.Context) ([]webhookdto.WebhookEndpointResponse, error) {
+	endpoints, err := s.repo.GetEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]webhookdto.WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		responses = append(responses, mapToWebhookEndpointResponse(endpoint, false))
+	}
+	return responses, nil
+}
+
+func (s *webhookService) GetEndpoint(ctx context.Context, endpointID uint) (*webhookdto.WebhookEndpointResponse, error) {
+	endpoint, err := s.getEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	response := mapToWebhookEndpointResponse(*endpoint, false)
+	return &response, nil
+}
+
+func (s *webhookService) getEndpoint(ctx context.Context, endpointID uint) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpointByID(ctx, endpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook endpoint not found")
+		}
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *webhookService) CreateEndpoint(ctx context.Context, req webhookdto.CreateWebhookRequest, createdBy uint) (*webhookdto.WebhookEndpointResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	endpointURL, err := validateWebhookURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	endpoint := &models.WebhookEndpoint{
+		Name:      name,
+		URL:       endpointURL,
+		Secret:    "whsec_" + secret,
+		Events:    events,
+		IsActive:  req.IsActive == nil || *req.IsActive,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	response := mapToWebhookEndpointResponse(*endpoint, true)
+	return &response, nil
+}
+
+func (s *webhookService) UpdateEndpoint(ctx context.Context, endpointID uint, req webhookdto.UpdateWebhookRequest) (*webhookdto.WebhookEndpointResponse, error) {
+	endpoint, err := s.getEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		endpoint.Name = name
+	}
+	if req.URL != nil {
+		endpointURL, err := validateWebhookURL(*req.URL)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.URL = endpointURL
+	}
+	if req.Events != nil {
+		events, err := normalizeWebhookEvents(*req.Events)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Events = events
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+
+	endpoint.UpdatedAt = time.Now()
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	response := mapToWebhookEndpointResponse(*endpoint, false)
+	return &response, nil
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, endpointID uint) error {
+	if err := s.repo.DeleteEndpoint(ctx, endpointID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("webhook endpoint not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *webhookService) RotateSecret(ctx context.Context, endpointID uint) (*webhookdto.WebhookEndpointResponse, error) {
+	endpoint, err := s.getEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Secret = "whsec_" + secret
+	endpoint.UpdatedAt = time.Now()
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	response := mapToWebhookEndpointResponse(*endpoint, true)
+	return &response, nil
+}
+
+func (s *webhookService) SendTest(ctx context.Context, endpointID uint) (*webhookdto.WebhookDeliveryResponse, error) {
+	endpoint, err := s.getEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, payload, err := newWebhookEvent(models.WebhookEventTest, map[string]interface{}{
+		"endpoint_id": endpoint.EndpointID,
+		"message":     "ทดสอบการส่ง webhook",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		EndpointID: endpoint.EndpointID,
+		EventID:    event.ID,
+		EventType:  models.WebhookEventTest,
+		Payload:    string(payload),
+		Status:     models.WebhookDeliveryPending,
+		IsTest:     true,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	if err := s.send(ctx, endpoint, delivery); err != nil {
+		delivery.Status = models.WebhookDeliveryFailed
+	}
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	response := mapToWebhookDeliveryResponse(*delivery)
+	return &response, nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, endpointID uint, status string, page, limit int) ([]webhookdto.WebhookDeliveryResponse, int64, error) {
+	if _, err := s.getEndpoint(ctx, endpointID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := s.repo.GetDeliveries(ctx, endpointID, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]webhookdto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, mapToWebhookDeliveryResponse(delivery))
+	}
+	return responses, total, nil
+}
+
+// Redeliver ส่ง event เดิม (EventID เดิม) อีกครั้งเป็น delivery ใหม่ผ่านคิว
+func (s *webhookService) Redeliver(ctx context.Context, deliveryID uint) (*webhookdto.WebhookDeliveryResponse, error) {
+	original, err := s.repo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, err
+	}
+	if original.IsTest {
+		return nil, errors.New("test deliveries cannot be redelivered")
+	}
+
+	delivery, err := s.enqueueDelivery(ctx, original.EndpointID, original.EventID, original.EventType, []byte(original.Payload))
+	if err != nil {
+		return nil, err
+	}
+	response := mapToWebhookDeliveryResponse(*delivery)
+	return &response, nil
+}
+
+func validateWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", errors.New("url must be an absolute http or https URL")
+	}
+	// ชื่อโฮสต์ทั่วไปตรวจอีกครั้งตอนส่งจาก IP ที่ resolve ได้
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", errWebhookAddressBlocked
+	}
+	if ip := net.ParseIP(host); ip != nil && isInternalWebhookIP(ip) {
+		return "", errWebhookAddressBlocked
+	}
+	return raw, nil
+}
+
+// isInternalWebhookIP ที่อยู่ที่ไม่ใช่ปลายทางสาธารณะ (loopback, private, link-local, multicast, unspecified, CGNAT)
+func isInternalWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		webhookSharedAddressSpace.Contains(ip)
+}
+
+func normalizeWebhookEvents(events []string) (string, error) {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !webhookEvents[event] {
+			return "", fmt.Errorf("unknown event: %s", event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	if len(normalized) == 0 {
+		return "", errors.New("events is required")
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+func endpointSubscribes(endpoint models.WebhookEndpoint, eventType string) bool {
+	for _, event := range strings.Split(endpoint.Events, ",") {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func mapToWebhookEndpointResponse(endpoint models.WebhookEndpoint, withSecret bool) webhookdto.WebhookEndpointResponse {
+	response := webhookdto.WebhookEndpointResponse{
+		EndpointID: endpoint.EndpointID,
+		Name:       endpoint.Name,
+		URL:        endpoint.URL,
+		Events:     strings.Split(endpoint.Events, ","),
+		IsActive:   endpoint.IsActive,
+		CreatedBy:  endpoint.CreatedBy,
+		CreatedAt:  endpoint.CreatedAt,
+		UpdatedAt:  endpoint.UpdatedAt,
+	}
+	if withSecret {
+		response.Secret = endpoint.Secret
+	}
+	return response
+}
+
+func mapToWebhookDeliveryResponse(delivery models.WebhookDelivery) webhookdto.WebhookDeliveryResponse {
+	return webhookdto.WebhookDeliveryResponse{
+		DeliveryID:     delivery.DeliveryID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		DurationMs:     delivery.DurationMs,
+		IsTest:         delivery.IsTest,
+		CreatedAt:      delivery.CreatedAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
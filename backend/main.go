@@ -2,13 +2,16 @@ package main
 
 import (
 	"backend/config"
+	"backend/internal/fieldcrypto"
 	"backend/internal/models"
 	"backend/internal/server"
 	"backend/migration"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
@@ -20,6 +23,48 @@ func main() {
 		log.Println("Warning: .env file not found, using system environment variables")
 	}
 
+	// "./main init-field-key" สร้างไฟล์ key พร้อม key แรกแล้วจบ (ทำครั้งเดียวตอนติดตั้ง ไม่ทับไฟล์เดิม)
+	if len(os.Args) > 1 && os.Args[1] == "init-field-key" {
+		keyFile, err := fieldcrypto.InitLocalKeyFile(config.LoadFieldEncryptionKeyFile())
+		if err != nil {
+			log.Fatal("Key initialization failed: ", err)
+		}
+		keyID, _, _ := keyFile.ActiveKey()
+		fmt.Printf("✓ Field encryption key file created, active key: %s\n", keyID)
+		return
+	}
+
+	// 1.5 โหลด key สำหรับเข้ารหัสข้อมูลส่วนบุคคลในฟอร์ม (ต้องพร้อมก่อนอ่าน/เขียน Award_Form)
+	// ไม่มีไฟล์ key ถือเป็นข้อผิดพลาด ไม่สร้าง key ใหม่เอง (ข้อมูลเดิมจะถอดรหัสไม่ได้)
+	keyFile, err := fieldcrypto.OpenLocalKeyFile(config.LoadFieldEncryptionKeyFile())
+	if err != nil {
+		log.Fatal("Failed to load field encryption keys (run \"./main init-field-key\" on first install): ", err)
+	}
+	fieldcrypto.Configure(keyFile)
+
+	// SIGHUP อ่านไฟล์ key ใหม่ เช่น หลังหมุน key ด้วยโปรเซสอื่น
+	reloadKeys := make(chan os.Signal, 1)
+	signal.Notify(reloadKeys, syscall.SIGHUP)
+	go func() {
+		for range reloadKeys {
+			if err := keyFile.Reload(); err != nil {
+				log.Println("Warning: failed to reload field encryption keys:", err)
+				continue
+			}
+			log.Println("Field encryption keys reloaded")
+		}
+	}()
+
+	// "./main rotate-field-key" สร้าง key ใหม่และตั้งเป็น active แล้วจบ (ตามด้วย reencrypt-fields)
+	if len(os.Args) > 1 && os.Args[1] == "rotate-field-key" {
+		keyID, err := keyFile.Rotate()
+		if err != nil {
+			log.Fatal("Key rotation failed: ", err)
+		}
+		fmt.Printf("✓ Field encryption key rotated, active key: %s\n", keyID)
+		return
+	}
+
 	// 2. เชื่อมต่อ Database และทำ Auto Migration
 	// ตรวจสอบให้แน่ใจว่าใน config/db.go มีการคืนค่า *gorm.DB ออกมา
 	db := config.ConnectDB()
@@ -30,15 +75,22 @@ func main() {
 		&models.User{},
 		&models.Campus{},
 		&models.AcademicYear{},
+		&models.TermType{},
+		&models.SubmissionWindow{},
+		&models.AcademicYearTransition{},
+		&models.AcademicYearCommittee{},
 		&models.Faculty{},
 		&models.Department{},
 		&models.Student{},
 		&models.StudentDevelopment{},
 		&models.AwardForm{},
+		&models.AwardType{},
+		&models.AwardTypeYear{},
 		&models.AwardApprovalLog{},
 		&models.CommitteeVoteLog{},
 		&models.AwardSignedLog{},
 		&models.AwardTypeLog{},
+		&models.AwardStatusLog{},
 		&models.AwardFileDirectory{},
 		&models.FormStatus{},
 		&models.Committee{},
@@ -47,6 +99,24 @@ func main() {
 		&models.HeadOfDepartment{},
 		&models.Chancellor{},
 		&models.Organization{},
+		&models.AwardVerification{},
+		&models.SignerKey{},
+		&models.RetentionPolicy{},
+		&models.RetentionPurgeRun{},
+		&models.RetentionPurgeItem{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.ApprovalSLA{},
+		&models.ApprovalSLAAlert{},
+		&models.Job{},
+		&models.JobSchedule{},
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+		&models.Announcement{},
+		&models.AnnouncementVersion{},
+		&models.AnnouncementItem{},
+		&models.PublicationPreference{},
+		&models.ErasureRequest{},
 	); err != nil {
 		log.Fatal("Migration failed: ", err)
 	}
@@ -86,6 +156,71 @@ func main() {
 	}
 	fmt.Println("✓ Faculty and Department seeded successfully")
 
+	// 2.10 Seed นโยบายการเก็บรักษาข้อมูลส่วนบุคคล
+	fmt.Println("Seeding RetentionPolicy data...")
+	if err := migration.SeedRetentionPolicies(db); err != nil {
+		log.Fatal("Seeding RetentionPolicy failed: ", err)
+	}
+	fmt.Println("✓ RetentionPolicy seeded successfully")
+
+	// 2.11 Seed SLA ของแต่ละขั้นการพิจารณา
+	fmt.Println("Seeding ApprovalSLA data...")
+	if err := migration.SeedApprovalSLAs(db); err != nil {
+		log.Fatal("Seeding ApprovalSLA failed: ", err)
+	}
+	fmt.Println("✓ ApprovalSLA seeded successfully")
+
+	// 2.12 Seed งานตามรอบเวลา (sla-check และ retention แบบ dry_run เปิดไว้ แก้ได้ผ่าน /api/jobs/schedules)
+	fmt.Println("Seeding JobSchedule data...")
+	if err := migration.SeedJobSchedules(db); err != nil {
+		log.Fatal("Seeding JobSchedule failed: ", err)
+	}
+	fmt.Println("✓ JobSchedule seeded successfully")
+
+	// 2.13 Seed แคตตาล็อกประเภทรางวัล และผูกฟอร์มเดิมเข้ากับประเภทรางวัล
+	fmt.Println("Seeding AwardType data...")
+	if err := migration.SeedAwardTypes(db); err != nil {
+		log.Fatal("Seeding AwardType failed: ", err)
+	}
+	fmt.Println("✓ AwardType seeded successfully")
+
+	// 2.14 แยกผู้เสนอชื่อกับผู้ถูกเสนอชื่อของฟอร์มเดิม และผูกกับข้อมูลนิสิตตามรหัสนิสิต
+	fmt.Println("Seeding Nomination data...")
+	if err := migration.SeedNominations(db); err != nil {
+		log.Fatal("Seeding Nomination failed: ", err)
+	}
+	fmt.Println("✓ Nomination seeded successfully")
+
+	// 2.15 Seed ประเภทภาคการศึกษาของปฏิทินกลาง (เฉพาะเมื่อยังไม่เคยกำหนด)
+	fmt.Println("Seeding TermType data...")
+	if err := migration.SeedTermTypes(db); err != nil {
+		log.Fatal("Seeding TermType failed: ", err)
+	}
+	fmt.Println("✓ TermType seeded successfully")
+
+	// 2.16 เวลาเข้าสู่สถานะปัจจุบันของฟอร์มเดิม (ใช้นับ SLA แทน latest_update)
+	fmt.Println("Seeding StepStartedAt data...")
+	if err := migration.SeedStepStartedAt(db); err != nil {
+		log.Fatal("Seeding StepStartedAt failed: ", err)
+	}
+	fmt.Println("✓ StepStartedAt seeded successfully")
+
+	// "./main reencrypt-fields" เข้ารหัสข้อมูลส่วนบุคคลของฟอร์มเดิมด้วย active key แล้วจบ
+	if len(os.Args) > 1 && os.Args[1] == "reencrypt-fields" {
+		updated, err := migration.ReencryptFormFields(db)
+		if err != nil {
+			log.Fatal("Re-encryption failed: ", err)
+		}
+		fmt.Printf("✓ Re-encrypted %d forms\n", updated)
+		return
+	}
+
+	// โหมด worker: "./main worker" ทำงานในคิวอย่างเดียว ไม่เปิด HTTP server
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		server.RunWorker(db)
+		return
+	}
+
 	// 3. ตั้งค่า Fiber App
 	app := fiber.New(fiber.Config{
 		AppName: "Backend JA",
@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"backend/internal/fieldcrypto"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// encryptedFormColumns คอลัมน์ของ Award_Form ที่ใช้ serializer:encrypted
+var encryptedFormColumns = []string{"student_address", "student_phone_number", "gpa", "student_date_of_birth"}
+
+const reencryptBatchSize = 500
+
+// ReencryptFormFields เข้ารหัสข้อมูลส่วนบุคคลของทุกฟอร์มด้วย active key ปัจจุบัน
+// ใช้หลังเปิดการเข้ารหัสครั้งแรก (ค่า plaintext เดิม) และหลังหมุน key คืนจำนวนฟอร์มที่ถูกเขียนใหม่
+func ReencryptFormFields(db *gorm.DB) (int, error) {
+	updated := 0
+	var lastID uint
+	for {
+		var rows []map[string]interface{}
+		if err := db.Table(`"Award_Form"`).
+			Select(append([]string{"form_id"}, encryptedFormColumns...)).
+			Where("form_id > ?", lastID).
+			Order("form_id").
+			Limit(reencryptBatchSize).
+			Find(&rows).Error; err != nil {
+			return updated, err
+		}
+		if len(rows) == 0 {
+			return updated, nil
+		}
+
+		for _, row := range rows {
+			formID, err := toUint(row["form_id"])
+			if err != nil {
+				return updated, err
+			}
+			lastID = formID
+
+			changes := make(map[string]interface{})
+			for _, column := range encryptedFormColumns {
+				value, ok := row[column].(string)
+				if !ok {
+					continue
+				}
+				stale, err := fieldcrypto.NeedsReencrypt(value)
+				if err != nil {
+					return updated, err
+				}
+				if !stale {
+					continue
+				}
+				plaintext, err := fieldcrypto.Decrypt(value)
+				if err != nil {
+					return updated, fmt.Errorf("form %d %s: %w", formID, column, err)
+				}
+				// GPA 0 เดิมคือไม่มีข้อมูล เก็บเป็น NULL เหมือน serializer
+				if column == "gpa" && isZeroGPA(plaintext) {
+					changes[column] = nil
+					continue
+				}
+				if changes[column], err = fieldcrypto.Encrypt(plaintext); err != nil {
+					return updated, err
+				}
+			}
+			if len(changes) == 0 {
+				continue
+			}
+
+			// เขียนค่าที่เข้ารหัสแล้วตรง ๆ ไม่ผ่าน model เพื่อไม่ให้ serializer เข้ารหัสซ้ำ
+			if err := db.Table(`"Award_Form"`).Where("form_id = ?", formID).Updates(changes).Error; err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+func isZeroGPA(plaintext string) bool {
+	gpa, err := strconv.ParseFloat(strings.TrimSpace(plaintext), 64)
+	return plaintext == "" || (err == nil && gpa == 0)
+}
+
+func toUint(value interface{}) (uint, error) {
+	switch v := value.(type) {
+	case int64:
+		return uint(v), nil
+	case int32:
+		return uint(v), nil
+	case int:
+		return uint(v), nil
+	case uint:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected form_id type %T", value)
+	}
+}
@@ -2,9 +2,14 @@ package migration
 
 import (
 	"backend/internal/models"
+	"backend/internal/usecase"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func SeedFormStatus(db *gorm.DB) error {
@@ -26,12 +31,20 @@ func SeedFormStatus(db *gorm.DB) error {
 	// ตรวจสอบว่า FormStatus มีข้อมูลอยู่แล้วหรือไม่
 	var count int64
 	db.Model(&models.FormStatus{}).Count(&count)
-	if count > 0 {
-		return nil // ข้อมูล FormStatus มีอยู่แล้ว ไม่ต้องสร้างใหม่
+	if count == 0 {
+		// บันทึก FormStatus ลงฐานข้อมูล
+		if err := db.CreateInBatches(formStatuses, 100).Error; err != nil {
+			return err
+		}
 	}
 
-	// บันทึก FormStatus ลงฐานข้อมูล
-	return db.CreateInBatches(formStatuses, 100).Error
+	// สถานะที่เพิ่มภายหลัง ระบุ ID ตรง ๆ เพื่อให้ฐานข้อมูลเดิมได้รับด้วย
+	addedStatuses := []models.FormStatus{
+		{FormStatusID: 13, FormStatusName: "รอการยินยอมจากนิสิต"}, // องค์กรเสนอชื่อ รอนิสิตตอบรับและกรอกข้อมูลส่วนตัว
+		{FormStatusID: 14, FormStatusName: "นิสิตปฏิเสธการเสนอชื่อ"},
+		{FormStatusID: 15, FormStatusName: "หมดเวลาเนื่องจากปิดภาคเรียน"}, // ฟอร์มที่ยังไม่เสร็จเมื่อปิดภาคเรียน
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&addedStatuses).Error
 }
 
 func SeedCampus(db *gorm.DB) error {
@@ -244,4 +257,238 @@ func SeedFacultyAndDepartments(db *gorm.DB) error {
 	return db.CreateInBatches(departments, 100).Error
 }
 
-func SeedAdmin(db *gorm.DB) error { return nil }
\ No newline at end of file
+// SeedRetentionPolicies เพิ่มนโยบายเริ่มต้นเฉพาะประเภทที่ยังไม่มี (ไม่ทับค่าที่ผู้ดูแลแก้ไขแล้ว)
+func SeedRetentionPolicies(db *gorm.DB) error {
+	now := time.Now()
+	policies := []models.RetentionPolicy{
+		{DataClass: models.RetentionClassContact, RetainYears: 2, Description: "ที่อยู่และเบอร์โทรศัพท์ของนิสิต", UpdatedAt: now},
+		{DataClass: models.RetentionClassDateOfBirth, RetainYears: 2, Description: "วันเกิดของนิสิต", UpdatedAt: now},
+		{DataClass: models.RetentionClassGPA, RetainYears: 5, Description: "เกรดเฉลี่ยที่ใช้ประกอบการพิจารณา", UpdatedAt: now},
+		{DataClass: models.RetentionClassAttachments, RetainYears: 2, Description: "ไฟล์แนบ PDF ของฟอร์ม", UpdatedAt: now},
+		{DataClass: models.RetentionClassIdentity, RetainYears: 10, Description: "ชื่อ อีเมล และรหัสนิสิตในฟอร์ม (แทนด้วยนามแฝง)", UpdatedAt: now},
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&policies).Error
+}
+
+func SeedAdmin(db *gorm.DB) error { return nil }
+// SeedApprovalSLAs เพิ่ม SLA เริ่มต้นของแต่ละขั้นการพิจารณาเฉพาะขั้นที่ยังไม่มี
+func SeedApprovalSLAs(db *gorm.DB) error {
+	now := time.Now()
+	slas := []models.ApprovalSLA{
+		{FormStatusID: 1, StepName: "หัวหน้าภาควิชาพิจารณา", DurationHours: 72, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateNextLevel, UpdatedAt: now},
+		{FormStatusID: 2, StepName: "รองคณบดีพิจารณา", DurationHours: 72, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateNextLevel, UpdatedAt: now},
+		{FormStatusID: 4, StepName: "คณบดีพิจารณา", DurationHours: 72, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateNextLevel, UpdatedAt: now},
+		{FormStatusID: 6, StepName: "กองพัฒนานิสิตพิจารณา", DurationHours: 120, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateStudentDevelopment, UpdatedAt: now},
+		{FormStatusID: 8, StepName: "คณะกรรมการลงมติ", DurationHours: 168, ReminderBeforeHours: 48, EscalateTo: models.SLAEscalateStudentDevelopment, UpdatedAt: now},
+		{FormStatusID: 9, StepName: "ประธานกรรมการลงนาม", DurationHours: 72, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateStudentDevelopment, UpdatedAt: now},
+		{FormStatusID: 11, StepName: "อธิการบดีลงนาม", DurationHours: 120, ReminderBeforeHours: 24, EscalateTo: models.SLAEscalateStudentDevelopment, UpdatedAt: now},
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&slas).Error
+}
+
+// SeedJobSchedules เพิ่มงานตามรอบเวลาเริ่มต้นเฉพาะชื่อที่ยังไม่มี (ไม่ทับค่าที่ผู้ดูแลแก้ไขแล้ว)
+// sla-check เปิดไว้ตั้งแต่แรกเพราะเป็นตัวส่งการเตือนและ escalation
+// retention-purge-nightly เปิดไว้แบบ dry_run ให้มีรายงานทุกคืน ผู้ดูแลเปลี่ยน payload เป็น {"dry_run": false} เมื่อพร้อมลบจริง
+func SeedJobSchedules(db *gorm.DB) error {
+	now := time.Now()
+	schedules := []models.JobSchedule{
+		{Name: "retention-purge-nightly", Type: usecase.JobTypeRetentionPurge, Payload: `{"dry_run": true}`, CronExpr: "0 2 * * *", Enabled: true, UpdatedAt: now},
+		{Name: "sla-check", Type: usecase.JobTypeSLACheck, Payload: `{}`, CronExpr: "*/15 * * * *", Enabled: true, UpdatedAt: now},
+	}
+	for i := range schedules {
+		if !schedules[i].Enabled {
+			continue
+		}
+		next, err := usecase.NextCronRun(schedules[i].CronExpr, now)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", schedules[i].Name, err)
+		}
+		schedules[i].NextRunAt = &next
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&schedules).Error
+}
+
+// SeedTermTypes ปฏิทินกลางเริ่มต้น: ภาคเรียนที่ 1, 2 และภาคฤดูร้อน (ข้ามได้)
+// เพิ่มเฉพาะเมื่อปฏิทินกลางยังไม่มีประเภทภาคการศึกษา เพื่อไม่ทับค่าที่ผู้ดูแลแก้ไขแล้ว
+func SeedTermTypes(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.TermType{}).Where("campus_id = ?", 0).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	termTypes := []models.TermType{
+		{CampusID: 0, Semester: 1, Sequence: 1, Name: "ภาคเรียนที่ 1"},
+		{CampusID: 0, Semester: 2, Sequence: 2, Name: "ภาคเรียนที่ 2"},
+		{CampusID: 0, Semester: 3, Sequence: 3, Name: "ภาคฤดูร้อน", IsOptional: true},
+	}
+
+	return db.Create(&termTypes).Error
+}
+
+// SeedAwardTypes เพิ่มประเภทรางวัลเริ่มต้นเฉพาะ code ที่ยังไม่มี แล้วผูกฟอร์มเดิมเข้ากับแคตตาล็อกจากชื่อประเภทรางวัล
+// ฟอร์มเดิมที่ซ้ำกัน (นิสิต ประเภทรางวัล ภาคเรียนเดียวกัน) ผูกเฉพาะฟอร์มแรก ที่เหลือคง award_type_id ว่างไว้ไม่ให้ชน idx_nominee_award_term
+func SeedAwardTypes(db *gorm.DB) error {
+	now := time.Now()
+	awardTypes := []models.AwardType{
+		{
+			Code:         "EXTRACURRICULAR",
+			NameTH:       "กิจกรรมเสริมหลักสูตร",
+			NameEN:       "Extracurricular Activities",
+			Description:  "ผู้นำ/แข่งขัน",
+			Category:     models.AwardCategoryExtracurricular,
+			IsActive:     true,
+			AllowedRoles: []int{1, 8},
+			LegacyNames:  []string{"กิจกรรมนอกหลักสูตร", "ด้านกิจกรรมเสริมหลักสูตร"},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		{
+			Code:         "CREATIVITY",
+			NameTH:       "ความคิดสร้างสรรค์และนวัตกรรม",
+			NameEN:       "Creativity and Innovation",
+			Description:  "สิ่งประดิษฐ์/วิจัย",
+			Category:     models.AwardCategoryCreativity,
+			IsActive:     true,
+			AllowedRoles: []int{1, 8},
+			LegacyNames:  []string{"ด้านความคิดสร้างสรรค์และนวัตกรรม", "ความคิดสร้างสรรค์เเละนวัตกรรม"},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		{
+			Code:         "GOOD_CONDUCT",
+			NameTH:       "ความประพฤติดี",
+			NameEN:       "Good Conduct",
+			Description:  "จิตอาสา/คุณธรรม",
+			Category:     models.AwardCategoryBehavior,
+			IsActive:     true,
+			AllowedRoles: []int{1, 8},
+			LegacyNames:  []string{"ด้านประพฤติดี"},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&awardTypes).Error; err != nil {
+		return err
+	}
+
+	if err := ensurePartialNomineeIndex(db); err != nil {
+		return err
+	}
+
+	var catalogue []models.AwardType
+	if err := db.Find(&catalogue).Error; err != nil {
+		return err
+	}
+	for _, awardType := range catalogue {
+		names := append([]string{awardType.NameTH}, awardType.LegacyNames...)
+
+		// ฟอร์มที่ปฏิเสธการเสนอชื่อไม่อยู่ใน index ผูกได้ทั้งหมด
+		if err := db.Model(&models.AwardForm{}).
+			Where("award_type_id IS NULL AND form_status_id = 14 AND TRIM(award_type) IN ?", names).
+			Update("award_type_id", awardType.AwardTypeID).Error; err != nil {
+			return err
+		}
+
+		if err := db.Exec(`
+			UPDATE "Award_Form" af
+			SET award_type_id = @award_type_id
+			FROM (
+				SELECT form_id, student_number, academic_year, semester,
+					ROW_NUMBER() OVER (PARTITION BY student_number, academic_year, semester ORDER BY form_id) AS rn
+				FROM "Award_Form"
+				WHERE award_type_id IS NULL AND form_status_id <> 14 AND TRIM(award_type) IN @names
+			) c
+			WHERE af.form_id = c.form_id AND c.rn = 1
+			  AND NOT EXISTS (
+				SELECT 1 FROM "Award_Form" o
+				WHERE o.award_type_id = @award_type_id AND o.form_status_id <> 14
+				  AND o.student_number = c.student_number AND o.academic_year = c.academic_year AND o.semester = c.semester)`,
+			sql.Named("award_type_id", awardType.AwardTypeID), sql.Named("names", names)).Error; err != nil {
+			return err
+		}
+
+		var skipped int64
+		if err := db.Model(&models.AwardForm{}).
+			Where("award_type_id IS NULL AND TRIM(award_type) IN ?", names).
+			Count(&skipped).Error; err != nil {
+			return err
+		}
+		if skipped > 0 {
+			fmt.Printf("Warning: %d duplicate forms of %s were left without award_type_id\n", skipped, awardType.Code)
+		}
+	}
+
+	// รายการประกาศที่สร้างก่อนมีหมวดในแคตตาล็อก
+	return db.Exec(`
+		UPDATE "Announcement_Item" ai
+		SET award_category = COALESCE(t.category, ?)
+		FROM "Award_Form" af
+		LEFT JOIN "Award_Type" t ON t.award_type_id = af.award_type_id
+		WHERE af.form_id = ai.form_id AND COALESCE(ai.award_category, '') = ''
+	`, models.AwardCategoryOther).Error
+}
+
+// ensurePartialNomineeIndex idx_nominee_award_term ที่สร้างก่อนยกเว้นฟอร์มที่ปฏิเสธการเสนอชื่อ สร้างใหม่เป็น partial index
+// AutoMigrate ตรวจ index จากชื่ออย่างเดียว จึงไม่เปลี่ยน index เดิมให้เอง
+func ensurePartialNomineeIndex(db *gorm.DB) error {
+	var indexDef string
+	if err := db.Raw(`SELECT indexdef FROM pg_indexes WHERE indexname = ?`, "idx_nominee_award_term").Scan(&indexDef).Error; err != nil {
+		return err
+	}
+	if indexDef == "" || strings.Contains(indexDef, " WHERE ") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropIndex(&models.AwardForm{}, "idx_nominee_award_term"); err != nil {
+			return err
+		}
+		return tx.Migrator().CreateIndex(&models.AwardForm{}, "idx_nominee_award_term")
+	})
+}
+
+// SeedNominations ปรับฟอร์มเดิมให้แยกผู้เสนอชื่อกับผู้ถูกเสนอชื่อ
+// ฟอร์มที่องค์กรเสนอชื่อผูกกับนิสิตเฉพาะเมื่ออีเมลที่กรอกตรงกับอีเมลบัญชี (เหมือน linkNominations)
+// ลบ unique index เดิม (1 ผู้ส่งต่อภาคเรียน) ที่ทำให้องค์กรเสนอชื่อได้ภาคเรียนละคน
+func SeedNominations(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&models.AwardForm{}, "idx_user_semester") {
+		if err := db.Migrator().DropIndex(&models.AwardForm{}, "idx_user_semester"); err != nil {
+			return err
+		}
+	}
+
+	if err := db.Exec(`
+		UPDATE "Award_Form" af
+		SET submitter_role_id = u.role_id
+		FROM "User" u
+		WHERE u.user_id = af.user_id AND u.role_id = 8 AND af.submitter_role_id <> 8`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		UPDATE "Award_Form" af
+		SET nominee_student_id = s.student_id, nominee_user_id = s.user_id
+		FROM "Student" s
+		JOIN "User" u ON u.user_id = s.user_id
+		WHERE af.nominee_student_id IS NULL
+		  AND af.student_number <> ''
+		  AND s.student_number = af.student_number
+		  AND (af.user_id = s.user_id OR LOWER(TRIM(af.student_email)) = LOWER(TRIM(u.email)))`).Error
+}
+
+// SeedStepStartedAt กำหนดเวลาเข้าสู่สถานะปัจจุบันให้ฟอร์มเดิม จาก Award_Status_Log ล่าสุดของสถานะนั้น
+// ถ้าไม่มีประวัติใช้ latest_update (ค่าที่ SLA เคยใช้นับ)
+func SeedStepStartedAt(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE "Award_Form" af
+		SET step_started_at = COALESCE(
+			(SELECT MAX(l.changed_at) FROM "Award_Status_Log" l
+			 WHERE l.form_id = af.form_id AND l.to_status_id = af.form_status_id),
+			af.latest_update, af.created_at)
+		WHERE af.step_started_at IS NULL`).Error
+}